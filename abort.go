@@ -0,0 +1,24 @@
+package main
+
+import "errors"
+
+// ErrAborted is returned by TypedPool.TryGet when the WithAbortOnGet
+// predicate sheds the call.
+var ErrAborted = errors.New("pool: get aborted")
+
+// WithAbortOnGet sheds load at the pool: every Get first calls predicate,
+// which might check CPU load, queue depth or a feature flag, and if it
+// returns true the Get neither takes an idle item nor constructs one. Get
+// and GetInfo then return the zero value and TryGet returns ErrAborted.
+// Callers must be ready for the zero value; this is a last resort, not flow
+// control.
+func WithAbortOnGet[T any](predicate func() bool) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.abortOnGet = predicate
+	}
+}
+
+// aborted reports whether the WithAbortOnGet predicate sheds this Get.
+func (tp *TypedPool[T]) aborted() bool {
+	return tp.cfg.abortOnGet != nil && tp.cfg.abortOnGet()
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestAbortOnGetShedsWithoutAllocating(t *testing.T) {
+	var overloaded atomic.Bool
+	built := 0
+	pool := NewTypedPool(func() *int { built++; return new(int) },
+		WithFIFO[*int](),
+		WithStats[*int](),
+		WithAbortOnGet[*int](overloaded.Load),
+	)
+	idle := pool.Get()
+	pool.Put(idle)
+
+	overloaded.Store(true)
+	if v := pool.Get(); v != nil {
+		t.Fatalf("Get while overloaded = %v, want nil", v)
+	}
+	if v, err := pool.TryGet(); !errors.Is(err, ErrAborted) || v != nil {
+		t.Fatalf("TryGet while overloaded = %v, %v; want nil, ErrAborted", v, err)
+	}
+	if built != 1 || pool.Len() != 1 {
+		t.Fatalf("constructor calls = %d, idle = %d; want 1 and the item left idle", built, pool.Len())
+	}
+	if s := pool.Stats(); s.Gets != 1 {
+		t.Fatalf("Gets = %d, want shed calls left uncounted", s.Gets)
+	}
+
+	overloaded.Store(false)
+	if v, err := pool.TryGet(); err != nil || v != idle {
+		t.Fatalf("TryGet after recovery = %v, %v; want the idle item", v, err)
+	}
+}
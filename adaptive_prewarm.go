@@ -0,0 +1,52 @@
+package main
+
+import "time"
+
+// defaultPrewarmPoll is how often WithAdaptivePrewarm polls its trigger
+// unless WithAdaptivePrewarmInterval says otherwise.
+const defaultPrewarmPoll = 100 * time.Millisecond
+
+// WithAdaptivePrewarm warms the pool on demand rather than on a schedule: a
+// background goroutine polls trigger and runs Warmup(n) each time it returns
+// true. trigger is the application's signal that a burst is coming, such as
+// a deep queue or a drained rate limiter, and must be cheap, since it is
+// called every poll interval for the life of the pool.
+func WithAdaptivePrewarm[T any](trigger func() bool, n int) PoolOption[T] {
+	if trigger == nil || n < 1 {
+		panic("WithAdaptivePrewarm: trigger must not be nil and n must be at least 1")
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.prewarmTrigger, cfg.prewarmN = trigger, n
+	}
+}
+
+// WithAdaptivePrewarmInterval sets how often WithAdaptivePrewarm polls its
+// trigger; the default is 100ms.
+func WithAdaptivePrewarmInterval[T any](d time.Duration) PoolOption[T] {
+	if d <= 0 {
+		panic("WithAdaptivePrewarmInterval: interval must be positive")
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.prewarmPoll = d
+	}
+}
+
+// startAdaptivePrewarm runs the WithAdaptivePrewarm poll loop until Close.
+func (tp *TypedPool[T]) startAdaptivePrewarm() {
+	poll := tp.cfg.prewarmPoll
+	if poll <= 0 {
+		poll = defaultPrewarmPoll
+	}
+	tp.bg.run(tp.cfg.schedule, func(stop <-chan struct{}) {
+		for {
+			select {
+			case <-stop:
+				return
+			case <-after(tp.cfg.clock, poll):
+				if tp.cfg.prewarmTrigger() {
+					tp.Warmup(tp.cfg.prewarmN)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ArditZubaku/go-sync-pool/pooltest"
+)
+
+func TestAdaptivePrewarm(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Unix(0, 0))
+	var burst, polls atomic.Int64
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithAdaptivePrewarm[*int](func() bool {
+			polls.Add(1)
+			return burst.Swap(0) == 1
+		}, 4),
+		WithAdaptivePrewarmInterval[*int](time.Second),
+		WithPoolClock[*int](clock),
+		WithFIFO[*int](),
+	)
+	defer pool.Close()
+
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	clock.Advance(time.Second) // no burst yet
+	waitFor(t, func() bool { return polls.Load() == 1 && clock.Waiters() == 1 })
+	if got := pool.Len(); got != 0 {
+		t.Fatalf("Len() = %d before the trigger fired, want 0", got)
+	}
+
+	burst.Store(1)
+	clock.Advance(time.Second)
+	waitFor(t, func() bool { return polls.Load() == 2 && clock.Waiters() == 1 })
+	if got := pool.Len(); got != 4 {
+		t.Fatalf("Len() = %d after the trigger fired, want 4", got)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// AllocTracker pushes pool counters to an external metrics backend in
+// batches. Get and Put only bump the pool's own atomic counters; FlushAll
+// hands the backend what changed since the last flush, so its shared
+// registry is touched once per counter and flush rather than once per call.
+// Pools join with WithAllocTracker.
+type AllocTracker struct {
+	export func(name string, delta int64)
+
+	mu    sync.Mutex
+	pools []*trackedPool
+}
+
+// trackedPool is one AllocTracker member and the counters last flushed.
+type trackedPool struct {
+	prefix string
+	stats  func() Stats
+	last   Stats
+}
+
+// NewAllocTracker returns an AllocTracker that reports each changed counter
+// to export as its name, such as pool.gets or prefix.gets under
+// WithTelemetryPrefix, and the change since the previous flush.
+func NewAllocTracker(export func(name string, delta int64)) *AllocTracker {
+	return &AllocTracker{export: export}
+}
+
+// WithAllocTracker makes t export the pool's counters until Close, which
+// exports the last changes. It implies WithStats.
+func WithAllocTracker[T any](t *AllocTracker) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.stats = true
+		cfg.tracker = t
+	}
+}
+
+// WithTelemetryFlushInterval calls FlushAll on the pool's WithAllocTracker
+// tracker every d, until Close. Without a tracker it does nothing.
+func WithTelemetryFlushInterval[T any](d time.Duration) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.trackerFlush = d
+	}
+}
+
+// FlushAll exports the counters of every member that changed since the
+// last flush. Calls are serialized, so export never runs concurrently.
+func (t *AllocTracker) FlushAll() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, p := range t.pools {
+		t.flush(p)
+	}
+}
+
+// flush exports what changed in p since its last flush. t.mu must be held.
+func (t *AllocTracker) flush(p *trackedPool) {
+	prefix := p.prefix
+	if prefix == "" {
+		prefix = "pool"
+	}
+	now := p.stats()
+	last := p.last.fields()
+	for i, f := range now.fields() {
+		if delta := f.value - last[i].value; delta != 0 {
+			t.export(prefix+"."+f.name, delta)
+		}
+	}
+	p.last = now
+}
+
+func (t *AllocTracker) register(prefix string, stats func() Stats) *trackedPool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p := &trackedPool{prefix: prefix, stats: stats}
+	t.pools = append(t.pools, p)
+	return p
+}
+
+// unregister exports what changed in p since its last flush and drops it,
+// for a pool being closed.
+func (t *AllocTracker) unregister(p *trackedPool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.flush(p)
+	t.pools = slices.DeleteFunc(t.pools, func(q *trackedPool) bool { return q == p })
+}
+
+// startTracker joins the pool's tracker, until Close, and schedules its
+// flushes.
+func (tp *TypedPool[T]) startTracker() {
+	t := tp.cfg.tracker
+	p := t.register(tp.cfg.telemetryPrefix, tp.Stats)
+	tp.detach = append(tp.detach, func() { t.unregister(p) })
+	if d := tp.cfg.trackerFlush; d > 0 {
+		tp.bg.every(tp.cfg.schedule, tp.cfg.clock, tp.cfg.jitter, d, t.FlushAll)
+	}
+}
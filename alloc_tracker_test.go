@@ -0,0 +1,100 @@
+package main
+
+import (
+	"maps"
+	"sync"
+	"testing"
+	"time"
+)
+
+// metricRecorder sums the deltas an AllocTracker exports.
+type metricRecorder struct {
+	mu     sync.Mutex
+	totals map[string]int64
+	calls  int
+}
+
+func (r *metricRecorder) export(name string, delta int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.totals == nil {
+		r.totals = make(map[string]int64)
+	}
+	r.totals[name] += delta
+	r.calls++
+}
+
+func (r *metricRecorder) snapshot() (map[string]int64, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return maps.Clone(r.totals), r.calls
+}
+
+func TestAllocTrackerFlushesDeltas(t *testing.T) {
+	var rec metricRecorder
+	tracker := NewAllocTracker(rec.export)
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithAllocTracker[*int](tracker), WithTelemetryPrefix[*int]("conns"), WithFIFO[*int]())
+
+	for range 3 {
+		pool.Put(pool.Get())
+	}
+	if _, calls := rec.snapshot(); calls != 0 {
+		t.Fatalf("exported %d times before FlushAll", calls)
+	}
+
+	tracker.FlushAll()
+	totals, calls := rec.snapshot()
+	if totals["conns.gets"] != 3 || totals["conns.hits"] != 2 || totals["conns.misses"] != 1 || totals["conns.puts"] != 3 {
+		t.Fatalf("totals = %v", totals)
+	}
+
+	tracker.FlushAll()
+	if _, again := rec.snapshot(); again != calls {
+		t.Fatalf("an idle flush exported %d more counters", again-calls)
+	}
+
+	pool.Get()
+	tracker.FlushAll()
+	if totals, _ := rec.snapshot(); totals["conns.gets"] != 4 || totals["conns.hits"] != 3 {
+		t.Fatalf("totals after one more Get = %v", totals)
+	}
+}
+
+func TestTelemetryFlushInterval(t *testing.T) {
+	var rec metricRecorder
+	tracker := NewAllocTracker(rec.export)
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithAllocTracker[*int](tracker), WithTelemetryFlushInterval[*int](time.Millisecond))
+	defer pool.Close()
+
+	pool.Get()
+	waitFor(t, func() bool {
+		totals, _ := rec.snapshot()
+		return totals["pool.gets"] == 1
+	})
+}
+
+func TestAllocTrackerUnregistersOnClose(t *testing.T) {
+	var rec metricRecorder
+	tracker := NewAllocTracker(rec.export)
+	pool := NewTypedPool(func() *int { return new(int) }, WithAllocTracker[*int](tracker))
+
+	pool.Get()
+	pool.Close()
+	totals, calls := rec.snapshot()
+	if totals["pool.gets"] != 1 {
+		t.Fatalf("totals after Close = %v, want the last Get exported", totals)
+	}
+
+	pool.Get()
+	tracker.FlushAll()
+	if _, again := rec.snapshot(); again != calls {
+		t.Fatalf("a closed pool exported %d more counters", again-calls)
+	}
+	if n := len(tracker.pools); n != 0 {
+		t.Fatalf("tracker holds %d pools after Close, want 0", n)
+	}
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// asyncBatchBytes is the batch size at which the flusher writes without
+// waiting for the next tick.
+const asyncBatchBytes = 64 << 10
+
+// Overflow selects what an async Logger does when its queue is full.
+type Overflow int
+
+const (
+	// OverflowBlock makes the logging call wait for room in the queue.
+	OverflowBlock Overflow = iota
+	// OverflowDrop discards the line and counts it in Logger.Dropped.
+	OverflowDrop
+)
+
+// WithAsync makes the Logger queue each rendered line, up to queue lines,
+// and return without writing. A background goroutine copies queued lines
+// into one batch buffer and writes it every flushEvery, or sooner once it
+// holds 64 KiB. Close flushes everything queued and stops the goroutine.
+//
+// With WithAdditionalWriter, every writer gets its own queue and goroutine,
+// so a slow writer only fills its own queue.
+func WithAsync(queue int, flushEvery time.Duration, overflow Overflow) LoggerOption {
+	return func(l *Logger) {
+		l.async = &asyncConfig{queue: queue, every: flushEvery, overflow: overflow}
+	}
+}
+
+// asyncConfig holds the WithAsync settings shared by every writer's queue.
+type asyncConfig struct {
+	queue    int
+	every    time.Duration
+	overflow Overflow
+}
+
+// asyncWriter batches pooled lines on a background goroutine.
+type asyncWriter struct {
+	w        io.Writer
+	onError  func(error)
+	entries  chan *bytes.Buffer
+	every    time.Duration
+	overflow Overflow
+	dropped  atomic.Uint64
+	done     chan struct{}
+	flushReq chan chan error
+
+	// mu keeps senders off entries once Close has closed it.
+	mu     sync.RWMutex
+	closed bool
+}
+
+// newAsyncWriter starts a flusher writing to w and reporting write errors
+// to onError.
+func newAsyncWriter(cfg *asyncConfig, w io.Writer, onError func(error)) *asyncWriter {
+	a := &asyncWriter{
+		w:        w,
+		onError:  onError,
+		entries:  make(chan *bytes.Buffer, cfg.queue),
+		every:    cfg.every,
+		overflow: cfg.overflow,
+		done:     make(chan struct{}),
+		flushReq: make(chan chan error),
+	}
+	go a.run()
+	return a
+}
+
+// enqueue hands the pooled line b to the flusher, which returns it to the
+// pool. Lines logged after Close are dropped. Write errors happen later on
+// the flusher and only reach the WithErrorHandler hook.
+func (a *asyncWriter) enqueue(b *bytes.Buffer) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.closed {
+		a.drop(b)
+		return
+	}
+	if a.overflow == OverflowBlock {
+		a.entries <- b
+		return
+	}
+	select {
+	case a.entries <- b:
+	default:
+		a.drop(b)
+	}
+}
+
+func (a *asyncWriter) drop(b *bytes.Buffer) {
+	a.dropped.Add(1)
+	buffPool.Put(b)
+}
+
+func (a *asyncWriter) run() {
+	defer close(a.done)
+
+	batch := buffPool.Get()
+	batch.Reset()
+	defer buffPool.Put(batch)
+
+	ticker := time.NewTicker(a.every)
+	defer ticker.Stop()
+	for {
+		select {
+		case b, ok := <-a.entries:
+			if !ok {
+				a.flush(batch)
+				return
+			}
+			a.add(batch, b)
+		case reply := <-a.flushReq:
+			// Everything enqueued before the request is already in the
+			// channel, so taking what is there now keeps the ordering promise.
+			for drained := false; !drained; {
+				select {
+				case b, ok := <-a.entries:
+					if !ok {
+						drained = true
+						break
+					}
+					a.add(batch, b)
+				default:
+					drained = true
+				}
+			}
+			reply <- a.flush(batch)
+		case <-ticker.C:
+			a.flush(batch)
+		}
+	}
+}
+
+// add copies the queued line b into batch, writing batch once it is large.
+func (a *asyncWriter) add(batch, b *bytes.Buffer) {
+	batch.Write(b.Bytes())
+	buffPool.Put(b)
+	if batch.Len() >= asyncBatchBytes {
+		a.flush(batch)
+	}
+}
+
+// flush writes batch, reporting an error to onError as well as returning it.
+func (a *asyncWriter) flush(batch *bytes.Buffer) error {
+	if batch.Len() == 0 {
+		return nil
+	}
+	err := writeFull(a.w, batch.Bytes())
+	if err != nil && a.onError != nil {
+		a.onError(err)
+	}
+	if debugBuild {
+		poisonWritten(batch.Bytes())
+	}
+	batch.Reset()
+	return err
+}
+
+// sync asks the flusher to write out everything queued so far and waits for
+// it, or for ctx. After close there is nothing left to write.
+func (a *asyncWriter) sync(ctx context.Context) error {
+	reply := make(chan error, 1)
+	select {
+	case a.flushReq <- reply:
+	case <-a.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-reply:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (a *asyncWriter) close() {
+	a.mu.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.entries)
+	}
+	a.mu.Unlock()
+	<-a.done
+}
+
+// Close does what Flush does and then stops the background goroutines of
+// an async Logger, which return their buffers to the pool. Lines logged
+// after Close are dropped in async mode.
+func (l *Logger) Close() error {
+	err := l.Flush(context.Background())
+	for _, s := range l.sinks {
+		if s.async != nil {
+			s.async.close()
+		}
+	}
+	return err
+}
+
+// Dropped returns the number of lines an async Logger has discarded under
+// OverflowDrop or after Close, summed over its writers.
+func (l *Logger) Dropped() uint64 {
+	var n uint64
+	for _, s := range l.sinks {
+		n += s.stats().Dropped
+	}
+	return n
+}
@@ -0,0 +1,84 @@
+package main
+
+// WithAsyncPut makes Put hand items to a background goroutine through a
+// channel buffering up to size items, and return right away. The goroutine
+// runs the WithHealthCheck function, if any, on each item and pools the
+// healthy ones, so an item becomes available again shortly after its Put.
+// When the channel is full, or after Close, Put does the work inline. Close
+// pools whatever is still queued before it returns.
+func WithAsyncPut[T any](size int) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.asyncPutSize = size
+	}
+}
+
+// WithParallelPut moves Put's processing, the WithReset and WithHealthCheck
+// functions and pooling itself, onto workers background goroutines that
+// drain a queue of returned items. Put only queues: when the queue is full
+// it discards the item rather than process it inline as WithAsyncPut does.
+// The queue holds 64 items per worker unless WithAsyncPut sets its size.
+// After Close, Put does the work inline.
+func WithParallelPut[T any](workers int) PoolOption[T] {
+	if workers < 1 {
+		panic("WithParallelPut: workers must be at least 1")
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.asyncPutWorkers = workers
+		cfg.parallelPut = true
+		if cfg.asyncPutSize == 0 {
+			cfg.asyncPutSize = 64 * workers
+		}
+	}
+}
+
+// enqueuePut queues v for the background goroutines and reports whether it
+// took care of v: queued it or, under WithParallelPut, dropped it because
+// the queue was full.
+func (tp *TypedPool[T]) enqueuePut(v T) bool {
+	select {
+	case <-tp.bg.stop:
+		return false
+	default:
+	}
+
+	select {
+	case tp.puts <- v:
+		return true
+	default:
+		if tp.cfg.parallelPut {
+			tp.drop(v)
+			return true
+		}
+		return false
+	}
+}
+
+// drainPuts pools queued items until stop is closed, then pools what is
+// left in the queue.
+func (tp *TypedPool[T]) drainPuts(stop <-chan struct{}) {
+	for {
+		select {
+		case v := <-tp.puts:
+			tp.putChecked(v)
+		case <-stop:
+			for {
+				select {
+				case v := <-tp.puts:
+					tp.putChecked(v)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// putChecked pools v if it passes the health check.
+func (tp *TypedPool[T]) putChecked(v T) {
+	if hc := tp.cfg.healthCheck; hc != nil && !hc(v) {
+		tp.stats.discard()
+		tp.discard(v)
+		return
+	}
+	tp.put(v)
+}
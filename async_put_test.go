@@ -0,0 +1,98 @@
+package main
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestWithAsyncPut(t *testing.T) {
+	var discarded []int
+	pool := NewTypedPool(func() int { return 0 },
+		WithFIFO[int](),
+		WithAsyncPut[int](8),
+		WithHealthCheck(func(v int) bool { return v > 0 }),
+		WithOnDiscard(func(v int) { discarded = append(discarded, v) }),
+	)
+
+	for _, v := range []int{1, -2, 3} {
+		pool.Put(v)
+	}
+	pool.Close() // pools everything still queued
+
+	got := []int{pool.Get(), pool.Get(), pool.Get()}
+	if !slices.Equal(got, []int{1, 3, 0}) {
+		t.Fatalf("got %v, want [1 3 0]", got)
+	}
+	if !slices.Equal(discarded, []int{-2}) {
+		t.Fatalf("discarded %v, want [-2]", discarded)
+	}
+
+	pool.Put(4) // inline after Close
+	if got := pool.Get(); got != 4 {
+		t.Fatalf("Get() after Close = %d, want 4", got)
+	}
+}
+
+func TestWithAsyncPutFullQueueGoesInline(t *testing.T) {
+	var queued []func()
+	sched := SchedulerFunc(func(fn func()) { queued = append(queued, fn) })
+	pool := NewTypedPool(func() int { return 0 },
+		WithFIFO[int](),
+		WithScheduler[int](sched),
+		WithAsyncPut[int](1),
+	)
+
+	pool.Put(1) // queued; the goroutine has not started
+	pool.Put(2) // queue full, pooled inline
+
+	if got := pool.Get(); got != 2 {
+		t.Fatalf("Get() = %d, want the inline item 2", got)
+	}
+
+	done := make(chan struct{})
+	go func() { queued[0](); close(done) }()
+	pool.Close()
+	<-done
+	if got := pool.Get(); got != 1 {
+		t.Fatalf("Get() = %d, want the queued item 1", got)
+	}
+}
+
+func TestWithParallelPutFullQueueDiscards(t *testing.T) {
+	var queued []func()
+	sched := SchedulerFunc(func(fn func()) { queued = append(queued, fn) })
+	var discarded []int
+	pool := NewTypedPool(func() int { return 0 },
+		WithFIFO[int](),
+		WithStats[int](),
+		WithScheduler[int](sched),
+		WithAsyncPut[int](1),
+		WithParallelPut[int](2),
+		WithReset(func(int) {}),
+		WithOnDiscard(func(v int) { discarded = append(discarded, v) }),
+	)
+	if len(queued) != 2 {
+		t.Fatalf("%d workers scheduled, want 2", len(queued))
+	}
+
+	pool.Put(1) // queued; no worker has started
+	pool.Put(2) // queue full, discarded
+	if !slices.Equal(discarded, []int{2}) || pool.Len() != 0 {
+		t.Fatalf("discarded %v with %d pooled, want [2] and none", discarded, pool.Len())
+	}
+
+	var done sync.WaitGroup
+	for _, worker := range queued {
+		done.Add(1)
+		go func() { worker(); done.Done() }()
+	}
+	pool.Close()
+	done.Wait()
+	if got := pool.Get(); got != 1 {
+		t.Fatalf("Get() = %d, want the queued item 1", got)
+	}
+	if s := pool.Stats(); s.Puts != 2 || s.Discards != 1 {
+		t.Fatalf("Stats = %+v, want 2 puts and 1 discard", s)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// gatedWriter signals entered on its first Write and blocks it until release
+// is closed.
+type gatedWriter struct {
+	lockedBuffer
+	once    sync.Once
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (w *gatedWriter) Write(p []byte) (int, error) {
+	w.once.Do(func() {
+		close(w.entered)
+		<-w.release
+	})
+	return w.lockedBuffer.Write(p)
+}
+
+func TestAsyncOrderedDelivery(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(&buf, WithTimeLayout(""), WithAsync(16, time.Millisecond, OverflowBlock))
+
+	const n = 1000
+	for i := range n {
+		logger.Info("line", "i", i)
+	}
+	logger.Close()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != n {
+		t.Fatalf("got %d lines, want %d", len(lines), n)
+	}
+	for i, line := range lines {
+		if want := "INFO : line i=" + strconv.Itoa(i); line != want {
+			t.Fatalf("line %d = %q, want %q", i, line, want)
+		}
+	}
+}
+
+func TestAsyncCloseLosesNothing(t *testing.T) {
+	var buf lockedBuffer
+	logger := NewLogger(&buf, WithTimeLayout(""), WithAsync(4, time.Hour, OverflowBlock))
+
+	const producers, perProducer = 8, 500
+	var wg sync.WaitGroup
+	for p := range producers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perProducer {
+				logger.Info(fmt.Sprintf("p%d-%d", p, i))
+			}
+		}()
+	}
+	wg.Wait()
+	logger.Close()
+	logger.Close()
+
+	if got := strings.Count(buf.String(), "\n"); got != producers*perProducer {
+		t.Fatalf("got %d lines, want %d", got, producers*perProducer)
+	}
+	if d := logger.Dropped(); d != 0 {
+		t.Fatalf("Dropped() = %d, want 0", d)
+	}
+}
+
+func TestAsyncOverflowDrop(t *testing.T) {
+	w := &gatedWriter{entered: make(chan struct{}), release: make(chan struct{})}
+	logger := NewLogger(w, WithTimeLayout(""), WithAsync(2, time.Millisecond, OverflowDrop))
+
+	logger.Info("first")
+	<-w.entered // the flusher is now stuck writing "first"
+
+	for i := range 10 {
+		logger.Info("queued", "i", i)
+	}
+	if d := logger.Dropped(); d != 8 {
+		t.Fatalf("Dropped() = %d, want 8", d)
+	}
+
+	close(w.release)
+	logger.Close()
+
+	want := "INFO : first\nINFO : queued i=0\nINFO : queued i=1\n"
+	if got := w.String(); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	logger.Info("after close")
+	if d := logger.Dropped(); d != 9 {
+		t.Fatalf("Dropped() after Close = %d, want 9", d)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// WithAuditLog writes one JSON record per line to out for every Get, Put,
+// constructor call, and discard. Records are meant for debugging and
+// compliance trails, not hot paths: each one parses the goroutine ID from a
+// stack trace and is encoded with encoding/json.
+func WithAuditLog[T any](out io.Writer) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.stats = true
+		cfg.audit = &auditLog{out: out}
+	}
+}
+
+// AuditRecord is one line of a WithAuditLog stream.
+type AuditRecord struct {
+	Time      int64  `json:"ts"` // Unix nanoseconds
+	Event     string `json:"event"`
+	Goroutine uint64 `json:"goroutine"`
+	Item      uint64 `json:"item"`         // pointer address, 0 for non-pointer items
+	ID        uint64 `json:"id,omitempty"` // under WithMonotonicID
+	Stats     Stats  `json:"stats"`
+}
+
+// auditLog serialises records so concurrent events never interleave.
+type auditLog struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+const (
+	auditGet     = "get"
+	auditPut     = "put"
+	auditNew     = "new"
+	auditDiscard = "discard"
+)
+
+func (a *auditLog) record(at time.Time, event string, item any, id uint64, stats Stats) {
+	rec := AuditRecord{
+		Time:      at.UnixNano(),
+		Event:     event,
+		Goroutine: goroutineID(),
+		Item:      itemIdentity(item),
+		ID:        id,
+		Stats:     stats,
+	}
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	a.mu.Lock()
+	a.out.Write(line)
+	a.mu.Unlock()
+}
+
+// itemIdentity returns the address behind pointer-like items, or 0.
+func itemIdentity(item any) uint64 {
+	v := reflect.ValueOf(item)
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Chan, reflect.Func, reflect.Slice, reflect.UnsafePointer:
+		return uint64(v.Pointer())
+	default:
+		return 0
+	}
+}
+
+// audit records an event for v if the pool has an audit or event log.
+func (tp *TypedPool[T]) audit(event string, v T) {
+	if a := tp.cfg.audit; a != nil {
+		a.record(tp.cfg.now(), event, v, tp.IDOf(v), tp.Stats())
+	}
+	if r := tp.events; r != nil {
+		r.record(PoolEvent{Time: tp.cfg.now(), Kind: event, Item: itemIdentity(v), ID: tp.IDOf(v)})
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestWithAuditLog(t *testing.T) {
+	var out bytes.Buffer
+	pool := NewTypedPool(func() *conn { return &conn{} },
+		WithAuditLog[*conn](&out),
+		WithMaxItems[*conn](1),
+	)
+
+	c := pool.Get()
+	pool.Put(c)
+	pool.Put(&conn{})
+
+	var (
+		events []string
+		recs   []AuditRecord
+	)
+	sc := bufio.NewScanner(&out)
+	for sc.Scan() {
+		var rec AuditRecord
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			t.Fatalf("line %q is not JSON: %v", sc.Text(), err)
+		}
+		events = append(events, rec.Event)
+		recs = append(recs, rec)
+	}
+
+	if want := []string{"new", "get", "put", "discard"}; !slices.Equal(events, want) {
+		t.Fatalf("events = %v, want %v", events, want)
+	}
+	if recs[0].Item == 0 || recs[0].Item != recs[2].Item {
+		t.Fatalf("new and put records disagree on item identity: %d vs %d", recs[0].Item, recs[2].Item)
+	}
+	if recs[0].Goroutine == 0 || recs[0].Time == 0 {
+		t.Fatalf("record missing goroutine or timestamp: %+v", recs[0])
+	}
+	if got := recs[3].Stats.Discards; got != 1 {
+		t.Fatalf("discard record stats.discards = %d, want 1", got)
+	}
+}
+
+func TestGoroutineID(t *testing.T) {
+	main := goroutineID()
+	other := make(chan uint64)
+	go func() { other <- goroutineID() }()
+
+	if id := <-other; main == 0 || id == 0 || id == main {
+		t.Fatalf("goroutine IDs %d and %d should be distinct and non-zero", main, id)
+	}
+}
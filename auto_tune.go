@@ -0,0 +1,69 @@
+package main
+
+import "time"
+
+// autoTuneWindow is how many periods the WithAutoTune average spans.
+const autoTuneWindow = 4
+
+// WithAutoTune adjusts the WithMaxItems limit every interval: when the
+// average peak of items checked out at once over the last few periods is
+// above 90% of the limit it grows by 10%, and when it is below 50% it
+// shrinks by 10%, always within [minSize, maxSize]. The limit starts at the
+// WithMaxItems value, or minSize without one. MaxItems reports the current
+// limit. Call Close to stop tuning.
+func WithAutoTune[T any](interval time.Duration, minSize, maxSize int) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.autoTune = &autoTune{interval: interval, min: int64(minSize), max: int64(maxSize)}
+	}
+}
+
+// autoTune is the WithAutoTune state. The peaks are only touched by the
+// tuning task.
+type autoTune struct {
+	interval time.Duration
+	min, max int64
+	peaks    [autoTuneWindow]int64
+	periods  int
+}
+
+// MaxItems returns the current WithMaxItems limit, as moved by
+// WithAutoTune; 0 means unlimited.
+func (tp *TypedPool[T]) MaxItems() int {
+	return int(tp.maxItems.Load())
+}
+
+// startAutoTune sets the starting limit and schedules the tuning task.
+func (tp *TypedPool[T]) startAutoTune() {
+	at := tp.cfg.autoTune
+	start := int64(tp.cfg.maxItems)
+	if start == 0 {
+		start = at.min
+	}
+	tp.maxItems.Store(min(max(start, at.min), at.max))
+	tp.bg.every(tp.cfg.schedule, tp.cfg.clock, tp.cfg.jitter, at.interval, tp.tune)
+}
+
+// tune closes one period and moves the limit if the average peak calls for
+// it.
+func (tp *TypedPool[T]) tune() {
+	at := tp.cfg.autoTune
+	at.peaks[at.periods%autoTuneWindow] = tp.conc.takePeriodPeak()
+	at.periods++
+
+	var sum int64
+	n := min(at.periods, autoTuneWindow)
+	for _, p := range at.peaks[:n] {
+		sum += p
+	}
+	avg := float64(sum) / float64(n)
+
+	limit := tp.maxItems.Load()
+	step := max(1, limit/10)
+	switch {
+	case avg > 0.9*float64(limit):
+		limit += step
+	case avg < 0.5*float64(limit):
+		limit -= step
+	}
+	tp.maxItems.Store(min(max(limit, at.min), at.max))
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// borrow checks out n items and returns them, so the period peak is n.
+func borrow(pool *TypedPool[*int], n int) {
+	items := make([]*int, n)
+	for i := range items {
+		items[i] = pool.Get()
+	}
+	for _, item := range items {
+		pool.Put(item)
+	}
+}
+
+func TestAutoTuneGrowsAndShrinks(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithMaxItems[*int](20), WithAutoTune[*int](time.Hour, 10, 30))
+	defer pool.Close()
+
+	borrow(pool, 20)
+	pool.tune()
+	if got := pool.MaxItems(); got != 22 {
+		t.Fatalf("MaxItems after a busy period = %d, want 22", got)
+	}
+
+	for range 10 {
+		borrow(pool, 30)
+		pool.tune()
+	}
+	if got := pool.MaxItems(); got != 30 {
+		t.Fatalf("MaxItems = %d, want it clamped to 30", got)
+	}
+
+	for range 20 {
+		pool.tune()
+	}
+	if got := pool.MaxItems(); got != 10 {
+		t.Fatalf("MaxItems after idle periods = %d, want it clamped to 10", got)
+	}
+}
+
+func TestAutoTuneAveragesPeriods(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithMaxItems[*int](20), WithAutoTune[*int](time.Hour, 1, 100))
+	defer pool.Close()
+
+	// The first period grows the limit to 22; after that a quiet period does
+	// not shrink it, since 19, 19, 19 and 0 still average 14.25, above half
+	// of 22.
+	borrow(pool, 19)
+	pool.tune()
+	borrow(pool, 19)
+	pool.tune()
+	borrow(pool, 19)
+	pool.tune()
+	pool.tune()
+	if got := pool.MaxItems(); got != 22 {
+		t.Fatalf("MaxItems = %d, want 22", got)
+	}
+}
+
+func TestAutoTuneLimitsPuts(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithAutoTune[*int](time.Hour, 2, 8), WithFIFO[*int]())
+	defer pool.Close()
+
+	if got := pool.MaxItems(); got != 2 {
+		t.Fatalf("MaxItems = %d, want minSize 2", got)
+	}
+	borrow(pool, 4)
+	if got := pool.Len(); got != 2 {
+		t.Fatalf("Len = %d, want 2 kept under the limit", got)
+	}
+}
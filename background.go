@@ -0,0 +1,85 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// background runs a pool's periodic tasks until the pool is closed.
+type background struct {
+	once sync.Once
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newBackground() *background {
+	return &background{stop: make(chan struct{})}
+}
+
+// every runs fn every interval on sched, after an initial jitter delay,
+// until close is called. The schedule is kept on clock by deadline, so a
+// slow fn does not push later runs back; runs it overlaps are skipped, as a
+// time.Ticker drops ticks.
+func (bg *background) every(sched func(func()), clock Clock, jitter *jitterSource, interval time.Duration, fn func()) {
+	if clock == nil {
+		clock = realClock{}
+	}
+	bg.wg.Add(1)
+	sched(func() {
+		defer bg.wg.Done()
+
+		next := clock.Now().Add(interval + jitter.next())
+		for {
+			select {
+			case <-bg.stop:
+				return
+			case <-after(clock, next.Sub(clock.Now())):
+				fn()
+			}
+			next = next.Add(interval)
+			if behind := clock.Now().Sub(next); behind >= 0 {
+				next = next.Add((behind/interval + 1) * interval)
+			}
+		}
+	})
+}
+
+// run runs fn on sched until it returns; fn should return once stop is
+// closed.
+func (bg *background) run(sched func(func()), fn func(stop <-chan struct{})) {
+	bg.wg.Add(1)
+	sched(func() {
+		defer bg.wg.Done()
+		fn(bg.stop)
+	})
+}
+
+// close stops every task and waits for them to return. It is idempotent.
+func (bg *background) close() {
+	bg.once.Do(func() {
+		close(bg.stop)
+	})
+	bg.wg.Wait()
+}
+
+// Close stops the pool's background tasks, such as the deadlock detector and
+// the WithAsyncPut goroutine, waits for them to finish, and undoes outside
+// registrations such as WithCounterExport's. The pool itself remains
+// usable. Under WithGracefulDrain it first waits for the checked-out items
+// and reports whether they all came back in time; otherwise it reports
+// true.
+func (tp *TypedPool[T]) Close() bool {
+	drained := true
+	if tp.drain != nil {
+		if drained = tp.waitDrained(); !drained {
+			tp.forceClose()
+		}
+	}
+	tp.bg.close()
+	tp.detachOnce.Do(func() {
+		for _, detach := range tp.detach {
+			detach()
+		}
+	})
+	return drained
+}
@@ -0,0 +1,23 @@
+package main
+
+// WithWriteBarrier asks barrierFn on every Put whether the pool is closed to
+// returns; while it reports true, returned items are discarded, passing
+// through the OnDiscard hook, so that misses build fresh items, such as
+// after a config reload. Idle items are still handed out until Drain removes
+// them. Stats().BarrierDiscards counts the items turned away, which tells
+// when every old item that was checked out has come back.
+func WithWriteBarrier[T any](barrierFn func() bool) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.barrierFn = barrierFn
+	}
+}
+
+// barred reports whether the write barrier turns a Put away, counting it if
+// so.
+func (tp *TypedPool[T]) barred() bool {
+	if tp.cfg.barrierFn == nil || !tp.cfg.barrierFn() {
+		return false
+	}
+	tp.barrierDiscards.Add(1)
+	return true
+}
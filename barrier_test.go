@@ -0,0 +1,36 @@
+package main
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestWithWriteBarrier(t *testing.T) {
+	var reloading atomic.Bool
+	var discarded []*int
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithFIFO[*int](),
+		WithWriteBarrier[*int](reloading.Load),
+		WithOnDiscard(func(v *int) { discarded = append(discarded, v) }),
+	)
+
+	old := pool.Get()
+	reloading.Store(true)
+	pool.Put(old)
+	if pool.Len() != 0 || len(discarded) != 1 || discarded[0] != old {
+		t.Fatalf("Put behind the barrier kept the item: Len %d, discarded %v", pool.Len(), discarded)
+	}
+	if got := pool.Stats().BarrierDiscards; got != 1 {
+		t.Fatalf("BarrierDiscards = %d, want 1", got)
+	}
+
+	reloading.Store(false)
+	fresh := pool.Get()
+	if fresh == old {
+		t.Fatal("Get returned the item turned away by the barrier")
+	}
+	pool.Put(fresh)
+	if pool.Len() != 1 {
+		t.Fatalf("Len() = %d after the barrier lifted, want 1", pool.Len())
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import "sync"
+
+// BatchGet fills dst with items from the pool.
+func (tp *TypedPool[T]) BatchGet(dst []T) {
+	for i := range dst {
+		dst[i] = tp.Get()
+	}
+}
+
+// BatchPut returns every item in items to the pool.
+func (tp *TypedPool[T]) BatchPut(items []T) {
+	for _, v := range items {
+		tp.Put(v)
+	}
+}
+
+// GetN returns n items and a release function that puts all of them back.
+// release is idempotent, so it is safe to defer and also call explicitly;
+// the items must not be used after the first call.
+func (tp *TypedPool[T]) GetN(n int) ([]T, func()) {
+	items := make([]T, n)
+	tp.BatchGet(items)
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			tp.BatchPut(items)
+			clear(items)
+		})
+	}
+	return items, release
+}
@@ -0,0 +1,31 @@
+package main
+
+import "testing"
+
+func TestGetNReleaseIsIdempotent(t *testing.T) {
+	var discarded int
+	pool := NewTypedPool(func() int { return 1 },
+		WithStats[int](),
+		WithOnDiscard(func(int) { discarded++ }),
+	)
+
+	items, release := pool.GetN(3)
+	if len(items) != 3 {
+		t.Fatalf("got %d items, want 3", len(items))
+	}
+	for i, v := range items {
+		if v != 1 {
+			t.Fatalf("items[%d] = %d, want 1", i, v)
+		}
+	}
+
+	release()
+	release()
+
+	if s := pool.Stats(); s.Gets != 3 || s.Puts != 3 {
+		t.Fatalf("stats = %+v, want 3 gets and 3 puts", s)
+	}
+	if discarded != 0 {
+		t.Fatalf("%d items discarded, want 0", discarded)
+	}
+}
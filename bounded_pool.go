@@ -0,0 +1,245 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// BoundedPool keeps at most max idle objects in a list it owns. Unlike
+// TypedPool, the GC never drops its contents, so the idle count and retrieval
+// order are deterministic.
+type BoundedPool[T any] struct {
+	mu       sync.Mutex
+	idle     idleRing[T]
+	newFn    func() T
+	cfg      poolConfig[T]
+	retained atomic.Int64
+	used     atomic.Int64
+	stats    *poolStats
+	bg       *background
+}
+
+// NewBoundedPool creates a BoundedPool that retains up to max idle objects.
+func NewBoundedPool[T any](max int, newFn func() T, opts ...PoolOption[T]) *BoundedPool[T] {
+	if max < 1 {
+		panic("BoundedPool: max must be at least 1")
+	}
+
+	bp := &BoundedPool[T]{
+		idle:  newIdleRing[T](max),
+		newFn: newFn,
+		bg:    newBackground(),
+	}
+	for _, opt := range opts {
+		opt(&bp.cfg)
+	}
+	bp.stats = newPoolStats(bp.cfg.stats)
+
+	if g := bp.cfg.group; g != nil {
+		if g.limited() && bp.cfg.sizeFn == nil {
+			panic("BoundedPool: a PoolGroup with a budget requires WithSizeFunc")
+		}
+		g.register(bp)
+	}
+	if bp.cfg.sweepInterval > 0 {
+		if bp.cfg.idleTTL <= 0 {
+			panic("BoundedPool: WithItemTTLSweeper requires WithIdleTTL")
+		}
+		bp.startSweeper()
+	}
+	if bp.cfg.minIdle > 0 {
+		bp.Refill()
+		if bp.cfg.refillInterval > 0 {
+			bp.startRefiller()
+		}
+	}
+
+	return bp
+}
+
+// Get returns an idle object according to the pool's Ordering, or a new one
+// if none is available.
+func (bp *BoundedPool[T]) Get() T {
+	v, _ := bp.GetInfo()
+	return v
+}
+
+// GetInfo is Get, also reporting whether the object was constructed for
+// this call.
+func (bp *BoundedPool[T]) GetInfo() (T, Origin) {
+	if g := bp.cfg.group; g != nil {
+		bp.used.Store(g.touch())
+	}
+
+	bp.mu.Lock()
+	freed := bp.evictExpired()
+
+	var (
+		it idleItem[T]
+		ok bool
+	)
+	if bp.cfg.ordering == FIFO {
+		it, ok = bp.idle.popFront()
+	} else {
+		it, ok = bp.idle.popBack()
+	}
+	if ok {
+		freed += bp.forget(it)
+	}
+	bp.mu.Unlock()
+
+	bp.release(freed)
+
+	if ok {
+		bp.stats.hit()
+		return it.v, OriginReused
+	}
+
+	bp.stats.miss()
+	return bp.newFn(), OriginNew
+}
+
+// Put returns an object to the pool. It is dropped if the pool is full, or
+// if its group or object limit has no budget left for it.
+func (bp *BoundedPool[T]) Put(v T) {
+	if bp.store(v) {
+		bp.stats.put()
+	} else {
+		bp.stats.discard()
+	}
+}
+
+// store pools v, or discards it if there is no room for it, and reports
+// which. It leaves the stats to the caller.
+func (bp *BoundedPool[T]) store(v T) bool {
+	var size int64
+	if bp.cfg.sizeFn != nil {
+		size = bp.cfg.sizeFn(v)
+	}
+	if g := bp.cfg.group; g != nil {
+		bp.used.Store(g.touch())
+		if !g.reserve(size) {
+			bp.discard(v)
+			return false
+		}
+	}
+
+	weight := bp.cfg.objectLimit.weightOf(v)
+
+	bp.mu.Lock()
+	freed := bp.evictExpired()
+	ok := true
+	if bp.cfg.objectLimit != nil {
+		var evicted int64
+		evicted, ok = bp.makeWeightRoom(weight)
+		freed += evicted
+	}
+	if ok {
+		ok = bp.idle.pushBack(idleItem[T]{v: v, since: bp.cfg.now(), weight: weight})
+	}
+	if ok {
+		bp.retained.Add(size)
+		if lim := bp.cfg.objectLimit; lim != nil {
+			lim.total.Add(weight)
+		}
+	} else {
+		bp.discard(v)
+	}
+	bp.mu.Unlock()
+
+	if !ok && bp.cfg.group != nil {
+		freed += size
+	}
+	bp.release(freed)
+	return ok
+}
+
+// Len returns the number of idle objects currently held.
+func (bp *BoundedPool[T]) Len() int {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	return bp.idle.len()
+}
+
+// RetainedBytes returns the total size of idle objects, as reported by the
+// WithSizeFunc function. It is 0 if no size function is set.
+func (bp *BoundedPool[T]) RetainedBytes() int64 {
+	return bp.retained.Load()
+}
+
+func (bp *BoundedPool[T]) lastUsed() int64 {
+	return bp.used.Load()
+}
+
+func (bp *BoundedPool[T]) evictOldest() int64 {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	it, ok := bp.idle.popFront()
+	if !ok {
+		return 0
+	}
+	bp.discard(it.v)
+	return bp.forget(it)
+}
+
+// evictExpired drops idle objects older than the configured TTL and returns
+// the bytes freed. bp.mu must be held.
+func (bp *BoundedPool[T]) evictExpired() int64 {
+	var freed int64
+	for {
+		n, ok := bp.evictFirstExpired()
+		freed += n
+		if !ok {
+			return freed
+		}
+	}
+}
+
+// evictFirstExpired drops the longest-idle object if it is older than the
+// configured TTL, returning the bytes freed and whether it did. The ring is
+// ordered by Put time, so expired objects always sit at the front. bp.mu
+// must be held.
+func (bp *BoundedPool[T]) evictFirstExpired() (int64, bool) {
+	if bp.cfg.idleTTL <= 0 {
+		return 0, false
+	}
+	it, ok := bp.idle.front()
+	if !ok || it.since.After(bp.cfg.now().Add(-bp.cfg.idleTTL)) {
+		return 0, false
+	}
+	bp.idle.popFront()
+	bp.discard(it.v)
+	return bp.forget(it), true
+}
+
+// forget removes an item leaving the idle list from the retained-bytes and
+// weight accounting, and returns its size. bp.mu must be held.
+func (bp *BoundedPool[T]) forget(it idleItem[T]) int64 {
+	if lim := bp.cfg.objectLimit; lim != nil {
+		lim.total.Add(-it.weight)
+	}
+	if bp.cfg.sizeFn == nil {
+		return 0
+	}
+	size := bp.cfg.sizeFn(it.v)
+	bp.retained.Add(-size)
+	return size
+}
+
+// discard hands v to the OnDiscard hook, if any. It may run with bp.mu held,
+// so the hook must not call back into the pool.
+func (bp *BoundedPool[T]) discard(v T) {
+	if bp.cfg.onDiscard != nil {
+		bp.cfg.onDiscard(v)
+	}
+}
+
+// release returns freed bytes to the group budget, if any.
+// bp.mu must not be held.
+func (bp *BoundedPool[T]) release(freed int64) {
+	if g := bp.cfg.group; g != nil {
+		g.release(freed)
+	}
+}
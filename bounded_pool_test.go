@@ -0,0 +1,116 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ArditZubaku/go-sync-pool/pooltest"
+)
+
+func drain(p *BoundedPool[int], n int) []int {
+	got := make([]int, 0, n)
+	for range n {
+		got = append(got, p.Get())
+	}
+	return got
+}
+
+func TestBoundedPoolOrdering(t *testing.T) {
+	tests := []struct {
+		ordering Ordering
+		want     []int
+	}{
+		{LIFO, []int{3, 2, 1, -1}},
+		{FIFO, []int{1, 2, 3, -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ordering.String(), func(t *testing.T) {
+			pool := NewBoundedPool(4, func() int { return -1 }, WithOrdering[int](tt.ordering))
+			for i := 1; i <= 3; i++ {
+				pool.Put(i)
+			}
+
+			if got := drain(pool, 4); !slices.Equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoundedPoolDropsWhenFull(t *testing.T) {
+	pool := NewBoundedPool(2, func() int { return -1 })
+	pool.Put(1)
+	pool.Put(2)
+	pool.Put(3)
+
+	if got := pool.Len(); got != 2 {
+		t.Fatalf("Len() = %d, want 2", got)
+	}
+}
+
+func TestBoundedPoolIdleTTL(t *testing.T) {
+	tests := []struct {
+		ordering Ordering
+		want     []int
+	}{
+		{LIFO, []int{3, 2, -1}},
+		{FIFO, []int{2, 3, -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ordering.String(), func(t *testing.T) {
+			clock := pooltest.NewFakeClock(time.Unix(0, 0))
+			pool := NewBoundedPool(4, func() int { return -1 },
+				WithOrdering[int](tt.ordering),
+				WithIdleTTL[int](10*time.Second),
+				WithPoolClock[int](clock),
+			)
+
+			pool.Put(1)
+			clock.Advance(5 * time.Second)
+			pool.Put(2)
+			pool.Put(3)
+
+			// Only the first object has been idle for longer than the TTL.
+			clock.Advance(6 * time.Second)
+
+			if got := drain(pool, 3); !slices.Equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoundedPoolResize(t *testing.T) {
+	var discarded []int
+	pool := NewBoundedPool(4, func() int { return 0 },
+		WithOnDiscard(func(v int) { discarded = append(discarded, v) }),
+	)
+	for i := 1; i <= 4; i++ {
+		pool.Put(i)
+	}
+
+	if err := pool.Resize(2); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(discarded, []int{1, 2}) {
+		t.Fatalf("discarded %v, want the oldest [1 2]", discarded)
+	}
+	pool.Put(5)
+	if pool.Len() != 2 || !slices.Equal(discarded, []int{1, 2, 5}) {
+		t.Fatalf("shrunk pool holds %d, discarded %v", pool.Len(), discarded)
+	}
+
+	if err := pool.Resize(5); err != nil {
+		t.Fatal(err)
+	}
+	if got := drain(pool, 5); !slices.Equal(got, []int{0, 0, 0, 4, 3}) {
+		t.Fatalf("after grow got %v, want three warmed objects on top", got)
+	}
+
+	if err := pool.Resize(-1); err != ErrInvalidCapacity {
+		t.Fatalf("Resize(-1) = %v, want ErrInvalidCapacity", err)
+	}
+}
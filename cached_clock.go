@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// WithCachedTime formats the timestamp once per second and reuses the bytes
+// for every line in that second. Layouts with sub-second precision change on
+// every call, so they keep formatting each line; use WithTimePrecision for
+// fractional seconds that keep the cache.
+func WithCachedTime() LoggerOption {
+	return func(l *Logger) {
+		l.stamp = new(atomic.Pointer[cachedStamp])
+	}
+}
+
+// cachedStamp is a timestamp formatted for one whole second. It is never
+// modified once published, so readers cannot see a torn value. With
+// WithTimePrecision the fraction goes between text[:split] and text[split:].
+type cachedStamp struct {
+	unix  int64
+	text  []byte
+	split int
+}
+
+// subSecond reports whether layout has fractional seconds.
+func subSecond(layout string) bool {
+	return strings.Contains(layout, ".0") || strings.Contains(layout, ".9") ||
+		strings.Contains(layout, ",0") || strings.Contains(layout, ",9")
+}
+
+// timeSlack is the room reserved beyond the layout's length for elements
+// that format longer than they are written, such as "Monday" or "MST".
+const timeSlack = 16
+
+// appendTime writes the current time in the Logger's layout.
+func (l *Logger) appendTime(b *bytes.Buffer) {
+	t := l.timestamp()
+	if l.stamp == nil || l.subSec {
+		// Reserve the room first so AppendFormat fills the buffer in place
+		// instead of allocating a slice for Write to copy from.
+		b.Grow(len(l.preciseLayout) + timeSlack)
+		b.Write(t.AppendFormat(b.AvailableBuffer(), l.preciseLayout))
+		return
+	}
+
+	// Every line writes text for the second of its own clock reading: either
+	// the cache already holds that second or the line formats it itself. A
+	// slow goroutine with an older reading never rolls the cache back.
+	sec := t.Unix()
+	c := l.stamp.Load()
+	if c == nil || c.unix != sec {
+		fresh := l.newStamp(t)
+		if c == nil || sec > c.unix {
+			l.stamp.CompareAndSwap(c, fresh)
+		}
+		c = fresh
+	}
+	if l.fracAt < 0 {
+		b.Write(c.text)
+		return
+	}
+	b.Write(c.text[:c.split])
+	appendFraction(b, t.Nanosecond(), l.precision.digits())
+	b.Write(c.text[c.split:])
+}
+
+// newStamp formats the second of t, split where the fraction goes.
+func (l *Logger) newStamp(t time.Time) *cachedStamp {
+	c := &cachedStamp{unix: t.Unix()}
+	if l.fracAt < 0 {
+		c.text = t.AppendFormat(nil, l.layout)
+		c.split = len(c.text)
+		return c
+	}
+	c.text = t.AppendFormat(nil, l.layout[:l.fracAt])
+	c.split = len(c.text)
+	c.text = t.AppendFormat(c.text, l.layout[l.fracAt:])
+	return c
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// tickingClock advances 1ms on every reading.
+type tickingClock struct {
+	start time.Time
+	ticks atomic.Int64
+}
+
+func (c *tickingClock) Now() time.Time {
+	return c.start.Add(time.Duration(c.ticks.Add(1)-1) * time.Millisecond)
+}
+
+func TestCachedTimeAcrossRollover(t *testing.T) {
+	const (
+		workers = 8
+		perWork = 250
+	)
+	// Each reading advances 1ms from 12:00:00.750, so the calls cross two
+	// second boundaries: 250 readings in :00, 1000 in :01, 750 in :02.
+	clock := &tickingClock{start: time.Date(2024, 1, 1, 12, 0, 0, 750e6, time.UTC)}
+	var out lockedBuffer
+	logger := NewLogger(&out, WithCachedTime(), WithUTC(), WithClock(clock))
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range perWork {
+				logger.Info("tick", "w", w)
+			}
+		}()
+	}
+	wg.Wait()
+
+	perSecond := map[string]int{}
+	last := make([]string, workers)
+	for line := range strings.Lines(out.String()) {
+		stamp, rest, _ := strings.Cut(line, " : ")
+		w, err := strconv.Atoi(strings.TrimSpace(rest[strings.LastIndex(rest, "=")+1:]))
+		if err != nil {
+			t.Fatalf("bad line %q", line)
+		}
+		if stamp < last[w] {
+			t.Fatalf("worker %d logged %s after %s", w, stamp, last[w])
+		}
+		last[w] = stamp
+		perSecond[stamp]++
+	}
+
+	want := map[string]int{"12:00:00": 250, "12:00:01": 1000, "12:00:02": 750}
+	for stamp, n := range want {
+		if perSecond[stamp] != n {
+			t.Errorf("%s: %d lines, want %d", stamp, perSecond[stamp], n)
+		}
+	}
+	if len(perSecond) != len(want) {
+		t.Errorf("unexpected timestamps: %v", perSecond)
+	}
+}
+
+func TestCachedTimeSubSecondLayout(t *testing.T) {
+	clock := &tickingClock{start: time.Date(2024, 1, 1, 12, 0, 0, 1e6, time.UTC)}
+	var out lockedBuffer
+	logger := NewLogger(&out, WithCachedTime(), WithTimeLayout("15:04:05.000"), WithUTC(), WithClock(clock))
+
+	logger.Info("a")
+	logger.Info("b")
+
+	if want := "12:00:00.001 : INFO : a\n12:00:00.002 : INFO : b\n"; out.String() != want {
+		t.Fatalf("got %q, want %q", out.String(), want)
+	}
+}
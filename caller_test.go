@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// nextLine returns "caller_test.go:N" for the line after the call.
+func nextLine() string {
+	_, _, line, _ := runtime.Caller(1)
+	return fmt.Sprintf("caller_test.go:%d", line+1)
+}
+
+func TestWithCaller(t *testing.T) {
+	var buf bytes.Buffer
+	text := NewLogger(&buf, WithTimeLayout(""), WithCaller())
+	json := NewLogger(&buf, WithJSON(), WithTimeLayout(""), WithCaller())
+	handler := slog.New(NewSlogHandler(&buf, &slog.HandlerOptions{AddSource: true}))
+
+	saved := defaultLogger.caller
+	defaultLogger.caller = true
+	defer func() { defaultLogger.caller = saved }()
+
+	tests := []struct {
+		name string
+		log  func() string // logs and returns the wanted output
+	}{
+		{"Info", func() string {
+			want := nextLine()
+			text.Info("msg")
+			return "INFO : " + want + " : msg\n"
+		}},
+		{"Warnf", func() string {
+			want := nextLine()
+			text.Warnf("n=%d", 1)
+			return "WARN : " + want + " : n=1\n"
+		}},
+		{"JSON", func() string {
+			want := nextLine()
+			json.Error("msg")
+			return `{"level":"error","caller":"` + want + `","msg":"msg"}` + "\n"
+		}},
+		{"log", func() string {
+			want := nextLine()
+			log(&buf, "msg")
+			return "TIME : " + want + " : msg"
+		}},
+		{"slog", func() string {
+			want := nextLine()
+			handler.Info("msg")
+			return "level=INFO source=" + want + " msg=msg\n"
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			want := tt.log()
+			got := stripTime(buf.String())
+			if tt.name == "slog" {
+				_, got, _ = strings.Cut(got, " ")
+			}
+			if got != want {
+				t.Fatalf("got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestCallerOffByDefault(t *testing.T) {
+	var buf bytes.Buffer
+	NewLogger(&buf, WithTimeLayout("")).Info("msg")
+
+	if got := buf.String(); got != "INFO : msg\n" {
+		t.Fatalf("got %q", got)
+	}
+}
@@ -0,0 +1,22 @@
+//go:build chaos
+
+package main
+
+import "math/rand/v2"
+
+// WithPoolChaosMonkey makes the pool unreliable on purpose, to test that
+// callers cope with both of its paths: with the given probability, each Put
+// discards its item as if the GC had cleared it, and each Get constructs a
+// new item even though idle ones are waiting. It only does so in builds
+// with the chaos tag and is a no-op otherwise, so it can stay in code that
+// ships.
+func WithPoolChaosMonkey[T any](probability float64) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.chaos = probability
+	}
+}
+
+// chaos reports whether the WithPoolChaosMonkey strikes this call.
+func (tp *TypedPool[T]) chaos() bool {
+	return tp.cfg.chaos > 0 && rand.Float64() < tp.cfg.chaos
+}
@@ -0,0 +1,15 @@
+//go:build !chaos
+
+package main
+
+// WithPoolChaosMonkey randomly discards Puts and bypasses idle items on Get
+// with the given probability, in builds with the chaos tag. Without the tag
+// it does nothing.
+func WithPoolChaosMonkey[T any](probability float64) PoolOption[T] {
+	return func(*poolConfig[T]) {}
+}
+
+// chaos is always false outside builds with the chaos tag.
+func (tp *TypedPool[T]) chaos() bool {
+	return false
+}
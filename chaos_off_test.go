@@ -0,0 +1,18 @@
+//go:build !chaos
+
+package main
+
+import "testing"
+
+func TestPoolChaosMonkeyIsNoOp(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithFIFO[*int](),
+		WithPoolChaosMonkey[*int](1),
+	)
+
+	v := pool.Get()
+	pool.Put(v)
+	if got := pool.Get(); got != v {
+		t.Fatal("the chaos monkey acted without the chaos tag")
+	}
+}
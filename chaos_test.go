@@ -0,0 +1,31 @@
+//go:build chaos
+
+package main
+
+import "testing"
+
+func TestPoolChaosMonkeyDropsAndStarves(t *testing.T) {
+	var discarded int
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithFIFO[*int](),
+		WithStats[*int](),
+		WithPoolChaosMonkey[*int](1),
+		WithOnDiscard(func(*int) { discarded++ }),
+	)
+
+	v := pool.Get()
+	pool.Put(v)
+	if discarded != 1 || pool.Len() != 0 {
+		t.Fatalf("discarded %d with %d idle, want the Put dropped", discarded, pool.Len())
+	}
+
+	pool.cfg.chaos = 0
+	pool.Put(v)
+	pool.cfg.chaos = 1
+	if got := pool.Get(); got == v {
+		t.Fatal("Get returned the idle item despite the chaos monkey")
+	}
+	if s := pool.Stats(); s.Misses != 2 || s.Hits != 0 {
+		t.Fatalf("Stats = %+v, want every Get a miss", s)
+	}
+}
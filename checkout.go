@@ -0,0 +1,131 @@
+package main
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WithLogger sets the Logger the pool uses for its own warnings. The default
+// writes to os.Stderr.
+func WithLogger[T any](l *Logger) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.logger = l
+	}
+}
+
+var defaultPoolLogger = NewLogger(os.Stderr)
+
+// log returns the Logger for pool warnings.
+func (cfg *poolConfig[T]) log() *Logger {
+	if cfg.logger != nil {
+		return cfg.logger
+	}
+	return defaultPoolLogger
+}
+
+// WithDeadlockDetector logs a warning for every item that stays checked out
+// longer than timeout, which on a bounded pool usually means a missing Put is
+// about to starve other callers. Items are told apart by address, so only
+// pointer-like item types are tracked. Call Close to stop the detector.
+func WithDeadlockDetector[T any](timeout time.Duration) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.deadlockTimeout = timeout
+	}
+}
+
+// Periodic checks over the checkout table, each reporting an item once.
+const (
+	checkDeadlock = iota
+	checkSoftTimeout
+	numChecks
+)
+
+// checkout is one item's time out of the pool.
+type checkout struct {
+	since    time.Time
+	item     any
+	goid     uint64 // the borrowing goroutine, under WithGoroutineTracking
+	reported [numChecks]atomic.Bool
+}
+
+// checkouts records which items are checked out, keyed by item address. It
+// is the side table that per-item diagnostics share.
+type checkouts struct {
+	m sync.Map // uintptr -> *checkout
+}
+
+func (c *checkouts) add(id uintptr, item any, now time.Time, goid uint64) {
+	if id != 0 {
+		c.m.Store(id, &checkout{since: now, item: item, goid: goid})
+	}
+}
+
+// remove drops the item's checkout and returns it, or nil if the item was
+// not checked out.
+func (c *checkouts) remove(id uintptr) *checkout {
+	if id == 0 {
+		return nil
+	}
+	co, ok := c.m.LoadAndDelete(id)
+	if !ok {
+		return nil
+	}
+	return co.(*checkout)
+}
+
+// overdue calls fn for every item out longer than timeout that check has
+// not reported yet. fn may Put the item.
+func (c *checkouts) overdue(now time.Time, timeout time.Duration, check int, fn func(id uintptr, co *checkout, held time.Duration)) {
+	c.m.Range(func(key, value any) bool {
+		co := value.(*checkout)
+		if held := now.Sub(co.since); held > timeout && co.reported[check].CompareAndSwap(false, true) {
+			fn(key.(uintptr), co, held)
+		}
+		return true
+	})
+}
+
+// startDeadlockDetector begins the periodic scan for overdue items.
+func (tp *TypedPool[T]) startDeadlockDetector() {
+	timeout := tp.cfg.deadlockTimeout
+	tp.bg.every(tp.cfg.schedule, tp.cfg.clock, tp.cfg.jitter, timeout/2, func() {
+		if tp.debugExpired() {
+			return
+		}
+		tp.checkouts.overdue(tp.cfg.now(), timeout, checkDeadlock, func(id uintptr, _ *checkout, held time.Duration) {
+			tp.cfg.log().Warn("pool item checked out too long",
+				"pool", tp.cfg.name(),
+				"item", "0x"+strconv.FormatUint(uint64(id), 16),
+				"held", held.Round(time.Millisecond),
+			)
+		})
+	})
+}
+
+// trackGet and trackPut maintain the checkout table when a detector needs it.
+func (tp *TypedPool[T]) trackGet(v T) {
+	if tp.checkouts == nil || tp.debugExpired() && !tp.cfg.checkoutsShared() {
+		return
+	}
+	var goid uint64
+	if tp.cfg.goroutineTracking {
+		goid = goroutineID()
+	}
+	tp.checkouts.add(uintptr(itemIdentity(v)), v, tp.cfg.now(), goid)
+}
+
+// trackPut returns v's checkout, or nil if it was not checked out.
+func (tp *TypedPool[T]) trackPut(v T) *checkout {
+	if tp.checkouts == nil {
+		return nil
+	}
+	id := uintptr(itemIdentity(v))
+	co := tp.checkouts.remove(id)
+	if tp.cfg.goroutineTracking && co != nil {
+		tp.checkOwner(id, co.goid)
+	}
+	return co
+}
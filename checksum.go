@@ -0,0 +1,82 @@
+package main
+
+import "sync"
+
+// WithChecksum guards idle items against tampering, for pools of sensitive
+// material such as key buffers: Put records sum(item), and when Get finds
+// the item idle again it calls verify with the recorded sum. An item that
+// fails, or has no recorded sum, is discarded, through the OnDiscard hook,
+// and Get moves on to the next idle item or the constructor. Sums are taken
+// after WithReset and live in a side table keyed by address, like
+// WithOnReuse counts, so only pointer-like item types are checked.
+func WithChecksum[T any](sum func(T) []byte, verify func(T, []byte) bool) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.checksum = &checksum[T]{sum: sum, verify: verify}
+	}
+}
+
+// checksum is the WithChecksum configuration and side table.
+type checksum[T any] struct {
+	sum    func(T) []byte
+	verify func(T, []byte) bool
+	sums   sync.Map // uintptr -> *sumEntry
+}
+
+// sumEntry is one idle item's checksum.
+type sumEntry struct {
+	idleGen
+	sum []byte
+}
+
+// recordSum notes the checksum of v as it goes idle.
+func (tp *TypedPool[T]) recordSum(v T) {
+	if c := tp.cfg.checksum; c != nil {
+		if id := uintptr(itemIdentity(v)); id != 0 {
+			e := &sumEntry{sum: c.sum(v)}
+			e.setIdle(true)
+			c.sums.Store(id, e)
+		}
+	}
+}
+
+// checkSum verifies an idle item against its recorded checksum, dropping
+// it on a mismatch, and reports whether Get may return it. An item of a
+// tracked type with no sum fails: it did not go idle through Put.
+func (tp *TypedPool[T]) checkSum(v T) bool {
+	c := tp.cfg.checksum
+	if c == nil {
+		return true
+	}
+	id := uintptr(itemIdentity(v))
+	if id == 0 {
+		return true
+	}
+	if e, ok := c.sums.LoadAndDelete(id); ok && c.verify(v, e.(*sumEntry).sum) {
+		return true
+	}
+	tp.drop(v)
+	return false
+}
+
+// forgetSum drops the checksum of a discarded item.
+func (tp *TypedPool[T]) forgetSum(v T) {
+	if c := tp.cfg.checksum; c != nil {
+		c.sums.Delete(uintptr(itemIdentity(v)))
+	}
+}
+
+// pruneSums drops the checksums of items idle since collection cutoff, for
+// pruneGone: they are gone, and the GC may hand their addresses to new
+// items.
+func (tp *TypedPool[T]) pruneSums(cutoff int64) {
+	c := tp.cfg.checksum
+	if c == nil {
+		return
+	}
+	c.sums.Range(func(key, value any) bool {
+		if value.(*sumEntry).gone(cutoff) {
+			c.sums.Delete(key)
+		}
+		return true
+	})
+}
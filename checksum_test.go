@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+)
+
+func TestChecksumDiscardsTamperedItems(t *testing.T) {
+	var discarded [][]byte
+	pool := NewTypedPool(func() *[]byte { b := []byte("secret"); return &b },
+		WithFIFO[*[]byte](),
+		WithChecksum(
+			func(b *[]byte) []byte { s := sha256.Sum256(*b); return s[:] },
+			func(b *[]byte, want []byte) bool { s := sha256.Sum256(*b); return bytes.Equal(s[:], want) },
+		),
+		WithOnDiscard(func(b *[]byte) { discarded = append(discarded, *b) }),
+	)
+
+	intact, tampered := pool.Get(), pool.Get()
+	pool.Put(intact)
+	pool.Put(tampered)
+	(*tampered)[0] = 'S' // mutated while idle
+
+	if got := pool.Get(); got != intact {
+		t.Fatal("Get did not return the intact item")
+	}
+	got := pool.Get()
+	if got == tampered {
+		t.Fatal("Get returned the tampered item")
+	}
+	if string(*got) != "secret" {
+		t.Fatalf("replacement holds %q, want a new item", *got)
+	}
+	if len(discarded) != 1 || string(discarded[0]) != "Secret" {
+		t.Fatalf("discarded %q, want the tampered item", discarded)
+	}
+
+	// An item mutated while checked out is summed afresh on its next Put.
+	*got = append(*got, '!')
+	pool.Put(got)
+	if again := pool.Get(); again != got {
+		t.Fatal("an item changed by its user was rejected")
+	}
+}
+
+func TestChecksumRejectsItemsWithoutSum(t *testing.T) {
+	store := NewLIFOStore[*[]byte]()
+	var discarded int
+	pool := NewTypedPool(func() *[]byte { b := []byte("secret"); return &b },
+		WithCustomStore[*[]byte](store),
+		WithStats[*[]byte](),
+		WithChecksum(
+			func(b *[]byte) []byte { return bytes.Clone(*b) },
+			func(b *[]byte, want []byte) bool { return bytes.Equal(*b, want) },
+		),
+		WithOnDiscard(func(*[]byte) { discarded++ }),
+	)
+
+	planted := []byte("secret")
+	store.Put(&planted) // never went through Put, so has no sum
+	if got := pool.Get(); got == &planted {
+		t.Fatal("Get returned an item with no recorded sum")
+	}
+	if s := pool.Stats(); discarded != 1 || s.Discards != 1 {
+		t.Fatalf("discarded %d, Discards = %d, want 1 and 1", discarded, s.Discards)
+	}
+}
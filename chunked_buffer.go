@@ -0,0 +1,150 @@
+package main
+
+import (
+	"io"
+	"net"
+)
+
+// chunkSize is the size of a ChunkedBuffer block.
+const chunkSize = 16 << 10
+
+// chunkPool recycles ChunkedBuffer blocks.
+var chunkPool = NewTypedPool(func() *[chunkSize]byte {
+	return new([chunkSize]byte)
+}, WithStats[*[chunkSize]byte]())
+
+// ChunkedBuffer is a variable-sized buffer of bytes built from pooled 16 KiB
+// blocks. Unlike bytes.Buffer it never copies what it already holds to
+// grow, and it holds only about as many blocks as its contents fill; Reset
+// hands every block back to the pool. The zero value is an
+// empty buffer ready to use. A ChunkedBuffer must not be copied after first
+// use.
+type ChunkedBuffer struct {
+	blocks []*[chunkSize]byte
+	n      int
+}
+
+var (
+	_ io.Writer     = (*ChunkedBuffer)(nil)
+	_ io.ReaderFrom = (*ChunkedBuffer)(nil)
+	_ io.WriterTo   = (*ChunkedBuffer)(nil)
+)
+
+// Len returns the number of bytes held.
+func (c *ChunkedBuffer) Len() int {
+	return c.n
+}
+
+// tail returns the free space of the last block, taking a new block from
+// the pool if the last one is full.
+func (c *ChunkedBuffer) tail() []byte {
+	if c.n == len(c.blocks)*chunkSize {
+		c.blocks = append(c.blocks, chunkPool.Get())
+	}
+	return c.blocks[len(c.blocks)-1][c.n-(len(c.blocks)-1)*chunkSize:]
+}
+
+// Write appends p, filling the last block before taking the next one. It
+// always returns len(p), nil.
+func (c *ChunkedBuffer) Write(p []byte) (int, error) {
+	total := len(p)
+	for len(p) > 0 {
+		n := copy(c.tail(), p)
+		c.n += n
+		p = p[n:]
+	}
+	return total, nil
+}
+
+// ReadFrom appends what r returns until io.EOF, reading straight into the
+// blocks. It returns the number of bytes read and any error other than
+// io.EOF.
+func (c *ChunkedBuffer) ReadFrom(r io.Reader) (int64, error) {
+	var total int64
+	for {
+		n, err := r.Read(c.tail())
+		c.n += n
+		total += int64(n)
+		if err == io.EOF {
+			return total, nil
+		}
+		if err != nil {
+			return total, err
+		}
+	}
+}
+
+// chunks returns the contents as one slice per block.
+func (c *ChunkedBuffer) chunks() [][]byte {
+	out := make([][]byte, 0, len(c.blocks))
+	left := c.n
+	for _, b := range c.blocks {
+		n := min(left, chunkSize)
+		if n == 0 {
+			break
+		}
+		out = append(out, b[:n])
+		left -= n
+	}
+	return out
+}
+
+// WriteTo writes the contents to w block by block, or as one net.Buffers
+// write, which becomes a single writev, when w is a net.Conn. Unlike
+// bytes.Buffer.WriteTo it leaves the contents in place; call Reset once
+// they are no longer needed.
+func (c *ChunkedBuffer) WriteTo(w io.Writer) (int64, error) {
+	if _, ok := w.(net.Conn); ok {
+		bufs := net.Buffers(c.chunks())
+		return bufs.WriteTo(w)
+	}
+
+	var total int64
+	for _, p := range c.chunks() {
+		for len(p) > 0 {
+			n, err := w.Write(p)
+			total += int64(n)
+			if err != nil {
+				return total, err
+			}
+			if n <= 0 {
+				return total, io.ErrShortWrite
+			}
+			p = p[n:]
+		}
+	}
+	return total, nil
+}
+
+// AppendTo appends a copy of the contents to dst and returns the result.
+func (c *ChunkedBuffer) AppendTo(dst []byte) []byte {
+	for _, p := range c.chunks() {
+		dst = append(dst, p...)
+	}
+	return dst
+}
+
+// Bytes returns the contents as one slice. While they fit in a single block
+// this is the block itself, valid until the next Write, ReadFrom or Reset,
+// with no copy; beyond that it falls back to AppendTo(nil), which allocates
+// and copies everything, so callers handling large contents should use
+// WriteTo or AppendTo instead.
+func (c *ChunkedBuffer) Bytes() []byte {
+	if len(c.blocks) <= 1 {
+		if c.n == 0 {
+			return nil
+		}
+		return c.blocks[0][:c.n]
+	}
+	return c.AppendTo(make([]byte, 0, c.n))
+}
+
+// Reset empties the buffer and returns all of its blocks to the pool.
+func (c *ChunkedBuffer) Reset() {
+	for i, b := range c.blocks {
+		chunkPool.Put(b)
+		c.blocks[i] = nil
+	}
+	c.blocks = c.blocks[:0]
+	c.n = 0
+}
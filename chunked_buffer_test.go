@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// pattern returns n bytes that differ from block to block.
+func pattern(n int) []byte {
+	p := make([]byte, n)
+	for i := range p {
+		p[i] = byte(i % 251)
+	}
+	return p
+}
+
+func TestChunkedBufferBlockBoundaries(t *testing.T) {
+	for _, n := range []int{0, 1, chunkSize - 1, chunkSize, chunkSize + 1, 2 * chunkSize, 3*chunkSize - 7} {
+		for _, step := range []int{1 << 10, chunkSize, 3*chunkSize + 1} {
+			want := pattern(n)
+			var c ChunkedBuffer
+			for p := want; len(p) > 0; {
+				k := min(step, len(p))
+				if m, err := c.Write(p[:k]); m != k || err != nil {
+					t.Fatalf("Write = %d, %v", m, err)
+				}
+				p = p[k:]
+			}
+
+			if c.Len() != n {
+				t.Fatalf("n=%d step=%d: Len = %d", n, step, c.Len())
+			}
+			if blocks := (n + chunkSize - 1) / chunkSize; len(c.blocks) != blocks {
+				t.Fatalf("n=%d step=%d: %d blocks, want %d", n, step, len(c.blocks), blocks)
+			}
+			if got := c.AppendTo(nil); !bytes.Equal(got, want) {
+				t.Fatalf("n=%d step=%d: AppendTo differs", n, step)
+			}
+			if got := c.Bytes(); !bytes.Equal(got, want) {
+				t.Fatalf("n=%d step=%d: Bytes differs", n, step)
+			}
+			var out bytes.Buffer
+			if m, err := c.WriteTo(&out); m != int64(n) || err != nil || !bytes.Equal(out.Bytes(), want) {
+				t.Fatalf("n=%d step=%d: WriteTo = %d, %v", n, step, m, err)
+			}
+			c.Reset()
+		}
+	}
+}
+
+func TestChunkedBufferBytesSingleBlockAliases(t *testing.T) {
+	var c ChunkedBuffer
+	c.Write([]byte("hello"))
+	b := c.Bytes()
+	c.Write([]byte(" world"))
+	if got := string(b[:cap(b)][:11]); got != "hello world" {
+		t.Fatalf("single-block Bytes does not alias the block: %q", got)
+	}
+}
+
+func TestChunkedBufferReadFrom(t *testing.T) {
+	want := pattern(2*chunkSize + 100)
+	var c ChunkedBuffer
+	n, err := c.ReadFrom(io.MultiReader(bytes.NewReader(want[:10]), bytes.NewReader(want[10:])))
+	if n != int64(len(want)) || err != nil {
+		t.Fatalf("ReadFrom = %d, %v", n, err)
+	}
+	if !bytes.Equal(c.AppendTo(nil), want) {
+		t.Fatal("ReadFrom contents differ")
+	}
+
+	_, err = c.ReadFrom(io.MultiReader(strings.NewReader("x"), iotest.ErrReader(io.ErrUnexpectedEOF)))
+	if err != io.ErrUnexpectedEOF || c.Len() != len(want)+1 {
+		t.Fatalf("ReadFrom of a failing reader = %v with Len %d", err, c.Len())
+	}
+}
+
+func TestChunkedBufferWriteToConn(t *testing.T) {
+	want := pattern(2*chunkSize + 5)
+	var c ChunkedBuffer
+	c.Write(want)
+
+	client, server := net.Pipe()
+	got := make(chan []byte)
+	go func() {
+		b, _ := io.ReadAll(server)
+		got <- b
+	}()
+	if n, err := c.WriteTo(client); n != int64(len(want)) || err != nil {
+		t.Fatalf("WriteTo(net.Conn) = %d, %v", n, err)
+	}
+	client.Close()
+	if !bytes.Equal(<-got, want) {
+		t.Fatal("the conn received different bytes")
+	}
+	if c.Len() != len(want) {
+		t.Fatal("WriteTo drained the buffer")
+	}
+}
+
+func TestChunkedBufferResetReturnsBlocks(t *testing.T) {
+	var c ChunkedBuffer
+	c.Write(pattern(3 * chunkSize))
+	before := chunkPool.Stats().Puts
+	c.Reset()
+	if got := chunkPool.Stats().Puts - before; got != 3 {
+		t.Fatalf("Reset returned %d blocks, want 3", got)
+	}
+	if c.Len() != 0 || c.Bytes() != nil {
+		t.Fatal("Reset left contents behind")
+	}
+}
+
+func TestChunkedBufferWriteToShortWriter(t *testing.T) {
+	want := pattern(chunkSize + 10)
+	var c ChunkedBuffer
+	c.Write(want)
+	w := &shortWriter{max: 1000}
+	if n, err := c.WriteTo(w); n != int64(len(want)) || err != nil || !bytes.Equal(w.Bytes(), want) {
+		t.Fatalf("WriteTo through short writes = %d, %v", n, err)
+	}
+}
+
+// go test -run '^$' -bench Build1MB -benchmem
+// Results (GOMAXPROCS=1); the remaining allocations are the block list
+// growing:
+//
+//	BenchmarkBuild1MB/bytes.Buffer     2930	  410076 ns/op	 2557.03 MB/s	 2096128 B/op	 11 allocs/op
+//	BenchmarkBuild1MB/ChunkedBuffer   29329	   40452 ns/op	25921.57 MB/s	    2811 B/op	  8 allocs/op
+func BenchmarkBuild1MB(b *testing.B) {
+	chunk := pattern(1 << 10)
+	b.Run("bytes.Buffer", func(b *testing.B) {
+		b.SetBytes(1 << 20)
+		for b.Loop() {
+			var buf bytes.Buffer
+			for range 1 << 10 {
+				buf.Write(chunk)
+			}
+			buf.WriteTo(io.Discard)
+		}
+	})
+	b.Run("ChunkedBuffer", func(b *testing.B) {
+		b.SetBytes(1 << 20)
+		for b.Loop() {
+			var buf ChunkedBuffer
+			for range 1 << 10 {
+				buf.Write(chunk)
+			}
+			buf.WriteTo(io.Discard)
+			buf.Reset()
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package main
+
+import "time"
+
+// Clock tells the time. Loggers and pools read the time through it, so tests
+// can substitute a fake such as pooltest.FakeClock.
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is the default Clock.
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+// WithClock sets the Clock the Logger takes its timestamps from.
+func WithClock(c Clock) LoggerOption {
+	return func(l *Logger) {
+		l.clock = c
+	}
+}
+
+// WithPoolClock sets the Clock the pool uses for idle TTLs, checkout times
+// and audit records. Durations measured for WithMaxPutLatency always use the
+// real clock.
+func WithPoolClock[T any](c Clock) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.clock = c
+	}
+}
+
+// now reads the pool's Clock.
+func (cfg *poolConfig[T]) now() time.Time {
+	if cfg.clock != nil {
+		return cfg.clock.Now()
+	}
+	return time.Now()
+}
+
+// timerClock is a Clock that can also schedule, such as
+// pooltest.FakeClock. Timers on other Clocks run on real time.
+type timerClock interface {
+	Clock
+	After(d time.Duration) <-chan time.Time
+}
+
+// after returns a channel that fires once d has passed on c.
+func after(c Clock, d time.Duration) <-chan time.Time {
+	if tc, ok := c.(timerClock); ok {
+		return tc.After(d)
+	}
+	return time.After(d)
+}
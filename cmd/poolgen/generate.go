@@ -0,0 +1,247 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/types"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// generator accumulates the reset code for one output file.
+type generator struct {
+	pkg     *types.Package
+	body    bytes.Buffer
+	imports map[string]string // path -> package name
+	errs    []error
+}
+
+// generate returns the formatted file holding the reset functions, and
+// constructors if asked, for the named struct types of pkg. args is recorded
+// in the header. Every field poolgen cannot reset is reported, not just the
+// first.
+func generate(pkg *types.Package, typeNames []string, constructor bool, args string) ([]byte, error) {
+	g := &generator{pkg: pkg, imports: make(map[string]string)}
+	for _, name := range typeNames {
+		g.genType(name, constructor)
+	}
+	if len(g.errs) > 0 {
+		return nil, errors.Join(g.errs...)
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by \"poolgen %s\"; DO NOT EDIT.\n\n", args)
+	fmt.Fprintf(&out, "package %s\n\n", pkg.Name())
+	if len(g.imports) > 0 {
+		paths := make([]string, 0, len(g.imports))
+		for path := range g.imports {
+			paths = append(paths, path)
+		}
+		slices.Sort(paths)
+		out.WriteString("import (\n")
+		for _, path := range paths {
+			fmt.Fprintf(&out, "\t%q\n", path)
+		}
+		out.WriteString(")\n\n")
+	}
+	out.Write(g.body.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %v\n%s", err, out.Bytes())
+	}
+	return src, nil
+}
+
+// genType writes the reset function and constructor for the type name.
+func (g *generator) genType(name string, constructor bool) {
+	obj, ok := g.pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		g.errorf("%s: no type %s in package %s", name, name, g.pkg.Path())
+		return
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok || named.TypeParams().Len() > 0 {
+		g.errorf("%s: only non-generic defined types are supported", name)
+		return
+	}
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		g.errorf("%s: %s is not a struct type", name, named.Underlying())
+		return
+	}
+
+	reset := "reset" + upperFirst(name)
+	g.comment(reset + " zeroes v for reuse. Truncated slices and cleared maps keep their memory; fields tagged pool:\"keep\" are left alone.")
+	fmt.Fprintf(&g.body, "func %s(v *%s) {\n", reset, name)
+	g.resetStruct(name, "v", st)
+	g.body.WriteString("}\n\n")
+
+	if !constructor {
+		return
+	}
+	ctor := "New" + upperFirst(name) + "Pool"
+	if !obj.Exported() {
+		ctor = "new" + upperFirst(name) + "Pool"
+	}
+	g.comment(fmt.Sprintf("%s creates a TypedPool of *%s whose items are reset by %s as they are put back.", ctor, name, reset))
+	fmt.Fprintf(&g.body, "func %s(opts ...PoolOption[*%s]) *TypedPool[*%s] {\n", ctor, name, name)
+	fmt.Fprintf(&g.body, "\topts = append([]PoolOption[*%s]{WithReset(%s)}, opts...)\n", name, reset)
+	fmt.Fprintf(&g.body, "\treturn NewTypedPool(func() *%s { return new(%s) }, opts...)\n", name, name)
+	g.body.WriteString("}\n\n")
+}
+
+// resetStruct writes the statements resetting each field of the struct at
+// expr. where names it in diagnostics, as Type.field.field.
+func (g *generator) resetStruct(where, expr string, st *types.Struct) {
+	for i := range st.NumFields() {
+		f := st.Field(i)
+		if f.Name() == "_" {
+			continue
+		}
+		fieldWhere := where + "." + f.Name()
+		switch tag := reflect.StructTag(st.Tag(i)).Get("pool"); tag {
+		case "":
+		case "keep":
+			continue
+		default:
+			g.errorf("%s: unknown pool tag %q; the only one is \"keep\"", fieldWhere, tag)
+			continue
+		}
+		g.resetField(fieldWhere, expr+"."+f.Name(), f.Type())
+	}
+}
+
+// resetField writes the statement resetting the value at expr of type t.
+func (g *generator) resetField(where, expr string, t types.Type) {
+	if isSync(t) {
+		g.refuse(where, types.TypeString(t, pkgName))
+		return
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Kind() == types.UnsafePointer:
+			fmt.Fprintf(&g.body, "\t%s = nil\n", expr)
+		case u.Info()&types.IsString != 0:
+			fmt.Fprintf(&g.body, "\t%s = \"\"\n", expr)
+		case u.Info()&types.IsBoolean != 0:
+			fmt.Fprintf(&g.body, "\t%s = false\n", expr)
+		default:
+			fmt.Fprintf(&g.body, "\t%s = 0\n", expr)
+		}
+	case *types.Pointer, *types.Signature, *types.Interface:
+		fmt.Fprintf(&g.body, "\t%s = nil\n", expr)
+	case *types.Slice:
+		fmt.Fprintf(&g.body, "\t%s = %s[:0]\n", expr, expr)
+	case *types.Map:
+		fmt.Fprintf(&g.body, "\tclear(%s)\n", expr)
+	case *types.Chan:
+		g.refuse(where, "channel "+types.TypeString(t, pkgName))
+	case *types.Array:
+		if what := g.unsafe(t); what != "" {
+			g.refuse(where, what)
+			return
+		}
+		fmt.Fprintf(&g.body, "\tclear(%s[:])\n", expr)
+	case *types.Struct:
+		if named, ok := t.(*types.Named); ok && named.Obj().Pkg() != g.pkg {
+			// Another package's fields may be unexported, and cannot be
+			// tagged keep, so the value starts over as a whole.
+			if what := g.unsafe(t); what != "" {
+				g.refuse(where, what)
+				return
+			}
+			fmt.Fprintf(&g.body, "\t%s = %s{}\n", expr, types.TypeString(t, g.qualify))
+			return
+		}
+		g.resetStruct(where, expr, u)
+	default:
+		g.errorf("%s: cannot reset %s", where, t)
+	}
+}
+
+// unsafe describes the first part of a value of type t that has no safe
+// zero, or returns "" if there is none. Pointers, slices and maps are not
+// looked through, since resetting them leaves what they point to alone.
+func (g *generator) unsafe(t types.Type) string {
+	if isSync(t) {
+		return types.TypeString(t, pkgName)
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Chan:
+		return "channel " + types.TypeString(t, pkgName)
+	case *types.Array:
+		if what := g.unsafe(u.Elem()); what != "" {
+			return what + " in " + types.TypeString(t, pkgName)
+		}
+	case *types.Struct:
+		for i := range u.NumFields() {
+			f := u.Field(i)
+			if what := g.unsafe(f.Type()); what != "" {
+				return what + " (field " + f.Name() + " of " + types.TypeString(t, pkgName) + ")"
+			}
+		}
+	}
+	return ""
+}
+
+// isSync reports whether t is defined in sync or sync/atomic.
+func isSync(t types.Type) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	path := named.Obj().Pkg().Path()
+	return path == "sync" || path == "sync/atomic"
+}
+
+// qualify is the types.Qualifier for the generated file: it records the
+// imports it names.
+func (g *generator) qualify(pkg *types.Package) string {
+	if pkg == g.pkg {
+		return ""
+	}
+	g.imports[pkg.Path()] = pkg.Name()
+	return pkg.Name()
+}
+
+// pkgName qualifies types in diagnostics by package name.
+func pkgName(pkg *types.Package) string {
+	return pkg.Name()
+}
+
+// refuse reports that the field at where holds what, which has no safe
+// zero.
+func (g *generator) refuse(where, what string) {
+	g.errorf("%s: cannot reset %s; tag the field pool:\"keep\" to leave it alone", where, what)
+}
+
+// comment writes text as a doc comment wrapped at 80 columns.
+func (g *generator) comment(text string) {
+	line := "//"
+	for _, word := range strings.Fields(text) {
+		if len(line)+1+len(word) > 80 && line != "//" {
+			g.body.WriteString(line + "\n")
+			line = "//"
+		}
+		line += " " + word
+	}
+	g.body.WriteString(line + "\n")
+}
+
+func (g *generator) errorf(format string, args ...any) {
+	g.errs = append(g.errs, fmt.Errorf(format, args...))
+}
+
+// upperFirst returns s with its first letter in upper case.
+func upperFirst(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[n:]
+}
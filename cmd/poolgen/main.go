@@ -0,0 +1,100 @@
+// Command poolgen writes reset functions for pooled struct types. For each
+// type T it emits resetT, which zeroes scalars, truncates slices, clears
+// maps and resets nested structs field by field, and NewTPool, a TypedPool
+// constructor that applies resetT through WithReset. Unlike a reflective
+// reset it costs nothing per field beyond the assignment, and the compiler
+// checks the result.
+//
+// Run it from go:generate in the package declaring the types:
+//
+//	//go:generate go run github.com/ArditZubaku/go-sync-pool/cmd/poolgen -type=Request,Header
+//
+// Fields tagged pool:"keep" are left as they are. Channels and values from
+// the sync and sync/atomic packages have no safe zero to return to while
+// the item may still be referenced, so poolgen refuses them unless they are
+// tagged keep. Pointers, funcs and interfaces are set to nil, since the item
+// does not own what they point to.
+//
+// The constructor refers to NewTypedPool, WithReset and PoolOption
+// unqualified, so it builds in the pool's own package; -constructor=false
+// emits only the reset functions.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("poolgen: ")
+
+	typeNames := flag.String("type", "", "comma-separated list of struct type names; required")
+	output := flag.String("output", "", "output file name; default <dir>/<type>_poolgen.go")
+	constructor := flag.Bool("constructor", true, "also emit a TypedPool constructor for each type")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: poolgen -type T [flags] [package]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	pattern := "."
+	if flag.NArg() > 0 {
+		pattern = flag.Arg(0)
+	}
+
+	pkg, err := load("", pattern)
+	if err != nil {
+		log.Fatal(err)
+	}
+	types := strings.Split(*typeNames, ",")
+	src, err := generate(pkg.Types, types, *constructor, strings.Join(os.Args[1:], " "))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	name := *output
+	if name == "" {
+		name = filepath.Join(filepath.Dir(pkg.GoFiles[0]), strings.ToLower(types[0])+"_poolgen.go")
+	}
+	if err := os.WriteFile(name, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// load type-checks the single package matching pattern, resolved in dir, or
+// the current directory if dir is "".
+func load(dir, pattern string) (*packages.Package, error) {
+	// Dependencies are type-checked from source rather than read from export
+	// data, whose format follows the toolchain and may be newer than this
+	// x/tools release can decode.
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedTypes |
+			packages.NeedSyntax | packages.NeedImports | packages.NeedDeps,
+		Dir: dir,
+	}
+	pkgs, err := packages.Load(cfg, pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("%s matches %d packages, want 1", pattern, len(pkgs))
+	}
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		return nil, pkg.Errors[0]
+	}
+	if len(pkg.GoFiles) == 0 {
+		return nil, fmt.Errorf("%s has no Go files", pattern)
+	}
+	return pkg, nil
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+// golden compares got with testdata/name, or rewrites it under -update.
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s differs; run go test -update to accept\ngot:\n%s", path, got)
+	}
+}
+
+func TestGenerateGolden(t *testing.T) {
+	pkg, err := load("", "./testdata/basic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := generate(pkg.Types, []string{"Request", "scratch"}, true, "-type=Request,scratch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "basic/want.golden", src)
+}
+
+func TestGenerateWithoutConstructor(t *testing.T) {
+	pkg, err := load("", "./testdata/basic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := generate(pkg.Types, []string{"scratch"}, false, "-type=scratch -constructor=false")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(src, []byte("Pool(")) {
+		t.Errorf("constructor emitted with -constructor=false:\n%s", src)
+	}
+}
+
+func TestGenerateRefusesUnsafeFields(t *testing.T) {
+	pkg, err := load("", "./testdata/refused")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = generate(pkg.Types, []string{"Conn", "NotStruct", "Missing"}, true, "")
+	if err == nil {
+		t.Fatal("generate succeeded")
+	}
+	golden(t, "refused/want.err", []byte(err.Error()+"\n"))
+}
+
+// e2eTypes is a pooled struct built into a copy of the pool package by
+// TestGeneratedPoolEndToEnd.
+const e2eTypes = `package main
+
+import (
+	"sync"
+	"time"
+)
+
+//go:generate poolgen -type=request
+
+type header struct{ name, value string }
+
+type request struct {
+	id      int
+	method  string
+	headers []header
+	params  map[string]string
+	created time.Time
+	parent  *request
+	header
+	trace struct {
+		spans [4]string
+		n     int
+	}
+	mu    sync.Mutex ` + "`pool:\"keep\"`" + `
+	owner string     ` + "`pool:\"keep\"`" + `
+}
+`
+
+const e2eTest = `package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGeneratedPool(t *testing.T) {
+	pool := newRequestPool(WithFIFO[*request](), WithStats[*request]())
+
+	r := pool.Get()
+	r.id, r.method, r.owner = 7, "GET", "worker-1"
+	r.headers = append(r.headers, header{"a", "1"}, header{"b", "2"})
+	r.params = map[string]string{"q": "x"}
+	r.created = time.Now()
+	r.parent = new(request)
+	r.name = "embedded"
+	r.trace.spans[1], r.trace.n = "span", 2
+	pool.Put(r)
+
+	got := pool.Get()
+	if got != r {
+		t.Fatal("Get did not reuse the pooled request")
+	}
+	if got.id != 0 || got.method != "" || !got.created.IsZero() || got.parent != nil || got.name != "" {
+		t.Errorf("scalars not reset: %+v", got)
+	}
+	if len(got.headers) != 0 || cap(got.headers) < 2 {
+		t.Errorf("headers = len %d cap %d, want truncated with capacity kept", len(got.headers), cap(got.headers))
+	}
+	if got.params == nil || len(got.params) != 0 {
+		t.Errorf("params = %v, want an empty, allocated map", got.params)
+	}
+	if got.trace.spans[1] != "" || got.trace.n != 0 {
+		t.Errorf("trace not reset: %+v", got.trace)
+	}
+	if got.owner != "worker-1" {
+		t.Errorf("owner = %q, want the keep-tagged value", got.owner)
+	}
+	if s := pool.Stats(); s.Hits != 1 || s.Misses != 1 {
+		t.Errorf("Stats = %+v, want 1 hit and 1 miss", s)
+	}
+}
+`
+
+func TestGeneratedPoolEndToEnd(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a copy of the pool package")
+	}
+	root, err := filepath.Abs("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	files, err := filepath.Glob(filepath.Join(root, "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files = append(files, filepath.Join(root, "go.mod"), filepath.Join(root, "go.sum"))
+	for _, f := range files {
+		if strings.HasSuffix(f, "_test.go") {
+			continue
+		}
+		data, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, filepath.Base(f)), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write := func(name, src string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("request.go", e2eTypes)
+	write("request_test.go", e2eTest)
+
+	pkg, err := load(dir, ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := generate(pkg.Types, []string{"request"}, true, "-type=request")
+	if err != nil {
+		t.Fatal(err)
+	}
+	write("request_poolgen.go", string(src))
+
+	cmd := exec.Command("go", "test", "-run", "^TestGeneratedPool$", ".")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go test in the generated package: %v\n%s\ngenerated:\n%s", err, out, src)
+	}
+}
@@ -0,0 +1,45 @@
+package basic
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+type Level int
+
+type header struct {
+	name, value string
+}
+
+type Request struct {
+	ID      int64
+	Method  string
+	Secure  bool
+	Level   Level
+	Ratio   float64
+	Headers []header
+	Params  map[string]string
+	Hops    [4]uint16
+	Next    *Request
+	Body    io.Reader
+	OnDone  func()
+	Created time.Time
+
+	header // embedded: reset field by field
+
+	trace struct {
+		spans []string
+		depth int
+	}
+
+	mu      sync.Mutex `pool:"keep"`
+	arena   []byte     `pool:"keep"`
+	_       [8]byte
+	private uint32
+}
+
+type scratch struct {
+	buf  []byte
+	seen map[uint64]struct{}
+}
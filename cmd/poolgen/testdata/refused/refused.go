@@ -0,0 +1,27 @@
+package refused
+
+import (
+	"log"
+	"sync"
+	"sync/atomic"
+)
+
+type inner struct {
+	mu sync.Mutex
+	ok sync.Mutex `pool:"keep"`
+}
+
+type Conn struct {
+	done    chan struct{}
+	mu      sync.RWMutex
+	count   atomic.Int64
+	waiters [2]chan int
+	logger  log.Logger
+	nested  inner
+	name    string `pool:"reset"`
+
+	ptr  *sync.Mutex
+	keep chan int `pool:"keep"`
+}
+
+type NotStruct []int
@@ -0,0 +1,31 @@
+package main
+
+// WithColdStartBuffer warms the pool with n items in the background, through
+// WarmupAsync, so NewTypedPool returns at once rather than waiting as it does
+// for WithPreHeat. Get works throughout, constructing items as usual until
+// the buffer fills; Ready reports when it has.
+func WithColdStartBuffer[T any](n int) PoolOption[T] {
+	if n < 0 {
+		panic("WithColdStartBuffer: n must not be negative")
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.coldStart = n
+	}
+}
+
+// closedReady is what Ready returns for a pool with nothing to warm.
+var closedReady = func() chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}()
+
+// Ready returns a channel that is closed once the WithColdStartBuffer warmup
+// has finished. Callers may wait on it before serving traffic, or carry on
+// with a cold pool. Without WithColdStartBuffer it is already closed.
+func (tp *TypedPool[T]) Ready() <-chan struct{} {
+	if tp.ready == nil {
+		return closedReady
+	}
+	return tp.ready
+}
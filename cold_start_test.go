@@ -0,0 +1,35 @@
+package main
+
+import "testing"
+
+func TestColdStartBuffer(t *testing.T) {
+	gate := make(chan struct{})
+	pool := NewTypedPool(func() *int { <-gate; return new(int) },
+		WithColdStartBuffer[*int](3),
+		WithFIFO[*int](),
+	)
+
+	select {
+	case <-pool.Ready():
+		t.Fatal("Ready closed before the warmup could build anything")
+	default:
+	}
+	close(gate)
+	<-pool.Ready()
+
+	if got := pool.Len(); got != 3 {
+		t.Fatalf("Len() = %d after Ready, want 3", got)
+	}
+	if _, origin := pool.GetInfo(); origin != OriginReused {
+		t.Errorf("Get after Ready = %v, want a warmed item", origin)
+	}
+}
+
+func TestReadyWithoutColdStartBuffer(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) })
+	select {
+	case <-pool.Ready():
+	default:
+		t.Fatal("Ready not closed without WithColdStartBuffer")
+	}
+}
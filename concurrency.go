@@ -0,0 +1,129 @@
+package main
+
+import (
+	"math/bits"
+	"sync/atomic"
+)
+
+// WithConcurrencyProfile tracks how many items are checked out at once and
+// reports the peak as Stats().PeakConcurrency, for sizing a bounded pool.
+// ResetStats starts a new measurement.
+func WithConcurrencyProfile[T any]() PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.concurrency = true
+	}
+}
+
+// WithBorrowCapTrace keeps a histogram of how many items were already
+// checked out at each Get, reported as Stats().BorrowDepthHistogram, which
+// shows whether the pool is usually idle or usually contested. The buckets
+// count depths of 0, 1, 2-3, 4-7, 8-15, 16-31, 32-63 and 64 or more.
+// ResetStats clears it.
+func WithBorrowCapTrace[T any]() PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.borrowTrace = true
+	}
+}
+
+// borrowDepthBuckets is the number of WithBorrowCapTrace buckets.
+const borrowDepthBuckets = 8
+
+// concurrencyProfile counts the items out of the pool. A nil
+// *concurrencyProfile records nothing.
+type concurrencyProfile struct {
+	inUse     atomic.Int64
+	maxInUse  atomic.Int64
+	periodMax atomic.Int64 // the peak since the last takePeriodPeak
+
+	depths *[borrowDepthBuckets]atomic.Uint64 // nil without WithBorrowCapTrace
+}
+
+func newConcurrencyProfile(enabled, trace bool) *concurrencyProfile {
+	if !enabled && !trace {
+		return nil
+	}
+	c := new(concurrencyProfile)
+	if trace {
+		c.depths = new([borrowDepthBuckets]atomic.Uint64)
+	}
+	return c
+}
+
+// borrow counts a Get and raises the peak if it is a new high.
+func (c *concurrencyProfile) borrow() {
+	if c == nil {
+		return
+	}
+	n := c.inUse.Add(1)
+	raise(&c.maxInUse, n)
+	raise(&c.periodMax, n)
+	if c.depths != nil {
+		c.depths[min(bits.Len64(uint64(n-1)), borrowDepthBuckets-1)].Add(1)
+	}
+}
+
+// raise lifts peak to n if n is higher.
+func raise(peak *atomic.Int64, n int64) {
+	for {
+		p := peak.Load()
+		if n <= p || peak.CompareAndSwap(p, n) {
+			return
+		}
+	}
+}
+
+// takePeriodPeak returns the peak since the previous call and starts the
+// next period from the items out now.
+func (c *concurrencyProfile) takePeriodPeak() int64 {
+	return c.periodMax.Swap(c.inUse.Load())
+}
+
+// release counts a Put and returns the items still out. Items that were
+// never borrowed, such as those from Warmup, do not take the count below
+// zero.
+func (c *concurrencyProfile) release() int64 {
+	if c == nil {
+		return -1
+	}
+	for {
+		n := c.inUse.Load()
+		if n <= 0 {
+			return 0
+		}
+		if c.inUse.CompareAndSwap(n, n-1) {
+			return n - 1
+		}
+	}
+}
+
+// peak returns the highest count seen.
+func (c *concurrencyProfile) peak() int64 {
+	if c == nil {
+		return 0
+	}
+	return c.maxInUse.Load()
+}
+
+// histogram returns the WithBorrowCapTrace counts.
+func (c *concurrencyProfile) histogram() [borrowDepthBuckets]uint64 {
+	var h [borrowDepthBuckets]uint64
+	if c != nil && c.depths != nil {
+		for i := range c.depths {
+			h[i] = c.depths[i].Load()
+		}
+	}
+	return h
+}
+
+// reset restarts the peak from the items out now and clears the histogram.
+func (c *concurrencyProfile) reset() {
+	if c == nil {
+		return
+	}
+	c.maxInUse.Store(c.inUse.Load())
+	if c.depths != nil {
+		for i := range c.depths {
+			c.depths[i].Store(0)
+		}
+	}
+}
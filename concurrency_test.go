@@ -0,0 +1,98 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestConcurrencyProfilePeak(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) }, WithConcurrencyProfile[*int]())
+
+	a, b, c := pool.Get(), pool.Get(), pool.Get()
+	pool.Put(a)
+	pool.Put(b)
+	d := pool.Get()
+	if got := pool.Stats().PeakConcurrency; got != 3 {
+		t.Fatalf("PeakConcurrency = %d, want 3", got)
+	}
+
+	pool.ResetStats()
+	if got := pool.Stats().PeakConcurrency; got != 2 {
+		t.Fatalf("PeakConcurrency after ResetStats = %d, want the 2 still out", got)
+	}
+	pool.Put(c)
+	pool.Put(d)
+	pool.Get()
+	if got := pool.Stats().PeakConcurrency; got != 2 {
+		t.Fatalf("PeakConcurrency = %d, want 2", got)
+	}
+}
+
+func TestConcurrencyProfileIgnoresWarmup(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) }, WithConcurrencyProfile[*int]())
+	pool.Warmup(5)
+
+	pool.Get()
+	if got := pool.Stats().PeakConcurrency; got != 1 {
+		t.Fatalf("PeakConcurrency = %d, want 1", got)
+	}
+}
+
+func TestConcurrencyProfileParallel(t *testing.T) {
+	const workers = 8
+	pool := NewTypedPool(func() *int { return new(int) }, WithConcurrencyProfile[*int]())
+
+	var held, release sync.WaitGroup
+	held.Add(workers)
+	release.Add(1)
+	var done sync.WaitGroup
+	done.Add(workers)
+	for range workers {
+		go func() {
+			defer done.Done()
+			v := pool.Get()
+			held.Done()
+			release.Wait()
+			pool.Put(v)
+		}()
+	}
+	held.Wait()
+	release.Done()
+	done.Wait()
+
+	if got := pool.Stats().PeakConcurrency; got != workers {
+		t.Fatalf("PeakConcurrency = %d, want %d", got, workers)
+	}
+}
+
+func TestResetStatsCounters(t *testing.T) {
+	pool := NewTypedPool(func() int { return 0 }, WithStats[int]())
+	pool.Put(pool.Get())
+
+	pool.ResetStats()
+	if got := pool.Stats(); got != (Stats{}) {
+		t.Fatalf("Stats after ResetStats = %+v, want zero", got)
+	}
+}
+
+func TestBorrowCapTrace(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) }, WithBorrowCapTrace[*int]())
+
+	var out []*int
+	for range 70 {
+		out = append(out, pool.Get())
+	}
+	want := [8]uint64{1, 1, 2, 4, 8, 16, 32, 6}
+	if got := pool.Stats().BorrowDepthHistogram; got != want {
+		t.Fatalf("histogram = %v, want %v", got, want)
+	}
+
+	for _, v := range out {
+		pool.Put(v)
+	}
+	pool.ResetStats()
+	pool.Put(pool.Get())
+	if got := pool.Stats().BorrowDepthHistogram; got != [8]uint64{1} {
+		t.Fatalf("histogram after ResetStats and an idle Get = %v, want [1 0 ...]", got)
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+// WithConditionalNew makes every miss ask cond first: the pool's constructor
+// runs only while cond returns true, and fallbackNew builds the item
+// otherwise, for instance a stub connection while the database is
+// unreachable. Once cond reports true again, later misses go back to the real
+// constructor. Fallback items are pooled like any other, so a pool that must
+// not hand them out after recovery should reject them with WithReuseCheck.
+func WithConditionalNew[T any](cond func() bool, fallbackNew func() T) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.condNew = &conditionalNew[T]{cond: cond, fallback: fallbackNew}
+	}
+}
+
+// conditionalNew is the WithConditionalNew configuration.
+type conditionalNew[T any] struct {
+	cond     func() bool
+	fallback func() T
+}
+
+// wrap returns newFn guarded by the condition.
+func (c *conditionalNew[T]) wrap(newFn func() T) func() T {
+	return func() T {
+		if c.cond() {
+			return newFn()
+		}
+		return c.fallback()
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestConditionalNewFallsBackWhileConditionFails(t *testing.T) {
+	var reachable atomic.Bool
+	pool := NewTypedPool(func() string { return "real" },
+		WithConditionalNew(reachable.Load, func() string { return "stub" }))
+
+	if got := pool.Get(); got != "stub" {
+		t.Fatalf("Get while unreachable = %q, want stub", got)
+	}
+	reachable.Store(true)
+	if got := pool.Get(); got != "real" {
+		t.Fatalf("Get once reachable = %q, want real", got)
+	}
+	reachable.Store(false)
+	if got := pool.Get(); got != "stub" {
+		t.Fatalf("Get after losing reachability = %q, want stub", got)
+	}
+}
+
+func TestConditionalNewOnlyAffectsMisses(t *testing.T) {
+	var reachable atomic.Bool
+	pool := NewTypedPool(func() *string { s := "real"; return &s },
+		WithFIFO[*string](),
+		WithConditionalNew(reachable.Load, func() *string { s := "stub"; return &s }))
+
+	reachable.Store(true)
+	real := pool.Get()
+	pool.Put(real)
+	reachable.Store(false)
+	if got := pool.Get(); got != real {
+		t.Fatalf("Get = %q, want the pooled real item", *got)
+	}
+}
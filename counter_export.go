@@ -0,0 +1,61 @@
+//go:build prometheus
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// WithCounterExport registers the pool's hits, misses, puts and discards
+// with reg as the counters name_pool_hits_total and so on, the names
+// Telemetry's Prometheus output uses. It implies WithStats. The counters are
+// unregistered by Close, so tests can build the same pool again against one
+// registry; a pool that is never closed keeps them registered. It panics if
+// reg refuses a counter, for instance because another pool already exports
+// under name.
+//
+// It needs the prometheus build tag, to keep client_golang out of builds that
+// do not use it.
+func WithCounterExport[T any](reg prometheus.Registerer, name string) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.stats = true
+		cfg.attach = append(cfg.attach, func(tp *TypedPool[T]) func() {
+			return exportCounters(reg, name, tp.Stats)
+		})
+	}
+}
+
+// exportCounters registers the counters read from stats and returns the
+// function that unregisters them.
+func exportCounters(reg prometheus.Registerer, name string, stats func() Stats) func() {
+	read := []struct {
+		name  string
+		value func(Stats) int64
+	}{
+		{"hits", func(s Stats) int64 { return s.Hits }},
+		{"misses", func(s Stats) int64 { return s.Misses }},
+		{"puts", func(s Stats) int64 { return s.Puts }},
+		{"discards", func(s Stats) int64 { return s.Discards }},
+	}
+
+	var registered []prometheus.Collector
+	unregister := func() {
+		for _, c := range registered {
+			reg.Unregister(c)
+		}
+	}
+	for _, r := range read {
+		c := prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name: name + "_pool_" + r.name + "_total",
+			Help: "Pool " + r.name + ", as counted by Stats.",
+		}, func() float64 { return float64(r.value(stats())) })
+		if err := reg.Register(c); err != nil {
+			unregister()
+			panic(fmt.Sprintf("WithCounterExport: %v", err))
+		}
+		registered = append(registered, c)
+	}
+	return unregister
+}
@@ -0,0 +1,42 @@
+//go:build prometheus
+
+package main
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestCounterExportRegistersAndUnregisters(t *testing.T) {
+	reg := prometheus.NewPedanticRegistry()
+	pool := NewTypedPool(func() *int { return new(int) }, WithFIFO[*int](), WithCounterExport[*int](reg, "api"))
+
+	pool.Put(pool.Get())
+	pool.Get()
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]float64{}
+	for _, f := range families {
+		got[f.GetName()] = f.GetMetric()[0].GetCounter().GetValue()
+	}
+	want := map[string]float64{
+		"api_pool_hits_total":     1,
+		"api_pool_misses_total":   1,
+		"api_pool_puts_total":     1,
+		"api_pool_discards_total": 0,
+	}
+	for name, v := range want {
+		if g, ok := got[name]; !ok || g != v {
+			t.Errorf("%s = %v (registered %v), want %v", name, g, ok, v)
+		}
+	}
+
+	pool.Close()
+	// The same names register again once the first pool is closed.
+	again := NewTypedPool(func() *int { return new(int) }, WithCounterExport[*int](reg, "api"))
+	again.Close()
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrBudgetExhausted is returned by TypedPool.TryGet when a miss finds the
+// WithConstructorBudget spent.
+var ErrBudgetExhausted = errors.New("pool: constructor budget exhausted")
+
+// WithConstructorBudget caps how many times the pool may construct an item
+// over its lifetime, for items whose construction uses up something scarce,
+// such as a license seat per TLS handshake. Every miss spends one unit;
+// once maxNew are spent, a miss is a normal outcome rather than a bug:
+// TryGet returns ErrBudgetExhausted and Get returns the WithItemSentinel
+// value or the zero value, while hits are served as before. AddBudget
+// grants more.
+func WithConstructorBudget[T any](maxNew int64) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.ctorBudget = &maxNew
+	}
+}
+
+// TryGet is Get for pools with a WithConstructorBudget or WithAbortOnGet:
+// instead of a bare zero value it returns ErrBudgetExhausted when it misses
+// with the budget spent and ErrAborted when the call is shed.
+func (tp *TypedPool[T]) TryGet() (T, error) {
+	if tp.classes != nil {
+		if tp.aborted() {
+			return tp.noItem(), ErrAborted
+		}
+		tp.wait(context.Background())
+		if err := tp.acquire(context.Background()); err != nil {
+			return tp.noItem(), err
+		}
+		return tp.classes[0].Get(), nil
+	}
+	v, _, err := tp.get(context.Background(), 0)
+	return v, err
+}
+
+// RemainingBudget returns how many more constructions the
+// WithConstructorBudget allows, or -1 if the pool has no budget.
+func (tp *TypedPool[T]) RemainingBudget() int64 {
+	if tp.budget == nil {
+		return -1
+	}
+	return tp.budget.Load()
+}
+
+// AddBudget grants n more constructions; a negative n takes them back, down
+// to none. It does nothing without WithConstructorBudget.
+func (tp *TypedPool[T]) AddBudget(n int64) {
+	if tp.budget == nil {
+		return
+	}
+	for {
+		old := tp.budget.Load()
+		if tp.budget.CompareAndSwap(old, max(old+n, 0)) {
+			return
+		}
+	}
+}
+
+// spendBudget takes one construction from the budget, reporting false if
+// none is left.
+func (tp *TypedPool[T]) spendBudget() bool {
+	if tp.budget == nil {
+		return true
+	}
+	for {
+		old := tp.budget.Load()
+		if old <= 0 {
+			return false
+		}
+		if tp.budget.CompareAndSwap(old, old-1) {
+			return true
+		}
+	}
+}
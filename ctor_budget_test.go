@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConstructorBudget(t *testing.T) {
+	news := 0
+	pool := NewTypedPool(func() *int { news++; return new(int) },
+		WithConstructorBudget[*int](2), WithFIFO[*int](), WithStats[*int]())
+
+	a, _ := pool.TryGet()
+	if _, err := pool.TryGet(); err != nil {
+		t.Fatalf("second TryGet: %v", err)
+	}
+	if got := pool.RemainingBudget(); got != 0 {
+		t.Fatalf("RemainingBudget = %d, want 0", got)
+	}
+	if v, err := pool.TryGet(); !errors.Is(err, ErrBudgetExhausted) || v != nil {
+		t.Fatalf("TryGet past the budget = %v, %v; want nil, ErrBudgetExhausted", v, err)
+	}
+
+	pool.Put(a)
+	if v, err := pool.TryGet(); err != nil || v != a {
+		t.Fatalf("TryGet of a pooled item = %v, %v; want the item", v, err)
+	}
+
+	pool.AddBudget(1)
+	if _, err := pool.TryGet(); err != nil {
+		t.Fatalf("TryGet after AddBudget: %v", err)
+	}
+	if news != 3 {
+		t.Fatalf("constructor calls = %d, want 3", news)
+	}
+	if s := pool.Stats(); s.Misses != 3 || s.Hits != 1 {
+		t.Fatalf("hits, misses = %d, %d; want 1, 3", s.Hits, s.Misses)
+	}
+}
+
+func TestConstructorBudgetGetReturnsNoItem(t *testing.T) {
+	sentinel := new(int)
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithConstructorBudget[*int](0), WithItemSentinel(sentinel))
+	if v := pool.Get(); v != sentinel {
+		t.Fatalf("Get with the budget spent = %p, want the sentinel %p", v, sentinel)
+	}
+}
+
+func TestConstructorBudgetUnset(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) })
+	pool.AddBudget(5)
+	if got := pool.RemainingBudget(); got != -1 {
+		t.Fatalf("RemainingBudget without a budget = %d, want -1", got)
+	}
+	if _, err := pool.TryGet(); err != nil {
+		t.Fatalf("TryGet: %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ConstructorObserver is told about every call of a pool's constructor: the
+// base on which to build metrics, tracing or logging of constructions
+// without touching the pool itself. AfterNew receives how long the call
+// took and, for a constructor passed to PreHeatFunc, the error it returned.
+// A constructor that panics is reported with an error describing the panic,
+// which then carries on. Both methods run on the constructing goroutine and
+// must not call back into the pool.
+type ConstructorObserver interface {
+	BeforeNew()
+	AfterNew(d time.Duration, err error)
+}
+
+// WithConstructorObserver reports every constructor call to obs.
+func WithConstructorObserver[T any](obs ConstructorObserver) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.ctorObserver = obs
+	}
+}
+
+// observedNew wraps newFn so each call is reported to obs.
+func observedNew[T any](obs ConstructorObserver, newFn func() T) func() T {
+	return func() T {
+		v, _ := observe(obs, func() (T, error) { return newFn(), nil })
+		return v
+	}
+}
+
+// observedNewCtx is observedNew for the constructors PreHeatFunc takes. It
+// returns newFn itself when obs is nil.
+func observedNewCtx[T any](obs ConstructorObserver, newFn func(context.Context) (T, error)) func(context.Context) (T, error) {
+	if obs == nil {
+		return newFn
+	}
+	return func(ctx context.Context) (T, error) {
+		return observe(obs, func() (T, error) { return newFn(ctx) })
+	}
+}
+
+// observe runs newFn between obs's BeforeNew and AfterNew.
+func observe[T any](obs ConstructorObserver, newFn func() (T, error)) (v T, err error) {
+	obs.BeforeNew()
+	start := time.Now()
+	panicked := true
+	defer func() {
+		if panicked {
+			r := recover()
+			obs.AfterNew(time.Since(start), fmt.Errorf("constructor panicked: %v", r))
+			if r != nil { // otherwise runtime.Goexit, which carries on by itself
+				panic(r)
+			}
+			return
+		}
+		obs.AfterNew(time.Since(start), err)
+	}()
+	v, err = newFn()
+	panicked = false
+	return v, err
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingObserver records each AfterNew error.
+type recordingObserver struct {
+	mu      sync.Mutex
+	started int
+	errs    []error
+}
+
+func (o *recordingObserver) BeforeNew() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.started++
+}
+
+func (o *recordingObserver) AfterNew(d time.Duration, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.errs = append(o.errs, err)
+}
+
+func TestConstructorObserver(t *testing.T) {
+	obs := new(recordingObserver)
+	pool := NewTypedPool(func() *int { return new(int) }, WithConstructorObserver[*int](obs), WithFIFO[*int]())
+
+	pool.Put(pool.Get())
+	pool.Get()
+	if err := pool.PreHeat(context.Background(), 2, 3); err != nil {
+		t.Fatal(err)
+	}
+	if obs.started != 4 || len(obs.errs) != 4 {
+		t.Fatalf("observed %d starts and %d ends, want 4 of each", obs.started, len(obs.errs))
+	}
+
+	errDial := errors.New("dial failed")
+	err := pool.PreHeatFunc(context.Background(), 1, 2, func(context.Context) (*int, error) { return nil, errDial })
+	if err != errDial {
+		t.Fatalf("PreHeatFunc = %v, want %v", err, errDial)
+	}
+	if got := obs.errs[len(obs.errs)-1]; got != errDial {
+		t.Fatalf("AfterNew error = %v, want the constructor's", got)
+	}
+}
+
+func TestConstructorObserverPanic(t *testing.T) {
+	obs := new(recordingObserver)
+	pool := NewTypedPool(func() *int { panic("out of sockets") }, WithConstructorObserver[*int](obs))
+	defer func() {
+		if r := recover(); r != "out of sockets" {
+			t.Fatalf("recovered %v, want the constructor's panic", r)
+		}
+		if len(obs.errs) != 1 || obs.errs[0] == nil {
+			t.Fatalf("AfterNew errors = %v, want one describing the panic", obs.errs)
+		}
+	}()
+	pool.Get()
+}
@@ -0,0 +1,17 @@
+package main
+
+// WithConstructorPool2 builds the pool's items from a recycled argument:
+// every miss takes an A from argPool, passes it to newFn and Puts it back once
+// newFn returns, so the construction-time scratch value (a *strings.Builder
+// assembling a connection string, say) is not allocated per item. newFn must
+// not retain its argument. It replaces the constructor given to NewTypedPool,
+// which may then be nil.
+func WithConstructorPool2[T, A any](argPool *TypedPool[A], newFn func(A) T) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.newFn = func() T {
+			a := argPool.Get()
+			defer argPool.Put(a)
+			return newFn(a)
+		}
+	}
+}
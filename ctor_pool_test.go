@@ -0,0 +1,38 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConstructorPool2RecyclesArgument(t *testing.T) {
+	var builders int
+	args := NewTypedPool(func() *strings.Builder {
+		builders++
+		return new(strings.Builder)
+	}, WithFIFO[*strings.Builder]())
+
+	var seen []*strings.Builder
+	pool := NewTypedPool(nil, WithConstructorPool2(args, func(b *strings.Builder) string {
+		seen = append(seen, b)
+		b.Reset()
+		b.WriteString("host=db port=")
+		b.WriteString("5432")
+		return b.String()
+	}))
+
+	for range 3 {
+		if got := pool.Get(); got != "host=db port=5432" {
+			t.Fatalf("Get = %q", got)
+		}
+	}
+
+	if builders != 1 {
+		t.Errorf("argument constructor ran %d times, want 1", builders)
+	}
+	for i, b := range seen {
+		if b != seen[0] {
+			t.Errorf("construction %d used a different builder", i)
+		}
+	}
+}
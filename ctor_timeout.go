@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// WithConstructorTimeout bounds how long a Get that misses waits for the
+// constructor. If it has not returned within d, Get returns fallbackFn()
+// instead. The constructor keeps running on a goroutine of its own, not the
+// pool's Scheduler, whose workers may be busy or run it inline, and its
+// result is Put into the pool once it completes.
+func WithConstructorTimeout[T any](d time.Duration, fallbackFn func() T) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.ctorTimeout = d
+		cfg.ctorFallback = fallbackFn
+	}
+}
+
+// newWithTimeout runs the constructor for a Get made with ctx under the
+// WithConstructorTimeout limit.
+func (tp *TypedPool[T]) newWithTimeout(ctx context.Context) T {
+	result := make(chan T, 1)
+	go func() { result <- tp.newIn(ctx) }()
+
+	timer := time.NewTimer(tp.cfg.ctorTimeout)
+	defer timer.Stop()
+	select {
+	case v := <-result:
+		return v
+	case <-timer.C:
+		go func() { tp.putBack(<-result) }()
+		return tp.cfg.ctorFallback()
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithConstructorTimeout(t *testing.T) {
+	release := make(chan struct{})
+	pool := NewTypedPool(func() *int { <-release; v := 1; return &v },
+		WithFIFO[*int](),
+		WithConstructorTimeout(5*time.Millisecond, func() *int { v := -1; return &v }),
+	)
+
+	if got := *pool.Get(); got != -1 {
+		t.Fatalf("Get() = %d, want the fallback -1", got)
+	}
+
+	close(release) // the abandoned constructor finishes and donates its item
+	deadline := time.Now().Add(time.Second)
+	for pool.Len() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("the late constructor result was never pooled")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if got := *pool.Get(); got != 1 {
+		t.Fatalf("Get() = %d, want the donated 1", got)
+	}
+}
+
+func TestWithConstructorTimeoutFastConstructor(t *testing.T) {
+	pool := NewTypedPool(func() int { return 1 },
+		WithConstructorTimeout(time.Second, func() int { return -1 }),
+	)
+	if got := pool.Get(); got != 1 {
+		t.Fatalf("Get() = %d, want 1", got)
+	}
+}
+
+func TestWithConstructorTimeoutInlineScheduler(t *testing.T) {
+	pool := NewTypedPool(func() int { return 1 },
+		WithScheduler[int](SchedulerFunc(func(fn func()) { fn() })),
+		WithConstructorTimeout(time.Second, func() int { return -1 }),
+	)
+	if got := pool.Get(); got != 1 {
+		t.Fatalf("Get() = %d, want 1", got)
+	}
+}
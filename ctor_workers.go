@@ -0,0 +1,94 @@
+package main
+
+// WithSeparateGoroutineConstruction moves the constructor off the calling
+// goroutine, for CPU-bound constructors such as RSA key generation that
+// would otherwise stall the caller's P. workers goroutines call it ahead of
+// demand, keeping a few finished items queued; a Get that finds the pool
+// empty takes one from the queue, waiting if none is ready. A constructor
+// panic is re-raised in the Get that receives it. Close discards the queued
+// items, through the OnDiscard hook, and from then on the constructor runs
+// on the caller again. It panics if workers is less than 1.
+func WithSeparateGoroutineConstruction[T any](workers int) PoolOption[T] {
+	if workers < 1 {
+		panic("WithSeparateGoroutineConstruction: workers must be at least 1")
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.ctorWorkers = workers
+	}
+}
+
+// built is one result of a WithSeparateGoroutineConstruction worker.
+type built[T any] struct {
+	v        T
+	panicked any
+}
+
+// startCtorWorkers starts the WithSeparateGoroutineConstruction workers and
+// points the pool's constructor at their queue.
+func (tp *TypedPool[T]) startCtorWorkers() {
+	newFn := tp.newFn
+	queue := make(chan built[T], tp.cfg.ctorWorkers)
+	for range tp.cfg.ctorWorkers {
+		tp.bg.run(tp.cfg.schedule, func(stop <-chan struct{}) {
+			// Whatever is still queued when this worker stops, its own last
+			// item included, was never handed out.
+			defer tp.discardQueued(queue)
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				b := buildRecovered(newFn)
+				select {
+				case queue <- b:
+				case <-stop:
+					tp.discardBuilt(b)
+					return
+				}
+			}
+		})
+	}
+	tp.newFn = func() T {
+		select {
+		case b := <-queue:
+			if b.panicked != nil {
+				panic(b.panicked)
+			}
+			return b.v
+		case <-tp.bg.stop:
+			return newFn()
+		}
+	}
+}
+
+// discardQueued discards the items left in queue.
+func (tp *TypedPool[T]) discardQueued(queue chan built[T]) {
+	for {
+		select {
+		case b := <-queue:
+			tp.discardBuilt(b)
+		default:
+			return
+		}
+	}
+}
+
+// discardBuilt discards an item a worker built but no Get took.
+func (tp *TypedPool[T]) discardBuilt(b built[T]) {
+	if b.panicked == nil {
+		tp.discard(b.v)
+	}
+}
+
+// buildRecovered calls newFn, capturing a panic instead of letting it kill
+// the worker's goroutine.
+func buildRecovered[T any](newFn func() T) (b built[T]) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.panicked = r
+		}
+	}()
+	b.v = newFn()
+	return b
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestSeparateGoroutineConstruction(t *testing.T) {
+	var built atomic.Int64
+	pool := NewTypedPool(func() *int { n := int(built.Add(1)); return &n },
+		WithSeparateGoroutineConstruction[*int](1),
+		WithStats[*int](),
+	)
+
+	waitFor(t, func() bool { return built.Load() >= 2 }) // one queued, one waiting to be
+	if got := *pool.Get(); got != 1 {
+		t.Fatalf("Get = item %d, want the first one built ahead", got)
+	}
+	if s := pool.Stats(); s.Misses != 1 {
+		t.Fatalf("Misses = %d, want the queued item to count as a miss", s.Misses)
+	}
+
+	pool.Close()
+	for range 3 { // the workers are gone, so these construct on the caller
+		if pool.Get() == nil {
+			t.Fatal("Get after Close returned nil")
+		}
+	}
+}
+
+func TestSeparateGoroutineConstructionPanic(t *testing.T) {
+	pool := NewTypedPool(func() *int { panic("no entropy") },
+		WithSeparateGoroutineConstruction[*int](1),
+	)
+	defer pool.Close()
+	defer func() {
+		if r := recover(); r != "no entropy" {
+			t.Fatalf("recovered %v, want the constructor's panic", r)
+		}
+	}()
+	pool.Get()
+}
+
+func TestSeparateGoroutineConstructionDiscardsQueueOnClose(t *testing.T) {
+	var built, discarded atomic.Int64
+	pool := NewTypedPool(func() *int { n := int(built.Add(1)); return &n },
+		WithSeparateGoroutineConstruction[*int](2),
+		WithOnDiscard(func(*int) { discarded.Add(1) }),
+	)
+
+	waitFor(t, func() bool { return built.Load() >= 2 })
+	pool.Get()
+	pool.Close()
+	if got, want := discarded.Load(), built.Load()-1; got != want {
+		t.Fatalf("Close discarded %d items, want the %d built and never handed out", got, want)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// lockedBuffer is a bytes.Buffer safe for a background writer.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestWithDeadlockDetector(t *testing.T) {
+	var out lockedBuffer
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithDeadlockDetector[*int](10*time.Millisecond),
+		WithLogger[*int](NewLogger(&out)),
+	)
+	defer pool.Close()
+
+	pool.Put(pool.Get())
+	pool.Get()
+
+	deadline := time.Now().Add(time.Second)
+	for !strings.Contains(out.String(), "checked out too long") {
+		if time.Now().After(deadline) {
+			t.Fatal("no warning for an item held past the timeout")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	pool.Close()
+
+	if n := strings.Count(out.String(), "checked out too long"); n != 1 {
+		t.Fatalf("got %d warnings, want 1:\n%s", n, out.String())
+	}
+	if !strings.Contains(out.String(), "WARN") {
+		t.Fatalf("warning not logged at WARN:\n%s", out.String())
+	}
+}
+
+func TestCloseWithoutDetector(t *testing.T) {
+	pool := NewTypedPool(func() int { return 0 })
+	pool.Close()
+	pool.Close()
+}
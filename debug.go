@@ -0,0 +1,24 @@
+//go:build debug
+
+package main
+
+import "time"
+
+// debugBuild enables the checks that cost something on every call, such as
+// poisoning released line buffers.
+const debugBuild = true
+
+// debugHoldTimeout is the WithDeadlockDetector timeout used by WithDebugMode.
+const debugHoldTimeout = time.Minute
+
+// WithDebugMode turns on WithDeadlockDetector with a one-minute timeout, so
+// a debug build reports items held too long without tuning a timeout per
+// pool. WithGoroutineTracking is left to the caller, since it makes Put
+// panic on hand-offs that are otherwise fine. It only does so in builds with
+// the debug tag and is a no-op otherwise.
+func WithDebugMode[T any]() PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.debugMode = true
+		cfg.deadlockTimeout = debugHoldTimeout
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import "time"
+
+// WithDebugExpiry turns the WithDebugMode checks off once d has passed since
+// NewTypedPool, so a production pool can be debugged for its first minutes
+// without paying for the checks forever. From then on the pool behaves as if
+// WithDebugMode was never set, which includes its deadlock detector even if
+// WithDeadlockDetector was also given. Without WithDebugMode, or without the
+// debug build tag, it does nothing.
+func WithDebugExpiry[T any](d time.Duration) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.debugExpiry = d
+	}
+}
+
+// startDebugExpiry arms the WithDebugExpiry timer, which Close stops.
+func (tp *TypedPool[T]) startDebugExpiry() {
+	timer := time.AfterFunc(tp.cfg.debugExpiry, tp.expireDebug)
+	tp.detach = append(tp.detach, func() { timer.Stop() })
+}
+
+// expireDebug turns the WithDebugMode checks off. The checkout table goes
+// too unless another option still reads it.
+func (tp *TypedPool[T]) expireDebug() {
+	tp.debugOff.Store(true)
+	if !tp.cfg.checkoutsShared() {
+		tp.checkouts.m.Clear()
+	}
+}
+
+// checkoutsShared reports whether an option other than the deadlock
+// detector reads the checkout table.
+func (cfg *poolConfig[T]) checkoutsShared() bool {
+	return cfg.softTimeout > 0 || cfg.goroutineTracking || cfg.drainTimeout > 0
+}
+
+// debugExpired reports whether WithDebugExpiry has turned the debug checks
+// off.
+func (tp *TypedPool[T]) debugExpired() bool {
+	return tp.cfg.debugMode && tp.debugOff.Load()
+}
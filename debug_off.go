@@ -0,0 +1,14 @@
+//go:build !debug
+
+package main
+
+// debugBuild is false outside builds with the debug tag, so the checks it
+// guards compile away.
+const debugBuild = false
+
+// WithDebugMode turns on WithDeadlockDetector with a one-minute timeout, in
+// builds with the debug tag. Without the tag it does nothing,
+// so it costs nothing in production.
+func WithDebugMode[T any]() PoolOption[T] {
+	return func(*poolConfig[T]) {}
+}
@@ -0,0 +1,14 @@
+//go:build !debug
+
+package main
+
+import "testing"
+
+func TestWithDebugModeIsNoOp(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) }, WithDebugMode[*int]())
+	defer pool.Close()
+
+	if pool.cfg.deadlockTimeout != 0 || pool.checkouts != nil {
+		t.Fatal("debug mode changed the pool without the debug tag")
+	}
+}
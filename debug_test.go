@@ -0,0 +1,109 @@
+//go:build debug
+
+package main
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestWithDebugModeEnablesChecks(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) }, WithDebugMode[*int]())
+	defer pool.Close()
+
+	if pool.cfg.deadlockTimeout != debugHoldTimeout || pool.checkouts == nil {
+		t.Fatal("debug mode did not enable the deadlock detector")
+	}
+}
+
+// stashWriter keeps the slices it is given, which io.Writer forbids.
+type stashWriter struct {
+	stashed [][]byte
+}
+
+func (w *stashWriter) Write(p []byte) (int, error) {
+	w.stashed = append(w.stashed, p)
+	return len(p), nil
+}
+
+func TestDebugPoisonsRetainedLines(t *testing.T) {
+	w := new(stashWriter)
+	logger := NewLogger(w, WithTimeLayout(""))
+
+	logger.Info("secret", "token", 42)
+	log(w, "legacy")
+
+	if len(w.stashed) != 2 {
+		t.Fatalf("got %d writes, want 2", len(w.stashed))
+	}
+	for i, p := range w.stashed {
+		for _, c := range p {
+			if c != poisonByte {
+				t.Fatalf("write %d still reads %q after its buffer was released", i, p)
+			}
+		}
+	}
+}
+
+func TestDebugRecoversAbandonedBatch(t *testing.T) {
+	logger := NewLogger(&stashWriter{})
+	before := AbandonedBatches()
+
+	func() {
+		batch := logger.Batch()
+		batch.Info("never flushed")
+	}()
+	flushed := logger.Batch()
+	flushed.Info("flushed")
+	flushed.Flush()
+
+	waitFor(t, func() bool {
+		runtime.GC()
+		return AbandonedBatches() == before+1
+	})
+	runtime.KeepAlive(flushed)
+}
+
+func TestDebugCatchesEventMisuse(t *testing.T) {
+	logger := NewLogger(&stashWriter{})
+
+	e := logger.InfoEvent()
+	e.Msg("once")
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("a second Msg did not panic")
+			}
+		}()
+		e.Msg("twice")
+	}()
+
+	before := AbandonedEvents()
+	func() {
+		logger.InfoEvent().Str("k", "v")
+	}()
+	waitFor(t, func() bool {
+		runtime.GC()
+		return AbandonedEvents() == before+1
+	})
+}
+
+func TestWithDebugExpiryTurnsChecksOff(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithDebugMode[*int](), WithDebugExpiry[*int](10*time.Millisecond))
+	defer pool.Close()
+
+	pool.Get()
+	if pool.debugExpired() {
+		t.Fatal("debug checks off before the expiry")
+	}
+	waitFor(t, pool.debugExpired)
+
+	held := pool.Get()
+	pool.checkouts.m.Range(func(key, _ any) bool {
+		t.Fatalf("checkout %#x tracked after the expiry", key)
+		return false
+	})
+	pool.Put(held)
+}
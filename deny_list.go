@@ -0,0 +1,34 @@
+package main
+
+// WithDenyList keeps items for which deny returns true out of the pool: Put
+// discards them through OnDiscard before any reset, validation or eviction
+// policy sees them. Use it for items built with flags that make them unsafe
+// to hand to another caller. deny must not call back into the pool.
+func WithDenyList[T any](deny func(T) bool) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.deny = deny
+	}
+}
+
+// WithAllowList is the dual of WithDenyList: Put keeps only items for which
+// allow returns true and discards the rest through OnDiscard. Where
+// WithHealthCheck judges whether an item still works, allow judges what it
+// is, such as only recycling items whose source is internal. With both
+// options an item is pooled only if it is allowed and not denied. allow
+// must not call back into the pool.
+func WithAllowList[T any](allow func(T) bool) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.allow = allow
+	}
+}
+
+// denied reports whether the WithDenyList or WithAllowList function refuses
+// v, dropping it if so.
+func (tp *TypedPool[T]) denied(v T) bool {
+	deny := tp.cfg.deny != nil && tp.cfg.deny(v)
+	if !deny && (tp.cfg.allow == nil || tp.cfg.allow(v)) {
+		return false
+	}
+	tp.drop(v)
+	return true
+}
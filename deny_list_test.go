@@ -0,0 +1,58 @@
+package main
+
+import "testing"
+
+type flagged struct{ unsafe bool }
+
+func TestDenyList(t *testing.T) {
+	var discarded, resets int
+	pool := NewTypedPool(func() *flagged { return new(flagged) },
+		WithDenyList(func(v *flagged) bool { return v.unsafe }),
+		WithOnDiscard(func(*flagged) { discarded++ }),
+		WithReset(func(*flagged) { resets++ }),
+		WithStats[*flagged](),
+		WithFIFO[*flagged](),
+	)
+
+	bad, good := pool.Get(), pool.Get()
+	bad.unsafe = true
+	pool.Put(bad)
+	pool.Put(good)
+
+	if discarded != 1 || resets != 1 {
+		t.Fatalf("discarded %d, reset %d; want the denied item discarded unreset", discarded, resets)
+	}
+	if s := pool.Stats(); s.Discards != 1 || s.Puts != 2 {
+		t.Fatalf("Stats = %+v, want 1 discard of 2 puts", s)
+	}
+	if got := pool.Get(); got != good {
+		t.Fatal("Get did not return the allowed item")
+	}
+}
+
+func TestAllowListComposesWithDenyList(t *testing.T) {
+	type item struct{ internal, unsafe bool }
+	var discarded []*item
+	pool := NewTypedPool(func() *item { return new(item) },
+		WithAllowList(func(v *item) bool { return v.internal }),
+		WithDenyList(func(v *item) bool { return v.unsafe }),
+		WithOnDiscard(func(v *item) { discarded = append(discarded, v) }),
+		WithFIFO[*item](),
+	)
+
+	external := pool.Get()
+	internal := pool.Get()
+	internal.internal = true
+	both := pool.Get()
+	both.internal, both.unsafe = true, true
+
+	pool.Put(external)
+	pool.Put(internal)
+	pool.Put(both)
+	if len(discarded) != 2 || discarded[0] != external || discarded[1] != both {
+		t.Fatalf("discarded %v, want the external and the unsafe item", discarded)
+	}
+	if got := pool.Get(); got != internal {
+		t.Fatal("Get did not return the allowed item")
+	}
+}
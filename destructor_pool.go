@@ -0,0 +1,30 @@
+package main
+
+// WithDestructorPool hands every item the pool refuses to keep to dp
+// instead of cleaning it up on the caller's goroutine: discarded items are
+// Put into dp, and the pool's own OnDiscard hook and WithObjectFactory
+// Destroy method are not called. dp is normally a NewDestructorPool, whose
+// workers do the slow part, such as closing connections, off the Put path.
+func WithDestructorPool[T any](dp *TypedPool[T]) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.destructor = dp
+	}
+}
+
+// NewDestructorPool returns a pool that works as a cleanup queue for
+// WithDestructorPool: every item Put into it is queued, up to queue of
+// them, and passed to destroy by one of workers background goroutines.
+// When the queue is full, Put runs destroy itself, so cleanup slows the
+// caller down rather than piling up. Close waits for the queued items to be
+// destroyed. Get on it returns the zero T.
+func NewDestructorPool[T any](workers, queue int, destroy func(T)) *TypedPool[T] {
+	return NewTypedPool(func() T {
+		var zero T
+		return zero
+	},
+		WithSingletonGet[T](),
+		WithOnDiscard(destroy),
+		WithAsyncPut[T](queue),
+		func(cfg *poolConfig[T]) { cfg.asyncPutWorkers = workers },
+	)
+}
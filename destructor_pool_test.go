@@ -0,0 +1,52 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestDestructorPoolCleansUpOffThePutPath(t *testing.T) {
+	release := make(chan struct{})
+	var (
+		mu        sync.Mutex
+		destroyed []*int
+	)
+	dp := NewDestructorPool(2, 8, func(v *int) {
+		<-release
+		mu.Lock()
+		destroyed = append(destroyed, v)
+		mu.Unlock()
+	})
+	hooked := 0
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithMaxItems[*int](1),
+		WithOnDiscard(func(*int) { hooked++ }),
+		WithDestructorPool(dp),
+	)
+
+	items := []*int{pool.Get(), pool.Get(), pool.Get()}
+	for _, v := range items {
+		pool.Put(v) // would block if destroy ran here
+	}
+	close(release)
+	dp.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(destroyed) != 2 {
+		t.Fatalf("destroyed %d items, want the 2 the pool had no room for", len(destroyed))
+	}
+	if hooked != 0 {
+		t.Fatalf("the pool's OnDiscard ran %d times, want 0", hooked)
+	}
+}
+
+func TestDestructorPoolFullQueueDestroysInline(t *testing.T) {
+	var n int
+	dp := NewDestructorPool(1, 1, func(*int) { n++ })
+	dp.Close() // stop the worker so nothing is drained
+	dp.Put(new(int))
+	if n != 1 {
+		t.Fatalf("Put after Close destroyed %d items, want 1 inline", n)
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import "math"
+
+// Drain removes every idle item, WithStickyPool items included, passes each
+// to the OnDiscard hook, and returns how many were removed.
+func (tp *TypedPool[T]) Drain() int {
+	n := 0
+	for {
+		item, ok := tp.take()
+		if !ok {
+			break
+		}
+		tp.cfg.staggerBefore(n)
+		tp.discard(item)
+		n++
+	}
+	if tp.sticky != nil {
+		for _, item := range tp.unparkAll(math.MaxInt64) {
+			tp.cfg.staggerBefore(n)
+			tp.discard(item)
+			n++
+		}
+	}
+	return n
+}
+
+// take removes an idle item without ever calling the constructor.
+func (tp *TypedPool[T]) take() (T, bool) {
+	item, ok := tp.pool.get()
+	if !ok {
+		tp.pruneGone()
+		var zero T
+		return zero, false
+	}
+
+	tp.taken(item)
+	return item, true
+}
+
+// taken accounts for an idle item that left the store.
+func (tp *TypedPool[T]) taken(item T) {
+	tp.inPool.Add(-1)
+	tp.releaseCount()
+	tp.releaseWeight(item)
+	tp.retain(-tp.sizeOf(item))
+}
+
+// Drain removes every idle item, passes each to the OnDiscard hook, and
+// returns how many were removed.
+func (bp *BoundedPool[T]) Drain() int {
+	bp.mu.Lock()
+	var (
+		n     int
+		freed int64
+	)
+	for {
+		it, ok := bp.idle.popFront()
+		if !ok {
+			break
+		}
+		bp.discard(it.v)
+		freed += bp.forget(it)
+		n++
+	}
+	bp.mu.Unlock()
+
+	bp.release(freed)
+	return n
+}
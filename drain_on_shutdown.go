@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// WithDrainOnShutdown drains the pool when the process receives one of sigs,
+// or SIGINT or SIGTERM if none are given: every idle item goes to the
+// OnDiscard hook, the result is logged on the pool's Logger, and the pool is
+// closed. The signals are caught through signal.NotifyContext, so they no
+// longer end the process by themselves; the application must still handle
+// them, for instance with its own signal.NotifyContext, which is notified
+// too. Close unregisters the handler.
+func WithDrainOnShutdown[T any](sigs ...os.Signal) PoolOption[T] {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.shutdownSignals = sigs
+	}
+}
+
+// startDrainOnShutdown waits for a WithDrainOnShutdown signal until Close.
+func (tp *TypedPool[T]) startDrainOnShutdown() {
+	ctx, stop := signal.NotifyContext(context.Background(), tp.cfg.shutdownSignals...)
+	tp.bg.run(tp.cfg.schedule, func(done <-chan struct{}) {
+		defer stop()
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+		}
+		n := tp.Drain()
+		tp.cfg.log().Info("pool drained on shutdown", "pool", tp.cfg.name(), "discarded", n)
+		// Close waits for this task to return, so it cannot run here.
+		go tp.Close()
+	})
+}
@@ -0,0 +1,40 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+)
+
+func TestDrainOnShutdown(t *testing.T) {
+	var out lockedBuffer
+	var discarded atomic.Int64
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithDrainOnShutdown[*int](syscall.SIGUSR1),
+		WithOnDiscard(func(*int) { discarded.Add(1) }),
+		WithLogger[*int](NewLogger(&out)),
+		WithFIFO[*int](),
+	)
+	defer pool.Close()
+	a, b := pool.Get(), pool.Get()
+	pool.Put(a)
+	pool.Put(b)
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return strings.Contains(out.String(), "drained on shutdown") })
+	if n := discarded.Load(); n != 2 {
+		t.Fatalf("discarded %d items, want 2", n)
+	}
+	if got := pool.Len(); got != 0 {
+		t.Fatalf("Len() = %d after the signal, want 0", got)
+	}
+	if !strings.Contains(out.String(), "discarded=2") {
+		t.Errorf("log = %q, want the discard count", out.String())
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import "sync/atomic"
+
+// WithDynamicNew builds the pool's items with one of several constructors:
+// on every miss selector is handed newFns and returns the one to call, which
+// allows round-robin across backends, falling back from a fast constructor
+// to a slow one, or weighted random choice. SetSelector swaps the policy at
+// run time. It replaces the constructor given to NewTypedPool, which may then
+// be nil, and panics if newFns is empty.
+func WithDynamicNew[T any](newFns []func() T, selector func([]func() T) func() T) PoolOption[T] {
+	if len(newFns) == 0 {
+		panic("WithDynamicNew: newFns must not be empty")
+	}
+	return func(cfg *poolConfig[T]) {
+		d := &dynamicNew[T]{newFns: newFns}
+		d.selector.Store(&selector)
+		cfg.dynamicNew = d
+		cfg.newFn = d.new
+	}
+}
+
+// dynamicNew is the WithDynamicNew constructor.
+type dynamicNew[T any] struct {
+	newFns   []func() T
+	selector atomic.Pointer[func([]func() T) func() T]
+}
+
+// new constructs an item with the constructor the selector picks.
+func (d *dynamicNew[T]) new() T {
+	return (*d.selector.Load())(d.newFns)()
+}
+
+// SetSelector replaces the WithDynamicNew selector; misses already under way
+// keep the old one. It panics if the pool was not built WithDynamicNew.
+func (tp *TypedPool[T]) SetSelector(selector func([]func() T) func() T) {
+	if tp.cfg.dynamicNew == nil {
+		panic("SetSelector: pool built without WithDynamicNew")
+	}
+	tp.cfg.dynamicNew.selector.Store(&selector)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDynamicNewRoundRobin(t *testing.T) {
+	var next atomic.Int64
+	roundRobin := func(fns []func() string) func() string {
+		return fns[int(next.Add(1)-1)%len(fns)]
+	}
+	pool := NewTypedPool(nil, WithDynamicNew([]func() string{
+		func() string { return "a" },
+		func() string { return "b" },
+		func() string { return "c" },
+	}, roundRobin))
+
+	var got []string
+	for range 4 {
+		got = append(got, pool.Get())
+	}
+	if want := []string{"a", "b", "c", "a"}; !slices.Equal(got, want) {
+		t.Fatalf("constructed %v, want %v", got, want)
+	}
+}
+
+func TestSetSelector(t *testing.T) {
+	first := func(fns []func() int) func() int { return fns[0] }
+	last := func(fns []func() int) func() int { return fns[len(fns)-1] }
+	pool := NewTypedPool(nil, WithDynamicNew([]func() int{
+		func() int { return 1 },
+		func() int { return 2 },
+	}, first))
+
+	if got := pool.Get(); got != 1 {
+		t.Fatalf("Get() = %d, want 1", got)
+	}
+	pool.SetSelector(last)
+	if got := pool.Get(); got != 2 {
+		t.Fatalf("Get() after SetSelector = %d, want 2", got)
+	}
+}
+
+func TestSetSelectorWithoutDynamicNew(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("SetSelector did not panic")
+		}
+	}()
+	NewTypedPool(func() int { return 0 }).SetSelector(nil)
+}
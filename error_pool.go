@@ -0,0 +1,66 @@
+package main
+
+// ErrorPool recycles PooledErrors, so error paths that wrap a cause with
+// context do not allocate a wrapper per call:
+//
+//	err := errPool.NewError("reading config", cause)
+//	defer err.Put()
+//
+// Once Put, an error must not be used, nor kept by anything it was handed
+// to; it suits errors handled, logged or matched on the spot.
+type ErrorPool struct {
+	pool *TypedPool[*PooledError]
+}
+
+// PooledError is an error built by an ErrorPool: Msg, followed by Cause if
+// there is one. It unwraps to Cause.
+type PooledError struct {
+	Msg   string
+	Cause error
+
+	pool *ErrorPool
+}
+
+// NewErrorPool creates an ErrorPool; opts configure the underlying
+// TypedPool.
+func NewErrorPool(opts ...PoolOption[*PooledError]) *ErrorPool {
+	ep := new(ErrorPool)
+	opts = append([]PoolOption[*PooledError]{WithReset(resetPooledError)}, opts...)
+	ep.pool = NewTypedPool(func() *PooledError { return &PooledError{pool: ep} }, opts...)
+	return ep
+}
+
+// NewError returns a PooledError from the pool holding msg and cause.
+func (ep *ErrorPool) NewError(msg string, cause error) *PooledError {
+	e := ep.pool.Get()
+	e.Msg, e.Cause = msg, cause
+	return e
+}
+
+// Stats returns the underlying pool's counters.
+func (ep *ErrorPool) Stats() Stats {
+	return ep.pool.Stats()
+}
+
+func (e *PooledError) Error() string {
+	if e.Cause == nil {
+		return e.Msg
+	}
+	return e.Msg + ": " + e.Cause.Error()
+}
+
+// Unwrap returns Cause, for errors.Is and errors.As.
+func (e *PooledError) Unwrap() error {
+	return e.Cause
+}
+
+// Put returns e to its pool. It must not be used afterwards.
+func (e *PooledError) Put() {
+	e.pool.pool.Put(e)
+}
+
+// resetPooledError drops e's message and cause, so the pool does not keep
+// the cause alive.
+func resetPooledError(e *PooledError) {
+	e.Msg, e.Cause = "", nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestErrorPoolWrapsAndRecycles(t *testing.T) {
+	ep := NewErrorPool(WithFIFO[*PooledError](), WithStats[*PooledError]())
+
+	err := ep.NewError("reading config", io.ErrUnexpectedEOF)
+	if got := err.Error(); got != "reading config: unexpected EOF" {
+		t.Fatalf("Error() = %q", got)
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("errors.Is did not find the cause")
+	}
+	err.Put()
+	if err.Cause != nil || err.Msg != "" {
+		t.Fatalf("Put kept %q, %v; want them cleared", err.Msg, err.Cause)
+	}
+
+	again := ep.NewError("closed", nil)
+	if again != err {
+		t.Fatal("NewError did not reuse the returned error")
+	}
+	if got := again.Error(); got != "closed" {
+		t.Fatalf("Error() without a cause = %q, want closed", got)
+	}
+	if s := ep.Stats(); s.Hits != 1 || s.Misses != 1 {
+		t.Fatalf("Stats = %+v, want 1 hit and 1 miss", s)
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// escapeMode selects the escaping rules of appendEscaped.
+type escapeMode int
+
+const (
+	// escapeJSON produces the body of a JSON string: quotes, backslashes
+	// and control characters are escaped, and invalid UTF-8 becomes U+FFFD.
+	escapeJSON escapeMode = iota
+	// escapeText keeps a text-mode message on one line: control bytes are
+	// written as \n, \r, \t or \xNN, and so is each byte of invalid UTF-8.
+	escapeText
+)
+
+const hexDigits = "0123456789abcdef"
+
+// WithRawMessages writes text-mode messages verbatim instead of escaping
+// newlines and other control bytes. Only use it when every message is
+// trusted: a raw newline splits one event across lines and lets user input
+// forge log lines.
+func WithRawMessages() LoggerOption {
+	return func(l *Logger) {
+		l.raw = true
+	}
+}
+
+// appendMessage appends a text-mode message, escaped unless WithRawMessages
+// is set.
+func (l *Logger) appendMessage(b *bytes.Buffer, msg string) {
+	if l.raw {
+		b.WriteString(msg)
+		return
+	}
+	appendEscaped(b, msg, escapeText)
+}
+
+// appendEscaped appends s under the escaping rules of mode. Runs of bytes
+// that need no escaping, including valid multi-byte UTF-8, are copied
+// unchanged.
+func appendEscaped(b *bytes.Buffer, s string, mode escapeMode) {
+	start := 0
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			if !mode.needsEscape(c) {
+				i++
+				continue
+			}
+			b.WriteString(s[start:i])
+			mode.escapeASCII(b, c)
+			i++
+			start = i
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			b.WriteString(s[start:i])
+			if mode == escapeJSON {
+				b.WriteString(`\ufffd`)
+			} else {
+				appendHexByte(b, c)
+			}
+		case mode == escapeJSON && (r == '\u2028' || r == '\u2029'):
+			// Valid JSON, but they break JavaScript parsers.
+			b.WriteString(s[start:i])
+			b.WriteString(`\u202`)
+			b.WriteByte(hexDigits[r&0xf])
+		default:
+			i += size
+			continue
+		}
+		i += size
+		start = i
+	}
+	b.WriteString(s[start:])
+}
+
+func (m escapeMode) needsEscape(c byte) bool {
+	if m == escapeJSON {
+		return c < ' ' || c == '"' || c == '\\'
+	}
+	return c < ' ' || c == 0x7f
+}
+
+func (m escapeMode) escapeASCII(b *bytes.Buffer, c byte) {
+	switch c {
+	case '\n':
+		b.WriteString(`\n`)
+	case '\r':
+		b.WriteString(`\r`)
+	case '\t':
+		b.WriteString(`\t`)
+	case '"', '\\':
+		b.WriteByte('\\')
+		b.WriteByte(c)
+	default:
+		if m == escapeJSON {
+			b.WriteString(`\u00`)
+			b.WriteByte(hexDigits[c>>4])
+			b.WriteByte(hexDigits[c&0xf])
+			return
+		}
+		appendHexByte(b, c)
+	}
+}
+
+// appendHexByte writes c as \xNN.
+func appendHexByte(b *bytes.Buffer, c byte) {
+	b.WriteString(`\x`)
+	b.WriteByte(hexDigits[c>>4])
+	b.WriteByte(hexDigits[c&0xf])
+}
+
+// bytesString views p as a string without copying. p must not change while
+// the string is in use.
+func bytesString(p []byte) string {
+	return unsafe.String(unsafe.SliceData(p), len(p))
+}
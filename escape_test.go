@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTextMessageEscaping(t *testing.T) {
+	tests := []struct {
+		name, msg, want string
+	}{
+		{"newline", "one\ntwo", `one\ntwo`},
+		{"forged line", "ok\r\n12:00:00 : ERROR : fake", `ok\r\n12:00:00 : ERROR : fake`},
+		{"tab and NUL", "a\tb\x00c", `a\tb\x00c`},
+		{"DEL", "a\x7fb", `a\x7fb`},
+		{"multi-byte UTF-8", "café 日本 \U0001F600", "café 日本 \U0001F600"},
+		{"invalid UTF-8", "bad\xff\xfeend", `bad\xff\xfeend`},
+		{"truncated rune", "x\xe6\x97", `x\xe6\x97`},
+		{"quotes and backslashes", `say "hi" \o/`, `say "hi" \o/`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := NewLogger(&buf, WithTimeLayout(""))
+
+			logger.Info(tt.msg)
+			if got, want := buf.String(), "INFO : "+tt.want+"\n"; got != want {
+				t.Errorf("Info: got %q, want %q", got, want)
+			}
+
+			buf.Reset()
+			logger.Infof("%s", tt.msg)
+			if got, want := buf.String(), "INFO : "+tt.want+"\n"; got != want {
+				t.Errorf("Infof: got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestWithRawMessages(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(&buf, WithTimeLayout(""), WithRawMessages())
+
+	logger.Info("one\ntwo")
+	logger.Infof("three\n%s", "four")
+
+	if want := "INFO : one\ntwo\nINFO : three\nfour\n"; buf.String() != want {
+		t.Fatalf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestLegacyLogEscapesMessage(t *testing.T) {
+	var buf bytes.Buffer
+	log(&buf, "a\nb")
+
+	if got, want := stripTime(buf.String()), `TIME : a\nb`; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestAppendEscapedDoesNotAllocate(t *testing.T) {
+	var buf bytes.Buffer
+	buf.Grow(256)
+	msg := "line\nwith \x00 control\xff bytes and café"
+
+	n := testing.AllocsPerRun(100, func() {
+		buf.Reset()
+		appendEscaped(&buf, msg, escapeText)
+		appendEscaped(&buf, msg, escapeJSON)
+	})
+	if n != 0 {
+		t.Fatalf("appendEscaped allocated %v times", n)
+	}
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// maxEventFields is the largest field buffer an Event keeps when it goes
+// back to the pool.
+const maxEventFields = 16 << 10
+
+// Event builds one line from typed fields without boxing them:
+//
+//	logger.InfoEvent().Str("method", m).Int("status", 200).Dur("elapsed", d).Msg("request done")
+//
+// Each field is appended straight into the Event's buffer, and Msg writes
+// the line and returns the Event to its pool, after which it must not be
+// used. Events from a level the Logger filters out are nil, and every
+// method on a nil Event does nothing. An Event is not safe for concurrent
+// use.
+//
+// Builds with the debug tag do not recycle Events, so using one after Msg
+// panics, and AbandonedEvents counts Events dropped without a Msg.
+type Event struct {
+	l       *Logger
+	level   Level
+	fields  bytes.Buffer
+	done    bool
+	cleanup runtime.Cleanup
+}
+
+var eventPool = NewTypedPool(func() *Event { return new(Event) })
+
+// abandonedEvents counts Events collected without a Msg.
+var abandonedEvents atomic.Int64
+
+// AbandonedEvents returns how many Events were garbage collected without
+// their Msg being called. It only counts in builds with the debug tag;
+// otherwise it is always 0.
+func AbandonedEvents() int64 {
+	return abandonedEvents.Load()
+}
+
+// DebugEvent starts an Event at LevelDebug.
+func (l *Logger) DebugEvent() *Event { return l.event(LevelDebug) }
+
+// InfoEvent starts an Event at LevelInfo.
+func (l *Logger) InfoEvent() *Event { return l.event(LevelInfo) }
+
+// WarnEvent starts an Event at LevelWarn.
+func (l *Logger) WarnEvent() *Event { return l.event(LevelWarn) }
+
+// ErrorEvent starts an Event at LevelError.
+func (l *Logger) ErrorEvent() *Event { return l.event(LevelError) }
+
+func (l *Logger) event(level Level) *Event {
+	if !l.renders(level) {
+		return nil
+	}
+	var e *Event
+	if debugBuild {
+		e = new(Event)
+		e.cleanup = runtime.AddCleanup(e, abandonEvent, struct{}{})
+	} else {
+		e = eventPool.Get()
+		e.fields.Reset()
+	}
+	e.l, e.level = l, level
+	return e
+}
+
+// abandonEvent counts an Event collected before its Msg.
+func abandonEvent(struct{}) {
+	abandonedEvents.Add(1)
+}
+
+// Str adds a string field.
+func (e *Event) Str(key, val string) *Event {
+	if e.key(key) {
+		if e.l.json {
+			appendJSONString(&e.fields, val)
+		} else {
+			appendText(&e.fields, val)
+		}
+	}
+	return e
+}
+
+// Bytes adds a field holding val as a string.
+func (e *Event) Bytes(key string, val []byte) *Event {
+	return e.Str(key, bytesString(val))
+}
+
+// Int adds an int field.
+func (e *Event) Int(key string, val int) *Event {
+	return e.Int64(key, int64(val))
+}
+
+// Int64 adds an int64 field.
+func (e *Event) Int64(key string, val int64) *Event {
+	if e.key(key) {
+		e.fields.Write(strconv.AppendInt(e.fields.AvailableBuffer(), val, 10))
+	}
+	return e
+}
+
+// Uint64 adds a uint64 field.
+func (e *Event) Uint64(key string, val uint64) *Event {
+	if e.key(key) {
+		e.fields.Write(strconv.AppendUint(e.fields.AvailableBuffer(), val, 10))
+	}
+	return e
+}
+
+// Float64 adds a float64 field. In JSON mode NaN and infinities are
+// strings.
+func (e *Event) Float64(key string, val float64) *Event {
+	if e.key(key) {
+		if e.l.json {
+			appendJSONFloat(&e.fields, val, 64)
+		} else {
+			e.fields.Write(strconv.AppendFloat(e.fields.AvailableBuffer(), val, 'g', -1, 64))
+		}
+	}
+	return e
+}
+
+// Bool adds a bool field.
+func (e *Event) Bool(key string, val bool) *Event {
+	if e.key(key) {
+		e.fields.Write(strconv.AppendBool(e.fields.AvailableBuffer(), val))
+	}
+	return e
+}
+
+// Dur adds a time.Duration field: text like "1.5s", or nanoseconds in JSON
+// mode, as the key-value arguments render it.
+func (e *Event) Dur(key string, val time.Duration) *Event {
+	if e.key(key) {
+		if e.l.json {
+			e.fields.Write(strconv.AppendInt(e.fields.AvailableBuffer(), int64(val), 10))
+		} else {
+			e.fields.WriteString(val.String())
+		}
+	}
+	return e
+}
+
+// Time adds a time.Time field in time.RFC3339Nano.
+func (e *Event) Time(key string, val time.Time) *Event {
+	if e.key(key) {
+		if e.l.json {
+			e.fields.WriteByte('"')
+		}
+		e.fields.Write(val.AppendFormat(e.fields.AvailableBuffer(), time.RFC3339Nano))
+		if e.l.json {
+			e.fields.WriteByte('"')
+		}
+	}
+	return e
+}
+
+// Err adds the message of err, or null (<nil> in text) for a nil err.
+func (e *Event) Err(key string, err error) *Event {
+	if err != nil {
+		return e.Str(key, err.Error())
+	}
+	if e.key(key) {
+		if e.l.json {
+			e.fields.WriteString("null")
+		} else {
+			e.fields.WriteString("<nil>")
+		}
+	}
+	return e
+}
+
+// Msg writes the line with msg and the fields, and returns the Event to
+// its pool.
+func (e *Event) Msg(msg string) error {
+	if e == nil {
+		return nil
+	}
+	e.check()
+	l := e.l
+	pc := l.callerPC()
+
+	var err error
+	if (l.sampler == nil && l.dedup == nil) || l.admit(e.level, msg, nil) {
+		b := l.lineBuffer()
+		if l.json {
+			l.appendJSON(b, e.level, msg, nil, false, e.fields.Bytes(), pc)
+		} else {
+			l.appendHeader(b)
+			b.WriteString(e.level.String())
+			b.WriteString(l.separator)
+			l.appendCaller(b, pc)
+			l.appendMessage(b, msg)
+			b.Write(l.static)
+			b.Write(e.fields.Bytes())
+			l.appendSeq(b)
+			b.WriteByte('\n')
+		}
+		err = l.emit(e.level, b)
+	}
+
+	e.l = nil
+	if debugBuild {
+		e.done = true
+		e.cleanup.Stop()
+		return err
+	}
+	if e.fields.Cap() > maxEventFields {
+		e.fields = bytes.Buffer{}
+	}
+	eventPool.Put(e)
+	return err
+}
+
+// key starts a field and reports whether the Event is live.
+func (e *Event) key(key string) bool {
+	if e == nil {
+		return false
+	}
+	e.check()
+	if e.l.json {
+		e.fields.WriteByte(',')
+		appendJSONString(&e.fields, key)
+		e.fields.WriteByte(':')
+	} else {
+		e.fields.WriteByte(' ')
+		appendText(&e.fields, key)
+		e.fields.WriteByte('=')
+	}
+	return true
+}
+
+// check panics in debug builds if the Event was already written.
+func (e *Event) check() {
+	if debugBuild && e.done {
+		panic("Event: used after Msg")
+	}
+}
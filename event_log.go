@@ -0,0 +1,71 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// WithEventLog keeps the last capacity Get, Put, New and Discard events in
+// a ring that EventLog returns, a flight recorder for post-incident
+// debugging. Unlike WithAuditLog nothing is encoded or written, and only
+// the events still in the ring cost memory.
+func WithEventLog[T any](capacity int) PoolOption[T] {
+	if capacity < 1 {
+		panic("WithEventLog: capacity must be at least 1")
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.eventLog = capacity
+	}
+}
+
+// PoolEvent is one entry of a WithEventLog ring.
+type PoolEvent struct {
+	Time time.Time
+	Kind string // "get", "put", "new" or "discard", as in AuditRecord
+	Item uint64 // pointer address, 0 for non-pointer items
+	ID   uint64 // under WithMonotonicID
+}
+
+// eventRing is a fixed-size ring of the most recent events.
+type eventRing struct {
+	mu     sync.Mutex
+	events []PoolEvent
+	next   int
+	full   bool
+}
+
+func newEventRing(capacity int) *eventRing {
+	return &eventRing{events: make([]PoolEvent, capacity)}
+}
+
+func (r *eventRing) record(ev PoolEvent) {
+	r.mu.Lock()
+	r.events[r.next] = ev
+	r.next++
+	if r.next == len(r.events) {
+		r.next, r.full = 0, true
+	}
+	r.mu.Unlock()
+}
+
+// snapshot returns the events in the ring, oldest first.
+func (r *eventRing) snapshot() []PoolEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.full {
+		return append([]PoolEvent(nil), r.events[:r.next]...)
+	}
+	out := make([]PoolEvent, 0, len(r.events))
+	out = append(out, r.events[r.next:]...)
+	return append(out, r.events[:r.next]...)
+}
+
+// EventLog returns the events in the WithEventLog ring, oldest first, or
+// nil if the pool has none.
+func (tp *TypedPool[T]) EventLog() []PoolEvent {
+	if tp.events == nil {
+		return nil
+	}
+	return tp.events.snapshot()
+}
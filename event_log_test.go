@@ -0,0 +1,38 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEventLog(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) }, WithEventLog[*int](3), WithFIFO[*int]())
+
+	v := pool.Get()
+	if got := kinds(pool.EventLog()); !slices.Equal(got, []string{"new", "get"}) {
+		t.Fatalf("events = %v, want [new get]", got)
+	}
+	pool.Put(v)
+	pool.Get()
+
+	events := pool.EventLog()
+	if got := kinds(events); !slices.Equal(got, []string{"get", "put", "get"}) {
+		t.Fatalf("events after wrapping = %v, want the last 3", got)
+	}
+	for _, ev := range events {
+		if ev.Item != itemIdentity(v) || ev.Time.IsZero() {
+			t.Errorf("event %+v, want item %#x and a timestamp", ev, itemIdentity(v))
+		}
+	}
+	if NewTypedPool(func() *int { return new(int) }).EventLog() != nil {
+		t.Fatal("EventLog without WithEventLog is not nil")
+	}
+}
+
+func kinds(events []PoolEvent) []string {
+	var out []string
+	for _, ev := range events {
+		out = append(out, ev.Kind)
+	}
+	return out
+}
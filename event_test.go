@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEventFieldsText(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(&buf, WithTimeLayout(""))
+	at := time.Date(2024, 3, 9, 23, 59, 58, 5e6, time.UTC)
+
+	logger.InfoEvent().
+		Str("method", "GET").
+		Str("path", "/a b").
+		Bytes("body", []byte("raw")).
+		Int("status", 200).
+		Int64("size", -1).
+		Uint64("id", math.MaxUint64).
+		Float64("ratio", 0.25).
+		Bool("ok", true).
+		Dur("elapsed", 1500*time.Millisecond).
+		Time("at", at).
+		Err("err", errors.New("boom")).
+		Err("none", nil).
+		Msg("request done")
+
+	want := `INFO : request done method=GET path="/a b" body=raw status=200 size=-1 ` +
+		`id=18446744073709551615 ratio=0.25 ok=true elapsed=1.5s ` +
+		`at=2024-03-09T23:59:58.005Z err=boom none=<nil>` + "\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestEventFieldsJSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(&buf, WithJSON(), WithTimeLayout(""))
+	at := time.Date(2024, 3, 9, 23, 59, 58, 0, time.UTC)
+
+	logger.WarnEvent().
+		Str("method", "GET").
+		Bytes("body", []byte("a\"b")).
+		Int("status", 503).
+		Float64("ratio", math.Inf(1)).
+		Bool("ok", false).
+		Dur("elapsed", time.Second).
+		Time("at", at).
+		Err("err", errors.New("boom")).
+		Err("none", nil).
+		Msg("slow")
+
+	want := `{"level":"warn","msg":"slow","method":"GET","body":"a\"b","status":503,` +
+		`"ratio":"+Inf","ok":false,"elapsed":1000000000,"at":"2024-03-09T23:59:58Z",` +
+		`"err":"boom","none":null}` + "\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+	if !json.Valid(buf.Bytes()) {
+		t.Fatal("line is not valid JSON")
+	}
+}
+
+func TestEventBelowLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(&buf)
+
+	if e := logger.DebugEvent(); e != nil {
+		t.Fatal("DebugEvent is not nil below the level")
+	}
+	if err := logger.DebugEvent().Str("k", "v").Int("n", 1).Msg("dropped"); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("wrote %q", buf.String())
+	}
+}
+
+func TestEventStaticFieldsAndReuse(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(&buf, WithTimeLayout(""), WithStaticFields("svc", "api"))
+
+	logger.ErrorEvent().Int("n", 1).Msg("first")
+	logger.InfoEvent().Msg("second")
+
+	want := "ERROR : first svc=api n=1\nINFO : second svc=api\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// WithEventualConsistency batches Puts: items collect in a buffer, and a
+// background goroutine pools them together once batchSize have gathered or
+// delay has passed since the last flush, by the pool's Clock. A FIFO store
+// takes each batch under a single lock acquisition. The price is that an
+// item is not available to Get until its batch is flushed. After Close, and
+// for items that arrive while Close flushes the last batch, Put pools
+// inline. It panics unless delay is positive and batchSize at least 1.
+func WithEventualConsistency[T any](delay time.Duration, batchSize int) PoolOption[T] {
+	if delay <= 0 || batchSize < 1 {
+		panic("WithEventualConsistency: delay must be positive and batchSize at least 1")
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.batchDelay, cfg.batchSize = delay, batchSize
+	}
+}
+
+// putBatch is the WithEventualConsistency buffer.
+type putBatch[T any] struct {
+	mu     sync.Mutex
+	items  []T
+	spare  []T // the flushed buffer, reused by the next batch
+	closed bool
+	full   chan struct{}
+}
+
+// batchStore is implemented by stores that can take many items at once.
+type batchStore[T any] interface {
+	putAll(items []T)
+}
+
+// batchPut adds v to the current batch and reports whether it did; it does
+// not once the pool has been closed.
+func (tp *TypedPool[T]) batchPut(v T) bool {
+	b := tp.batch
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return false
+	}
+	b.items = append(b.items, v)
+	full := len(b.items) >= tp.cfg.batchSize
+	b.mu.Unlock()
+
+	if full {
+		select {
+		case b.full <- struct{}{}:
+		default:
+		}
+	}
+	return true
+}
+
+// startBatchFlush runs the WithEventualConsistency flusher until Close,
+// which flushes the last batch.
+func (tp *TypedPool[T]) startBatchFlush() {
+	tp.batch = &putBatch[T]{full: make(chan struct{}, 1)}
+	tp.bg.run(tp.cfg.schedule, func(stop <-chan struct{}) {
+		for {
+			select {
+			case <-stop:
+				tp.flushBatch(true)
+				return
+			case <-tp.batch.full:
+				tp.flushBatch(false)
+			case <-after(tp.cfg.clock, tp.cfg.batchDelay):
+				tp.flushBatch(false)
+			}
+		}
+	})
+}
+
+// flushBatch pools the current batch; last marks the batch closed.
+func (tp *TypedPool[T]) flushBatch(last bool) {
+	b := tp.batch
+	b.mu.Lock()
+	items := b.items
+	b.items, b.spare = b.spare[:0], nil
+	b.closed = last
+	b.mu.Unlock()
+
+	kept := items[:0]
+	for _, v := range items {
+		if v, ok := tp.admitPut(v); ok {
+			kept = append(kept, v)
+		}
+	}
+	if bs, ok := tp.pool.(batchStore[T]); ok {
+		bs.putAll(kept)
+	} else {
+		for _, v := range kept {
+			tp.pool.put(v)
+		}
+	}
+	clear(items)
+	b.spare = items[:0]
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ArditZubaku/go-sync-pool/pooltest"
+)
+
+func TestEventualConsistency(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Unix(0, 0))
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithEventualConsistency[*int](time.Second, 3),
+		WithPoolClock[*int](clock),
+		WithFIFO[*int](),
+		WithStats[*int](),
+	)
+
+	a, b := new(int), new(int)
+	pool.Put(a)
+	pool.Put(b)
+	if got := pool.Len(); got != 0 {
+		t.Fatalf("Len() = %d before the batch was flushed, want 0", got)
+	}
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	clock.Advance(time.Second)
+	waitFor(t, func() bool { return pool.Len() == 2 })
+	if got := pool.Get(); got != a {
+		t.Fatal("the flushed batch lost its order")
+	}
+
+	pool.Put(new(int))
+	pool.Put(new(int))
+	pool.Put(new(int)) // a full batch flushes without waiting for the clock
+	waitFor(t, func() bool { return pool.Len() == 4 })
+
+	pool.Put(new(int))
+	pool.Close()
+	if got := pool.Len(); got != 5 {
+		t.Fatalf("Len() = %d after Close, want the last batch flushed", got)
+	}
+	pool.Put(new(int))
+	if got := pool.Len(); got != 6 {
+		t.Fatalf("Len() = %d after a Put past Close, want it pooled inline", got)
+	}
+	if s := pool.Stats(); s.Puts != 7 {
+		t.Fatalf("Puts = %d, want 7", s.Puts)
+	}
+}
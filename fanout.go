@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"sync/atomic"
+)
+
+// WithAdditionalWriter sends every line to w as well as to the Logger's
+// main writer. The line is rendered once and copied into a pooled buffer per
+// writer. Writers are written one after another, so a slow one delays the
+// rest unless the Logger is also WithAsync, which gives each writer its own
+// queue. WriterStats reports errors and drops per writer.
+func WithAdditionalWriter(w io.Writer) LoggerOption {
+	return WithLevelWriter(LevelDebug, w)
+}
+
+// WithLevelWriter sends the lines at min or above to w as well, such as
+// warnings and errors to os.Stderr next to the main stream. It can be given
+// several times; each line is still rendered once, in the Logger's text or
+// JSON format, and goes to every writer whose threshold it meets. Routes
+// behave like WithAdditionalWriter writers, including their own queue under
+// WithAsync.
+func WithLevelWriter(min Level, w io.Writer) LoggerOption {
+	return func(l *Logger) {
+		l.routes = append(l.routes, route{min: min, w: w})
+	}
+}
+
+// route is a writer and the lowest level it receives.
+type route struct {
+	min Level
+	w   io.Writer
+}
+
+// WriterStats counts the failures of one of a Logger's writers.
+type WriterStats struct {
+	Errors  uint64 // failed writes
+	Dropped uint64 // lines dropped by the writer's async queue
+}
+
+// WriterStats returns the counters of each writer, the main writer first and
+// then the WithAdditionalWriter and WithLevelWriter writers in the order they
+// were given.
+func (l *Logger) WriterStats() []WriterStats {
+	stats := make([]WriterStats, len(l.sinks))
+	for i, s := range l.sinks {
+		stats[i] = s.stats()
+	}
+	return stats
+}
+
+// sink is one destination of a Logger's lines.
+type sink struct {
+	w      io.Writer
+	min    Level
+	async  *asyncWriter
+	errors atomic.Uint64
+	logger *Logger
+}
+
+func (l *Logger) newSink(r route) *sink {
+	s := &sink{w: r.w, min: r.min, logger: l}
+	if l.async != nil {
+		s.async = newAsyncWriter(l.async, r.w, func(err error) { s.fail(err) })
+	}
+	return s
+}
+
+// fail counts a non-nil err against the writer and passes it to the error
+// handler.
+func (s *sink) fail(err error) error {
+	if err != nil {
+		s.errors.Add(1)
+	}
+	return s.logger.handleError(err)
+}
+
+// write writes the pooled line b, or queues it, and gives up ownership of b.
+// In async mode errors surface later, through the error handler.
+func (s *sink) write(b *bytes.Buffer) error {
+	if s.async != nil {
+		s.async.enqueue(b)
+		return nil
+	}
+
+	err := writeFull(s.w, b.Bytes())
+	releaseWritten(b)
+	return s.fail(err)
+}
+
+func (s *sink) stats() WriterStats {
+	st := WriterStats{Errors: s.errors.Load()}
+	if s.async != nil {
+		st.Dropped = s.async.dropped.Load()
+	}
+	return st
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestAdditionalWriterIsolatesSlowSink(t *testing.T) {
+	var fast lockedBuffer
+	slow := &gatedWriter{entered: make(chan struct{}), release: make(chan struct{})}
+	logger := NewLogger(&fast, WithTimeLayout(""),
+		WithAdditionalWriter(slow),
+		WithAsync(4, time.Millisecond, OverflowDrop),
+	)
+
+	logger.Info("first")
+	<-slow.entered // the slow sink's flusher is now stuck
+
+	// The fast writer gets every line while the slow one is still blocked.
+	deadline := time.Now().Add(time.Second)
+	for i := 2; i <= 11; i++ {
+		logger.Info("more")
+		for strings.Count(fast.String(), "\n") < i {
+			if time.Now().After(deadline) {
+				t.Fatalf("fast writer got %q while the slow one was blocked", fast.String())
+			}
+			time.Sleep(100 * time.Microsecond)
+		}
+	}
+
+	close(slow.release)
+	logger.Close()
+
+	if got := strings.Count(slow.String(), "\n"); got != 5 {
+		t.Fatalf("slow writer got %d lines, want 5", got)
+	}
+	stats := logger.WriterStats()
+	if stats[0] != (WriterStats{}) || stats[1] != (WriterStats{Dropped: 6}) {
+		t.Fatalf("WriterStats() = %+v", stats)
+	}
+	if d := logger.Dropped(); d != 6 {
+		t.Fatalf("Dropped() = %d, want 6", d)
+	}
+}
+
+func TestAdditionalWriterCountsErrors(t *testing.T) {
+	var good bytes.Buffer
+	bad := &failingWriter{err: syscall.EPIPE}
+	logger := NewLogger(bad, WithTimeLayout(""), WithAdditionalWriter(&good))
+	before := buffersInFlight()
+
+	for range 3 {
+		if err := logger.Info("line"); err != syscall.EPIPE {
+			t.Fatalf("Info() = %v, want EPIPE", err)
+		}
+	}
+
+	if got := good.String(); got != strings.Repeat("INFO : line\n", 3) {
+		t.Fatalf("good writer got %q", got)
+	}
+	stats := logger.WriterStats()
+	if stats[0].Errors != 3 || stats[1].Errors != 0 {
+		t.Fatalf("WriterStats() = %+v", stats)
+	}
+	if n := buffersInFlight() - before; n != 0 {
+		t.Fatalf("%d buffers leaked", n)
+	}
+}
+
+func TestWithLevelWriter(t *testing.T) {
+	var all, errs bytes.Buffer
+	logger := NewLogger(&all, WithTimeLayout(""), WithLevelWriter(LevelError, &errs))
+	before := buffersInFlight()
+
+	logger.Info("started")
+	logger.Warn("slow", "ms", 900)
+	logger.Errorf("failed: %s", "EOF")
+
+	if want := "INFO : started\nWARN : slow ms=900\nERROR : failed: EOF\n"; all.String() != want {
+		t.Fatalf("main writer got %q, want %q", all.String(), want)
+	}
+	if want := "ERROR : failed: EOF\n"; errs.String() != want {
+		t.Fatalf("error writer got %q, want %q", errs.String(), want)
+	}
+	if n := buffersInFlight() - before; n != 0 {
+		t.Fatalf("%d buffers leaked", n)
+	}
+}
+
+func TestWithLevelWriterJSON(t *testing.T) {
+	var all, errs bytes.Buffer
+	logger := NewLogger(&all, WithJSON(), WithTimeLayout(""), WithLevelWriter(LevelWarn, &errs))
+
+	logger.Info("a")
+	logger.Warn("b")
+
+	if want := `{"level":"warn","msg":"b"}` + "\n"; errs.String() != want {
+		t.Fatalf("warn writer got %q, want %q", errs.String(), want)
+	}
+	if !strings.HasSuffix(all.String(), errs.String()) || strings.Count(all.String(), "\n") != 2 {
+		t.Fatalf("main writer got %q", all.String())
+	}
+}
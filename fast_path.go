@@ -0,0 +1,81 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// WithFastPath puts fp, a raw sync.Pool the application may already use and
+// have tuned, in front of the pool, for migrating from sync.Pool to the
+// typed API a call site at a time. Get tries fp first, as fp.Get().(T), and
+// then the pool's own store; Put resets the item and hands it to fp until
+// fp holds about WithMaxItems items, estimated by counting Puts against
+// Gets, and only then takes the pool's usual path, limits included. Without
+// WithMaxItems every Put goes to fp. Items in fp are checked on their way in
+// and out like any other, but are outside the pool's own accounting, such
+// as Len, so NewTypedPool panics if it is combined with WithObjectLimit or
+// WithObjectCount; they do count as Stats hits and Puts. Values of another
+// type found in fp are left to the GC, so code still using fp directly must
+// store T as is, and leave New unset.
+func WithFastPath[T any](fp *sync.Pool) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.fastPath = fp
+	}
+}
+
+// fastPath is the WithFastPath tier.
+type fastPath struct {
+	pool *sync.Pool
+	n    atomic.Int64 // estimated items in pool
+}
+
+// takeFast takes an item from the fast path, if any.
+func (tp *TypedPool[T]) takeFast() (T, bool) {
+	if f := tp.fast; f != nil {
+		for {
+			x := f.pool.Get()
+			if x == nil {
+				// Empty, whatever the estimate says: the GC or other code
+				// took the rest.
+				f.n.Store(0)
+				break
+			}
+			v, ok := x.(T)
+			if !ok {
+				continue
+			}
+			if f.n.Add(-1) < 0 { // an item other code put there
+				f.n.Store(0)
+			}
+			if tp.checkExpiry(v) && tp.checkReuse(v) && tp.checkVersion(v) && tp.checkSum(v) {
+				return v, true
+			}
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// putFast hands v to the fast path and reports whether there was room.
+func (tp *TypedPool[T]) putFast(v T) bool {
+	f := tp.fast
+	if f == nil {
+		return false
+	}
+	if limit := tp.maxItems.Load(); limit > 0 && f.n.Load() >= limit {
+		return false
+	}
+	if !tp.acceptable(v) {
+		return false // for put to refuse
+	}
+	if tp.chaos() {
+		tp.drop(v)
+		return true
+	}
+	if v, ok := tp.prepare(v); ok {
+		tp.pooled(v)
+		f.n.Add(1)
+		f.pool.Put(v)
+	}
+	return true
+}
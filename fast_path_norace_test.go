@@ -0,0 +1,87 @@
+//go:build !race
+
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+// The race detector makes sync.Pool drop items at random, so these only run
+// without it.
+
+func TestFastPathSharesRawPool(t *testing.T) {
+	var raw sync.Pool
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithFastPath[*int](&raw), WithFIFO[*int](), WithStats[*int]())
+
+	legacy := new(int)
+	raw.Put(legacy) // code not yet migrated
+	if got := pool.Get(); got != legacy {
+		t.Fatal("Get did not take the item put in the raw pool")
+	}
+	pool.Put(legacy)
+	if got, _ := raw.Get().(*int); got != legacy {
+		t.Fatal("Put did not go to the raw pool")
+	}
+
+	raw.Put("not an *int")
+	if got := pool.Get(); got == nil {
+		t.Fatal("Get returned nil past a foreign value")
+	}
+}
+
+func TestFastPathFallsThroughPastMaxItems(t *testing.T) {
+	var raw sync.Pool
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithFastPath[*int](&raw), WithMaxItems[*int](2), WithFIFO[*int]())
+
+	a, b, c := pool.Get(), pool.Get(), pool.Get()
+	pool.Put(a)
+	pool.Put(b)
+	pool.Put(c) // the raw pool holds 2
+	if got := pool.Len(); got != 1 {
+		t.Fatalf("Len() = %d, want the third item in the pool's own store", got)
+	}
+	raw.Get()
+	raw.Get()
+	if got := pool.Get(); got != c {
+		t.Fatal("Get did not fall through to the pool's own store")
+	}
+}
+
+func TestFastPathRunsPoolChecks(t *testing.T) {
+	var (
+		raw    sync.Pool
+		hooked int
+	)
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithFastPath[*int](&raw), WithFIFO[*int](),
+		WithItemReuseLimit[*int](1),
+		WithPutHook(func(v *int) *int { hooked++; return v }),
+	)
+
+	a := pool.Get()
+	pool.Put(a)
+	if got := pool.Get(); got != a {
+		t.Fatal("Get did not take the item from the raw pool")
+	}
+	pool.Put(a) // reused once, so retired
+	if got := pool.Get(); got == a {
+		t.Fatal("the fast path handed out an item past its reuse limit")
+	}
+	if hooked != 1 {
+		t.Fatalf("put hook ran %d times, want 1: the retired item is not pooled", hooked)
+	}
+}
+
+func TestFastPathRejectsObjectLimit(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("NewTypedPool accepted WithFastPath with WithObjectLimit")
+		}
+	}()
+	NewTypedPool(func() *int { return new(int) },
+		WithFastPath[*int](new(sync.Pool)),
+		WithObjectLimit(8, func(*int) int64 { return 8 }))
+}
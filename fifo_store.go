@@ -0,0 +1,101 @@
+package main
+
+import "sync"
+
+// idleStore is the backing store of a TypedPool.
+type idleStore[T any] interface {
+	// get removes an idle item, reporting false if there is none.
+	get() (T, bool)
+	put(v T)
+}
+
+// fifoStore is an unbounded, mutex-guarded ring buffer that hands out the
+// longest-idle item first. Unlike sync.Pool its contents survive GC cycles.
+type fifoStore[T any] struct {
+	mu    sync.Mutex
+	items []T
+	head  int
+	n     int
+}
+
+func (s *fifoStore[T]) get() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var zero T
+	if s.n == 0 {
+		return zero, false
+	}
+	v := s.items[s.head]
+	s.items[s.head] = zero
+	s.head = (s.head + 1) % len(s.items)
+	s.n--
+	return v, true
+}
+
+func (s *fifoStore[T]) put(v T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.n == len(s.items) {
+		s.grow()
+	}
+	s.items[(s.head+s.n)%len(s.items)] = v
+	s.n++
+}
+
+// putAll puts items in order under a single lock acquisition.
+func (s *fifoStore[T]) putAll(items []T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, v := range items {
+		if s.n == len(s.items) {
+			s.grow()
+		}
+		s.items[(s.head+s.n)%len(s.items)] = v
+		s.n++
+	}
+}
+
+// filterStore is implemented by stores that can remove items in place.
+type filterStore[T any] interface {
+	// removeWhere removes the items remove reports true for, in one pass
+	// under the store's lock, and returns them.
+	removeWhere(remove func(T) bool) []T
+}
+
+func (s *fifoStore[T]) removeWhere(remove func(T) bool) []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		removed []T
+		zero    T
+		kept    int
+	)
+	for i := range s.n {
+		v := s.items[(s.head+i)%len(s.items)]
+		if remove(v) {
+			removed = append(removed, v)
+			continue
+		}
+		s.items[(s.head+kept)%len(s.items)] = v
+		kept++
+	}
+	for i := kept; i < s.n; i++ {
+		s.items[(s.head+i)%len(s.items)] = zero
+	}
+	s.n = kept
+	return removed
+}
+
+// grow doubles the ring's capacity, unwrapping it so head is 0.
+func (s *fifoStore[T]) grow() {
+	items := make([]T, max(2*len(s.items), 8))
+	for i := range s.n {
+		items[i] = s.items[(s.head+i)%len(s.items)]
+	}
+	s.items = items
+	s.head = 0
+}
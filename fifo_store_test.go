@@ -0,0 +1,41 @@
+package main
+
+import (
+	"runtime"
+	"slices"
+	"testing"
+)
+
+func TestWithFIFO(t *testing.T) {
+	next := 0
+	pool := NewTypedPool(func() int { next++; return next }, WithFIFO[int]())
+
+	for i := 1; i <= 20; i++ {
+		pool.Put(i)
+	}
+	runtime.GC()
+	runtime.GC()
+
+	got := make([]int, 0, 20)
+	for range 10 {
+		got = append(got, pool.Get())
+	}
+	pool.Put(21)
+	for range 11 {
+		got = append(got, pool.Get())
+	}
+
+	want := make([]int, 0, 21)
+	for i := 1; i <= 21; i++ {
+		want = append(want, i)
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if next != 0 {
+		t.Fatalf("constructor ran %d times, want 0", next)
+	}
+	if got := pool.Get(); got != 1 {
+		t.Fatalf("empty pool Get() = %d, want a new item", got)
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import "sync"
+
+// FixedPool circulates a fixed number of objects, all constructed up front.
+// Get blocks while every object is checked out, so the pool also bounds how
+// many callers hold an object at once.
+type FixedPool[T any] struct {
+	mu    sync.Mutex
+	ready sync.Cond
+	idle  idleRing[T]
+	newFn func() T
+	cfg   poolConfig[T]
+	stats *poolStats
+	free  *lockFreeSlots[T]
+
+	// size counts the objects in circulation, idle or checked out. It can
+	// exceed max after a shrinking Resize until enough objects come back.
+	size int
+	max  int
+}
+
+// NewFixedPool creates a FixedPool holding size objects built with newFn.
+func NewFixedPool[T any](size int, newFn func() T, opts ...PoolOption[T]) *FixedPool[T] {
+	if size < 1 {
+		panic("FixedPool: size must be at least 1")
+	}
+
+	fp := &FixedPool[T]{
+		idle:  newIdleRing[T](size),
+		newFn: newFn,
+	}
+	fp.ready.L = &fp.mu
+	for _, opt := range opts {
+		opt(&fp.cfg)
+	}
+	fp.stats = newPoolStats(fp.cfg.stats)
+	fp.grow(size)
+	if fp.cfg.lockFree {
+		fp.free = newLockFreeSlots[T](size)
+		for fp.idle.len() > 0 {
+			fp.free.offer(fp.popLocked())
+		}
+	}
+
+	return fp
+}
+
+// Get returns an idle object according to the pool's Ordering, waiting for
+// a Put if every object is checked out.
+func (fp *FixedPool[T]) Get() T {
+	if v, ok := fp.free.take(); ok {
+		fp.stats.hit()
+		return v
+	}
+
+	fp.mu.Lock()
+	fp.free.wait(1)
+	v, ok := fp.takeLocked()
+	for !ok {
+		fp.ready.Wait()
+		v, ok = fp.takeLocked()
+	}
+	fp.free.wait(-1)
+	fp.mu.Unlock()
+
+	fp.stats.hit()
+	return v
+}
+
+// popLocked takes an idle object according to the pool's Ordering. fp.mu
+// must be held and an object must be idle.
+func (fp *FixedPool[T]) popLocked() T {
+	var it idleItem[T]
+	if fp.cfg.ordering == FIFO {
+		it, _ = fp.idle.popFront()
+	} else {
+		it, _ = fp.idle.popBack()
+	}
+	return it.v
+}
+
+// Put returns an object to the pool and wakes one waiting Get. Objects in
+// excess of the capacity, after a shrinking Resize or from outside the pool,
+// are discarded instead.
+func (fp *FixedPool[T]) Put(v T) {
+	if fp.putFast(v) {
+		fp.stats.put()
+		return
+	}
+
+	fp.mu.Lock()
+	ok := fp.size <= fp.max && fp.idle.pushBack(idleItem[T]{v: v})
+	if ok {
+		fp.ready.Signal()
+	} else if fp.size > fp.max {
+		fp.size--
+		fp.markOver()
+	}
+	fp.mu.Unlock()
+
+	if ok {
+		fp.stats.put()
+		return
+	}
+	fp.stats.discard()
+	fp.discard(v)
+}
+
+// Len returns the number of idle objects.
+func (fp *FixedPool[T]) Len() int {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	return fp.idle.len() + fp.free.len()
+}
+
+// Cap returns the number of objects the pool circulates.
+func (fp *FixedPool[T]) Cap() int {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	return fp.max
+}
+
+// Stats returns the pool's counters. It is the zero Stats unless the pool
+// was built WithStats.
+func (fp *FixedPool[T]) Stats() Stats {
+	return fp.stats.snapshot()
+}
+
+// grow raises the capacity to newMax and constructs the missing objects,
+// waking any Gets waiting for them. fp.mu must be held once the pool is
+// shared, so newFn runs under the lock.
+func (fp *FixedPool[T]) grow(newMax int) {
+	fp.max = newMax
+	fp.idle.resize(newMax)
+	for ; fp.size < newMax; fp.size++ {
+		fp.idle.pushBack(idleItem[T]{v: fp.newFn()})
+	}
+	fp.ready.Broadcast()
+}
+
+// discard hands v to the OnDiscard hook, if any.
+func (fp *FixedPool[T]) discard(v T) {
+	if fp.cfg.onDiscard != nil {
+		fp.cfg.onDiscard(v)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestFixedPoolBlocksUntilPut(t *testing.T) {
+	built := 0
+	pool := NewFixedPool(1, func() int { built++; return built })
+
+	v := pool.Get()
+	got := make(chan int)
+	go func() { got <- pool.Get() }()
+
+	select {
+	case <-got:
+		t.Fatal("Get returned while every object was checked out")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	pool.Put(v)
+	if w := <-got; w != 1 || built != 1 {
+		t.Fatalf("Get() = %d after %d constructions, want 1 after 1", w, built)
+	}
+}
+
+func TestFixedPoolResize(t *testing.T) {
+	var discarded []int
+	built := 0
+	pool := NewFixedPool(4, func() int { built++; return built },
+		WithOrdering[int](FIFO),
+		WithOnDiscard(func(v int) { discarded = append(discarded, v) }),
+	)
+
+	a, b, c := pool.Get(), pool.Get(), pool.Get()
+	if err := pool.Resize(2); err != nil {
+		t.Fatal(err)
+	}
+	// The one idle object goes now; one checked-out object goes on Put.
+	if !slices.Equal(discarded, []int{4}) || pool.Len() != 0 {
+		t.Fatalf("after shrink: discarded %v, idle %d", discarded, pool.Len())
+	}
+	pool.Put(a)
+	pool.Put(b)
+	pool.Put(c)
+	if !slices.Equal(discarded, []int{4, 1}) || pool.Len() != 2 {
+		t.Fatalf("after Puts: discarded %v, idle %d", discarded, pool.Len())
+	}
+
+	if err := pool.Resize(3); err != nil {
+		t.Fatal(err)
+	}
+	if pool.Len() != 3 || pool.Cap() != 3 || built != 5 {
+		t.Fatalf("after grow: idle %d, cap %d, built %d", pool.Len(), pool.Cap(), built)
+	}
+
+	if err := pool.Resize(0); err != ErrInvalidCapacity {
+		t.Fatalf("Resize(0) = %v, want ErrInvalidCapacity", err)
+	}
+}
+
+func TestFixedPoolGrowWakesGet(t *testing.T) {
+	pool := NewFixedPool(1, func() int { return 7 })
+	pool.Get()
+
+	got := make(chan int)
+	go func() { got <- pool.Get() }()
+	time.Sleep(5 * time.Millisecond)
+	pool.Resize(2)
+
+	select {
+	case v := <-got:
+		if v != 7 {
+			t.Fatalf("Get() = %d, want 7", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("growing did not wake the waiting Get")
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"syscall"
+)
+
+// Flush makes everything logged before the call durable: it writes any
+// pending WithDedup summary, drains every writer's WithAsync queue, then
+// calls Flush() error and Sync() error on each writer that has them, such
+// as a FlushingWriter or an *os.File. It returns the errors it met, or
+// ctx.Err() once ctx is done; the work already started carries on in the
+// background. A ctx that is already done returns at once.
+func (l *Logger) Flush(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	l.flushDedup()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- l.flushSinks(ctx)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// flushSinks flushes each writer in turn.
+func (l *Logger) flushSinks(ctx context.Context) error {
+	var errs []error
+	for _, s := range l.sinks {
+		if s.async != nil {
+			if err := s.async.sync(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if err := syncWriter(s.w); err != nil {
+			errs = append(errs, s.fail(err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// syncWriter flushes and then syncs w, for the methods w has. Files that
+// cannot be synced, such as a terminal or pipe on os.Stdout, are not an
+// error.
+func syncWriter(w any) error {
+	var errs []error
+	if f, ok := w.(interface{ Flush() error }); ok {
+		errs = append(errs, f.Flush())
+	}
+	if s, ok := w.(interface{ Sync() error }); ok {
+		if err := s.Sync(); !errors.Is(err, syscall.EINVAL) && !errors.Is(err, syscall.ENOTSUP) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
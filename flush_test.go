@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncRecorder records Write and Sync calls in order.
+type syncRecorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (w *syncRecorder) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.events = append(w.events, "write "+strings.TrimSuffix(string(p), "\n"))
+	return len(p), nil
+}
+
+func (w *syncRecorder) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.events = append(w.events, "sync")
+	return nil
+}
+
+func TestFlushDrainsAsyncQueueInOrder(t *testing.T) {
+	var out lockedBuffer
+	logger := NewLogger(&out, WithTimeLayout(""), WithAsync(1024, time.Hour, OverflowBlock))
+	defer logger.Close()
+
+	for i := range 100 {
+		logger.Info("line", "i", i)
+	}
+	if err := logger.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 100 {
+		t.Fatalf("Flush wrote %d lines, want 100", len(lines))
+	}
+	for i, line := range lines {
+		if want := fmt.Sprintf("INFO : line i=%d", i); line != want {
+			t.Fatalf("line %d = %q, want %q", i, line, want)
+		}
+	}
+}
+
+func TestFlushWritesStagedBytesBeforeSync(t *testing.T) {
+	rec := new(syncRecorder)
+	fw := NewFlushingWriter(rec, 1<<20, time.Hour)
+	defer fw.Close()
+	logger := NewLogger(fw, WithTimeLayout(""))
+
+	logger.Info("one")
+	logger.Info("two")
+	if len(rec.events) != 0 {
+		t.Fatalf("wrote %v before Flush", rec.events)
+	}
+	if err := logger.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"write INFO : one\nINFO : two", "sync"}
+	if fmt.Sprint(rec.events) != fmt.Sprint(want) {
+		t.Fatalf("events = %q, want %q", rec.events, want)
+	}
+}
+
+func TestFlushCancelledContext(t *testing.T) {
+	w := &gatedWriter{entered: make(chan struct{}), release: make(chan struct{})}
+	logger := NewLogger(w, WithTimeLayout(""), WithAsync(16, time.Millisecond, OverflowBlock))
+	defer logger.Close()
+	defer close(w.release)
+
+	logger.Info("stuck")
+	<-w.entered // the flusher is blocked inside Write
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	if err := logger.Flush(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Flush = %v, want context.Canceled", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := logger.Flush(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Flush = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Flush on a blocked writer took %v", elapsed)
+	}
+}
+
+func TestCloseReturnsPooledBuffers(t *testing.T) {
+	before := buffersInFlight()
+	logger := NewLogger(new(lockedBuffer), WithAsync(64, time.Hour, OverflowBlock))
+	for range 10 {
+		logger.Info("line")
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if n := buffersInFlight() - before; n != 0 {
+		t.Fatalf("%d buffers still checked out after Close", n)
+	}
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// Defaults for NewFlushingWriter's limits.
+const (
+	defaultFlushSize  = 32 << 10
+	defaultFlushDelay = 100 * time.Millisecond
+)
+
+// ErrWriterClosed is returned by writes to a FlushingWriter after Close.
+var ErrWriterClosed = errors.New("flushing writer closed")
+
+// FlushingWriter stages writes in a pooled buffer and passes them on to the
+// underlying writer once maxSize bytes have accumulated or maxDelay has
+// passed since the first of them, whichever comes first. Each Write is kept
+// whole and in order, so a Logger on top of it never has a line split
+// across flushes. It is safe for concurrent use.
+type FlushingWriter struct {
+	w        io.Writer
+	maxSize  int
+	maxDelay time.Duration
+	clock    Clock
+
+	mu      sync.Mutex
+	buf     *bytes.Buffer // nil while nothing is staged
+	batch   uint64        // counts staged batches, for the timer
+	since   time.Time     // when the staged batch began
+	err     error         // a timer flush error not yet reported
+	closed  bool
+	started bool
+	kick    chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// FlushOption configures a FlushingWriter.
+type FlushOption func(*FlushingWriter)
+
+// WithFlushClock sets the Clock whose timers drive the interval flush. A
+// Clock with an After method, such as pooltest.FakeClock, is used for the
+// timer; any other Clock leaves it on real time.
+func WithFlushClock(c Clock) FlushOption {
+	return func(f *FlushingWriter) {
+		f.clock = c
+	}
+}
+
+// NewFlushingWriter returns a FlushingWriter for w. A maxSize or maxDelay
+// of zero or less selects 32 KiB or 100ms. The goroutine behind the interval
+// flush starts with the first write; Close stops it.
+func NewFlushingWriter(w io.Writer, maxSize int, maxDelay time.Duration, opts ...FlushOption) *FlushingWriter {
+	if maxSize <= 0 {
+		maxSize = defaultFlushSize
+	}
+	if maxDelay <= 0 {
+		maxDelay = defaultFlushDelay
+	}
+	f := &FlushingWriter{
+		w:        w,
+		maxSize:  maxSize,
+		maxDelay: maxDelay,
+		clock:    realClock{},
+		kick:     make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// Write stages p, flushing first if p would not fit. A p of maxSize or more
+// is written straight through once the staged bytes are out. An error from
+// an earlier interval flush is returned here, with p discarded.
+func (f *FlushingWriter) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return 0, ErrWriterClosed
+	}
+	if err := f.takeErr(); err != nil {
+		return 0, err
+	}
+
+	if f.buf != nil && f.buf.Len()+len(p) > f.maxSize {
+		if err := f.flushLocked(); err != nil {
+			return 0, err
+		}
+	}
+	if len(p) >= f.maxSize {
+		if err := writeFull(f.w, p); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+
+	if f.buf == nil {
+		f.buf = buffPool.Get()
+		f.buf.Reset()
+		f.batch++
+		f.since = f.clock.Now()
+		f.startTimer()
+	}
+	f.buf.Write(p)
+	return len(p), nil
+}
+
+// Flush writes out whatever is staged.
+func (f *FlushingWriter) Flush() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.takeErr(); err != nil {
+		return err
+	}
+	return f.flushLocked()
+}
+
+// Sync flushes the staged bytes and then syncs the underlying writer if it
+// has a Sync method, such as an *os.File.
+func (f *FlushingWriter) Sync() error {
+	if err := f.Flush(); err != nil {
+		return err
+	}
+	if s, ok := f.w.(interface{ Sync() error }); ok {
+		return s.Sync()
+	}
+	return nil
+}
+
+// Close flushes the staged bytes and stops the interval goroutine. Writes
+// after Close fail with ErrWriterClosed; Close itself may be called again.
+func (f *FlushingWriter) Close() error {
+	f.mu.Lock()
+	if f.closed {
+		f.mu.Unlock()
+		return nil
+	}
+	f.closed = true
+	err := f.takeErr()
+	if ferr := f.flushLocked(); err == nil {
+		err = ferr
+	}
+	started := f.started
+	f.mu.Unlock()
+
+	close(f.stop)
+	if started {
+		<-f.done
+	}
+	return err
+}
+
+// flushLocked writes the staged buffer and returns it to the pool. The
+// staged bytes are dropped even if the write fails.
+func (f *FlushingWriter) flushLocked() error {
+	if f.buf == nil {
+		return nil
+	}
+	err := writeFull(f.w, f.buf.Bytes())
+	releaseWritten(f.buf)
+	f.buf = nil
+	return err
+}
+
+// takeErr returns and clears the pending interval flush error.
+func (f *FlushingWriter) takeErr() error {
+	err := f.err
+	f.err = nil
+	return err
+}
+
+// startTimer tells the interval goroutine, starting it if need be, that a
+// batch began.
+func (f *FlushingWriter) startTimer() {
+	if !f.started {
+		f.started = true
+		go f.run()
+	}
+	select {
+	case f.kick <- struct{}{}:
+	default: // the goroutine will see this batch when it looks next
+	}
+}
+
+// run flushes each batch maxDelay after it began, unless it has been
+// flushed by then.
+func (f *FlushingWriter) run() {
+	defer close(f.done)
+	for {
+		select {
+		case <-f.kick:
+		case <-f.stop:
+			return
+		}
+
+		f.mu.Lock()
+		staged, batch, since := f.buf != nil, f.batch, f.since
+		f.mu.Unlock()
+		if !staged {
+			continue
+		}
+
+		select {
+		case <-after(f.clock, f.maxDelay-f.clock.Now().Sub(since)):
+		case <-f.stop:
+			return
+		}
+
+		f.mu.Lock()
+		if f.batch == batch && f.buf != nil {
+			if err := f.flushLocked(); err != nil {
+				f.err = err
+			}
+		}
+		f.mu.Unlock()
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ArditZubaku/go-sync-pool/pooltest"
+)
+
+func TestFlushingWriterSize(t *testing.T) {
+	var out lockedBuffer
+	f := NewFlushingWriter(&out, 16, time.Hour)
+	defer f.Close()
+
+	f.Write([]byte("0123456789\n"))
+	if got := out.String(); got != "" {
+		t.Fatalf("flushed %q before reaching maxSize", got)
+	}
+
+	f.Write([]byte("abcdef\n")) // does not fit next to the first line
+	if got, want := out.String(), "0123456789\n"; got != want {
+		t.Fatalf("out = %q, want %q", got, want)
+	}
+
+	f.Write([]byte(strings.Repeat("z", 20))) // straight through, after the staged line
+	if got, want := out.String(), "0123456789\nabcdef\n"+strings.Repeat("z", 20); got != want {
+		t.Fatalf("out = %q, want %q", got, want)
+	}
+}
+
+func TestFlushingWriterInterval(t *testing.T) {
+	var out lockedBuffer
+	clock := pooltest.NewFakeClock(time.Unix(0, 0))
+	f := NewFlushingWriter(&out, 1<<10, 100*time.Millisecond, WithFlushClock(clock))
+	defer f.Close()
+
+	f.Write([]byte("line\n"))
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+
+	clock.Advance(99 * time.Millisecond)
+	if got := out.String(); got != "" {
+		t.Fatalf("flushed %q before maxDelay", got)
+	}
+	clock.Advance(time.Millisecond)
+	waitFor(t, func() bool { return out.String() == "line\n" })
+
+	// The next batch gets its own timer.
+	f.Write([]byte("again\n"))
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	clock.Advance(100 * time.Millisecond)
+	waitFor(t, func() bool { return out.String() == "line\nagain\n" })
+}
+
+// waitFor polls cond until it holds or a second has passed.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within a second")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestFlushingWriterPreservesBytes(t *testing.T) {
+	var out, in bytes.Buffer
+	f := NewFlushingWriter(&out, 64, time.Millisecond)
+
+	for i := range 500 {
+		p := []byte(strings.Repeat(string(rune('a'+i%26)), i%150) + "\n")
+		in.Write(p)
+		if _, err := f.Write(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out.Bytes(), in.Bytes()) {
+		t.Fatalf("output differs from input: %d bytes vs %d", out.Len(), in.Len())
+	}
+}
+
+func TestFlushingWriterConcurrentLogging(t *testing.T) {
+	const (
+		writers = 8
+		lines   = 1000
+	)
+	var out lockedBuffer
+	f := NewFlushingWriter(&out, 512, time.Millisecond)
+	logger := NewLogger(f, WithTimeLayout(""))
+
+	var wg sync.WaitGroup
+	wg.Add(writers)
+	for g := range writers {
+		go func() {
+			defer wg.Done()
+			for i := range lines {
+				logger.Info("message", "g", g, "i", i)
+			}
+		}()
+	}
+	wg.Wait()
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var want []string
+	for g := range writers {
+		for i := range lines {
+			want = append(want, fmt.Sprintf("INFO : message g=%d i=%d", g, i))
+		}
+	}
+	got := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %d lines, want %d, or some lines were torn", len(got), len(want))
+	}
+}
+
+func TestFlushingWriterClose(t *testing.T) {
+	var out lockedBuffer
+	f := NewFlushingWriter(&out, 1<<10, time.Hour)
+
+	f.Write([]byte("staged\n"))
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); got != "staged\n" {
+		t.Fatalf("Close flushed %q", got)
+	}
+	if _, err := f.Write([]byte("late\n")); !errors.Is(err, ErrWriterClosed) {
+		t.Fatalf("Write after Close = %v, want ErrWriterClosed", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("second Close = %v", err)
+	}
+}
+
+func TestFlushingWriterIntervalError(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Unix(0, 0))
+	errBoom := errors.New("boom")
+	f := NewFlushingWriter(&failingWriter{err: errBoom}, 1<<10, time.Millisecond, WithFlushClock(clock))
+	defer f.Close()
+
+	f.Write([]byte("line\n"))
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	clock.Advance(time.Millisecond)
+
+	var err error
+	waitFor(t, func() bool {
+		_, err = f.Write([]byte("next\n"))
+		return err != nil
+	})
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("Write after a failed interval flush = %v, want %v", err, errBoom)
+	}
+}
@@ -0,0 +1,32 @@
+//go:build gcimpact
+
+package main
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ArditZubaku/go-sync-pool/pooltest"
+)
+
+// go test -tags gcimpact -run TestGCImpact -v
+//
+// Runs each logging strategy under sustained load from several goroutines
+// and logs the GC it caused, to paste into the results at the top of
+// log_bench_test.go.
+func TestGCImpact(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		log  func(io.Writer, string)
+	}{
+		{"logNoPool", logNoPool},
+		{"logWithPool", logWithPool},
+	} {
+		r := pooltest.MeasureGC(func() { tt.log(io.Discard, "some log message") }, 2*time.Second)
+		if r.Ops == 0 {
+			t.Fatalf("%s: the workload never ran", tt.name)
+		}
+		t.Logf("GC %-12s %s", tt.name, r)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+// WithGCNotify calls fn, on the pool's Scheduler, after each garbage
+// collection the pool observes, with the number observed so far. A canary
+// object is left for the GC to find; its cleanup counts the cycle and plants
+// the next one. Unlike polling runtime.MemStats.NumGC this needs no
+// stop-the-world read and no ticker, and it fires right after the cycles
+// that empty a sync.Pool, which makes it the cue for re-warming one. A cycle
+// that runs while the cleanup queue is busy may go unnoticed. Notifications
+// stop on Close.
+func WithGCNotify[T any](fn func(gcCount int)) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.onGC = fn
+	}
+}
+
+// gcCanary is only allocated to be collected. The pointer keeps it out of
+// the tiny allocator, whose blocks are freed together.
+type gcCanary struct {
+	_ *byte
+}
+
+// gcNotifier plants canaries until it is stopped.
+type gcNotifier struct {
+	n       atomic.Int64
+	stopped atomic.Bool
+	notify  func(n int)
+}
+
+// plant allocates a canary whose collection calls collected.
+func (g *gcNotifier) plant() {
+	runtime.AddCleanup(new(gcCanary), (*gcNotifier).collected, g)
+}
+
+func (g *gcNotifier) collected() {
+	if g.stopped.Load() {
+		return
+	}
+	g.notify(int(g.n.Add(1)))
+	g.plant()
+}
+
+// startGCNotify plants the first WithGCNotify canary.
+func (tp *TypedPool[T]) startGCNotify() {
+	fn := tp.cfg.onGC
+	g := &gcNotifier{notify: func(n int) {
+		tp.cfg.schedule(func() { fn(n) })
+	}}
+	g.plant()
+	tp.detach = append(tp.detach, func() { g.stopped.Store(true) })
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGCNotify(t *testing.T) {
+	var last atomic.Int64
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithGCNotify[*int](func(n int) { last.Store(int64(n)) }))
+
+	waitFor(t, func() bool {
+		runtime.GC()
+		return last.Load() >= 2
+	})
+
+	pool.Close()
+	runtime.GC()
+	time.Sleep(10 * time.Millisecond)
+	stopped := last.Load()
+	for range 3 {
+		runtime.GC()
+	}
+	time.Sleep(10 * time.Millisecond)
+	if n := last.Load(); n != stopped {
+		t.Fatalf("notified of GC %d after Close, having stopped at %d", n, stopped)
+	}
+}
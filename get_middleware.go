@@ -0,0 +1,38 @@
+package main
+
+// WithGetMiddleware wraps every Get, in all its forms, in m, which receives
+// the rest of the Get as next and returns the item the caller gets. It may
+// act before and after calling next, as for metrics or tracing, replace or
+// adjust the item, or not call next at all, to serve from elsewhere or shed
+// load. Middlewares compose like HTTP handlers: the first option given is
+// outermost, so WithGetMiddleware(m1) then WithGetMiddleware(m2) runs
+// m1(m2(get)). If next fails, as TryGet or GetContext can, it returns the
+// pool's failed-Get value and the caller still sees the error, whatever m
+// returns; an item m supplies without calling next reports OriginNew.
+func WithGetMiddleware[T any](m func(next func() T) T) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.getMiddleware = append(cfg.getMiddleware, m)
+	}
+}
+
+// getThrough runs get inside the WithGetMiddleware chain.
+func (tp *TypedPool[T]) getThrough(get func() (T, Origin, error)) (T, Origin, error) {
+	var (
+		origin Origin
+		err    error
+	)
+	next := func() T {
+		var v T
+		v, origin, err = get()
+		return v
+	}
+	for i := len(tp.cfg.getMiddleware) - 1; i >= 0; i-- {
+		m, inner := tp.cfg.getMiddleware[i], next
+		next = func() T { return m(inner) }
+	}
+	v := next()
+	if err != nil {
+		return tp.noItem(), origin, err
+	}
+	return v, origin, nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestGetMiddlewareOrder(t *testing.T) {
+	var calls []string
+	trace := func(name string) func(func() *int) *int {
+		return func(next func() *int) *int {
+			calls = append(calls, name+" before")
+			v := next()
+			calls = append(calls, name+" after")
+			return v
+		}
+	}
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithGetMiddleware(trace("m1")), WithGetMiddleware(trace("m2")))
+
+	pool.Get()
+	want := []string{"m1 before", "m2 before", "m2 after", "m1 after"}
+	if fmt.Sprint(calls) != fmt.Sprint(want) {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestGetMiddlewareShortCircuit(t *testing.T) {
+	spare := new(int)
+	news := 0
+	pool := NewTypedPool(func() *int { news++; return new(int) },
+		WithGetMiddleware(func(func() *int) *int { return spare }))
+
+	if v, origin := pool.GetInfo(); v != spare || origin != OriginNew {
+		t.Fatalf("GetInfo = %p, %v; want the middleware's item and OriginNew", v, origin)
+	}
+	if news != 0 {
+		t.Fatalf("constructor calls = %d, want 0", news)
+	}
+}
+
+func TestGetMiddlewareKeepsErrors(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithConstructorBudget[*int](0),
+		WithGetMiddleware(func(next func() *int) *int {
+			if v := next(); v != nil {
+				return v
+			}
+			return new(int) // tries to paper over the failure
+		}))
+
+	if v, err := pool.TryGet(); err != ErrBudgetExhausted || v != nil {
+		t.Fatalf("TryGet = %p, %v; want nil and ErrBudgetExhausted", v, err)
+	}
+}
+
+// span and tracer stand in for go.opentelemetry.io/otel/trace's Span and
+// Tracer, which a real middleware would use the same way.
+type span interface {
+	SetAttributes(kv ...any)
+	End()
+}
+
+type tracer interface {
+	Start(ctx context.Context, name string) (context.Context, span)
+}
+
+type printSpan struct{ name string }
+
+func (s printSpan) SetAttributes(kv ...any) { fmt.Println(s.name, kv) }
+func (s printSpan) End()                    { fmt.Println(s.name, "ended") }
+
+type printTracer struct{}
+
+func (printTracer) Start(ctx context.Context, name string) (context.Context, span) {
+	return ctx, printSpan{name}
+}
+
+// tracedGet returns a middleware recording every Get as a span.
+func tracedGet[T any](tr tracer, pool string) func(next func() T) T {
+	return func(next func() T) T {
+		_, sp := tr.Start(context.Background(), "pool.Get")
+		defer sp.End()
+		v := next()
+		sp.SetAttributes("pool.name", pool)
+		return v
+	}
+}
+
+func ExampleWithGetMiddleware() {
+	pool := NewTypedPool(func() []byte { return make([]byte, 0, 64) },
+		WithGetMiddleware(tracedGet[[]byte](printTracer{}, "buffers")))
+
+	pool.Get()
+	// Output:
+	// pool.Get [pool.name buffers]
+	// pool.Get ended
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// globalKey identifies a Global pool.
+type globalKey struct {
+	name string
+	typ  reflect.Type
+}
+
+// globalPool is a registered Global pool, created on first use.
+type globalPool struct {
+	ctor uintptr // code pointer of the constructor it was registered with
+	pool func() any
+}
+
+var (
+	globalsMu sync.Mutex
+	globals   = make(map[globalKey]*globalPool)
+)
+
+// Global returns the process-wide TypedPool registered under name for T,
+// creating it with newFn and opts on the first call, so packages and files
+// that need the same kind of item can share one pool instead of each
+// declaring their own. Later calls return the same pool and ignore their
+// opts. It panics if name is already registered for T with a different
+// constructor; closures made by the same function literal count as the same
+// one.
+func Global[T any](name string, newFn func() T, opts ...PoolOption[T]) *TypedPool[T] {
+	key := globalKey{name: name, typ: reflect.TypeFor[T]()}
+	ctor := reflect.ValueOf(newFn).Pointer()
+
+	globalsMu.Lock()
+	g, ok := globals[key]
+	if !ok {
+		g = &globalPool{ctor: ctor, pool: sync.OnceValue(func() any {
+			return NewTypedPool(newFn, opts...)
+		})}
+		globals[key] = g
+	}
+	globalsMu.Unlock()
+
+	if g.ctor != ctor {
+		panic(fmt.Sprintf("Global: pool %q of %v is already registered with a different constructor", name, key.typ))
+	}
+	return g.pool().(*TypedPool[T])
+}
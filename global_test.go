@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGlobalConcurrentFirstUse(t *testing.T) {
+	var news atomic.Int64
+	newFn := func() *int { news.Add(1); return new(int) }
+
+	const n = 64
+	pools := make([]*TypedPool[*int], n)
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := range n {
+		go func() {
+			defer wg.Done()
+			pools[i] = Global("test.concurrent", newFn, WithFIFO[*int]())
+		}()
+	}
+	wg.Wait()
+
+	for i, p := range pools {
+		if p != pools[0] {
+			t.Fatalf("goroutine %d got a different pool", i)
+		}
+	}
+	if news.Load() != 0 {
+		t.Fatalf("creating the pool called the constructor %d times", news.Load())
+	}
+	if Global("test.concurrent", newFn) != pools[0] {
+		t.Fatal("a later call returned a different pool")
+	}
+}
+
+func TestGlobalKeyedByType(t *testing.T) {
+	ints := Global("test.typed", func() *int { return new(int) })
+	strs := Global("test.typed", func() *string { return new(string) })
+	if any(ints) == any(strs) {
+		t.Fatal("pools of different types share a registration")
+	}
+}
+
+func TestGlobalCollisionPanics(t *testing.T) {
+	Global("test.collision", func() *int { return new(int) })
+
+	defer func() {
+		msg, _ := recover().(string)
+		if !strings.Contains(msg, `"test.collision"`) || !strings.Contains(msg, "different constructor") {
+			t.Fatalf("panic = %q, want one naming the pool and the constructor clash", msg)
+		}
+	}()
+	Global("test.collision", func() *int { return new(int) })
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+)
+
+// goroutineID returns the current goroutine's ID, parsed from the first line
+// of runtime.Stack ("goroutine 123 [running]:"). It is meant for diagnostics
+// only: it is slow, and Go deliberately offers no supported way to get it.
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
@@ -0,0 +1,22 @@
+package main
+
+import "fmt"
+
+// WithGoroutineTracking enforces non-transferable ownership: Get records the
+// borrowing goroutine and Put panics if another goroutine returns the item.
+// That includes Puts from a WithSoftTimeout handler. Goroutine IDs are
+// parsed from runtime.Stack, which costs about a microsecond per call, so
+// this is meant for development. Only pointer-like item types are tracked.
+func WithGoroutineTracking[T any]() PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.goroutineTracking = true
+	}
+}
+
+// checkOwner panics unless the calling goroutine is owner.
+func (tp *TypedPool[T]) checkOwner(id uintptr, owner uint64) {
+	if g := goroutineID(); g != owner {
+		panic(fmt.Sprintf("pool %s: item 0x%x borrowed by goroutine %d was returned by goroutine %d",
+			tp.cfg.name(), id, owner, g))
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGoroutineTrackingSameGoroutine(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) }, WithGoroutineTracking[*int]())
+
+	pool.Put(pool.Get())
+	pool.Put(new(int)) // never borrowed, so not checked
+}
+
+func TestGoroutineTrackingPanicsOnHandoff(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) }, WithGoroutineTracking[*int]())
+	v := pool.Get()
+
+	recovered := make(chan any)
+	go func() {
+		defer func() { recovered <- recover() }()
+		pool.Put(v)
+	}()
+
+	r := <-recovered
+	msg, _ := r.(string)
+	if !strings.Contains(msg, "was returned by goroutine") {
+		t.Fatalf("Put from another goroutine recovered %v, want an ownership panic", r)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// WithGracefulDrain makes Close wait up to timeout for every checked-out
+// item to be returned. Items still out when it expires are force-closed:
+// each is passed to the OnDiscard hook, and its eventual Put is dropped.
+// Only pointer-like item types can be force-closed, as the pool has to
+// find them by address. Close reports whether the drain completed in time.
+func WithGracefulDrain[T any](timeout time.Duration) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.drainTimeout = timeout
+	}
+}
+
+// drainWaiter lets Close sleep until the last checked-out item is back.
+type drainWaiter struct {
+	mu     sync.Mutex
+	cond   sync.Cond
+	forced sync.Map // uintptr -> struct{}, items force-closed by Close
+}
+
+func newDrainWaiter() *drainWaiter {
+	d := new(drainWaiter)
+	d.cond.L = &d.mu
+	return d
+}
+
+// signal wakes Close; the caller has just returned the last item.
+func (d *drainWaiter) signal() {
+	d.mu.Lock()
+	d.cond.Broadcast()
+	d.mu.Unlock()
+}
+
+// waitDrained waits up to the WithGracefulDrain timeout for the items in use
+// to reach zero and reports whether they did.
+func (tp *TypedPool[T]) waitDrained() bool {
+	d := tp.drain
+	expired := false
+	timer := time.AfterFunc(tp.cfg.drainTimeout, func() {
+		d.mu.Lock()
+		expired = true
+		d.cond.Broadcast()
+		d.mu.Unlock()
+	})
+	defer timer.Stop()
+
+	d.mu.Lock()
+	for tp.conc.inUse.Load() > 0 && !expired {
+		d.cond.Wait()
+	}
+	d.mu.Unlock()
+	return tp.conc.inUse.Load() == 0
+}
+
+// forceClose discards every item still checked out. Each is marked forced
+// before its checkout is taken, so a Put that finds the checkout gone knows
+// the item is no longer its to return.
+func (tp *TypedPool[T]) forceClose() {
+	tp.checkouts.m.Range(func(key, value any) bool {
+		tp.drain.forced.Store(key, struct{}{})
+		co := tp.checkouts.remove(key.(uintptr))
+		if co == nil {
+			// Put got there first and owns the item.
+			tp.drain.forced.Delete(key)
+			return true
+		}
+		tp.conc.release()
+		tp.discard(co.item.(T))
+		return true
+	})
+}
+
+// dropForced reports whether v was force-closed, forgetting it if so; its
+// Put is then dropped. co is the checkout Put removed: whichever of Put and
+// forceClose removes it owns the item.
+func (tp *TypedPool[T]) dropForced(v T, co *checkout) bool {
+	if tp.drain == nil || co != nil {
+		return false
+	}
+	_, forced := tp.drain.forced.LoadAndDelete(uintptr(itemIdentity(v)))
+	return forced
+}
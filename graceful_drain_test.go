@@ -0,0 +1,94 @@
+package main
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGracefulDrainWaitsForReturns(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) }, WithGracefulDrain[*int](time.Second))
+	a, b := pool.Get(), pool.Get()
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		pool.Put(a)
+		pool.Put(b)
+	}()
+	start := time.Now()
+	if !pool.Close() {
+		t.Fatal("Close reported an incomplete drain")
+	}
+	if time.Since(start) < 10*time.Millisecond {
+		t.Fatal("Close returned before the items came back")
+	}
+}
+
+func TestGracefulDrainForceClosesOnTimeout(t *testing.T) {
+	var closed []*int
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithFIFO[*int](),
+		WithGracefulDrain[*int](10*time.Millisecond),
+		WithOnDiscard(func(v *int) { closed = append(closed, v) }),
+	)
+	returned, stuck := pool.Get(), pool.Get()
+	pool.Put(returned)
+
+	if pool.Close() {
+		t.Fatal("Close reported a complete drain with an item still out")
+	}
+	if len(closed) != 1 || closed[0] != stuck {
+		t.Fatalf("force-closed %v, want only the item still out", closed)
+	}
+
+	pool.Put(stuck) // a late return of a closed item is dropped
+	if len(closed) != 1 || pool.Len() != 1 {
+		t.Fatalf("late Put: closed %d, Len %d; want 1 and 1", len(closed), pool.Len())
+	}
+}
+
+func TestCloseWithoutGracefulDrain(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) })
+	pool.Get()
+	if !pool.Close() {
+		t.Fatal("Close without WithGracefulDrain reported false")
+	}
+}
+
+func TestGracefulDrainRacingPutsAreNotPooled(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		closed = make(map[*int]bool)
+	)
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithFIFO[*int](),
+		WithGracefulDrain[*int](time.Millisecond),
+		WithOnDiscard(func(v *int) { mu.Lock(); closed[v] = true; mu.Unlock() }),
+	)
+	items := make([]*int, 200)
+	for i := range items {
+		items[i] = pool.Get()
+	}
+
+	var wg sync.WaitGroup
+	for _, v := range items {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			time.Sleep(time.Millisecond)
+			pool.Put(v)
+		}()
+	}
+	pool.Close()
+	wg.Wait()
+
+	for {
+		v, ok := pool.pool.get()
+		if !ok {
+			break
+		}
+		if closed[v] {
+			t.Fatal("an item force-closed by Close was pooled by its Put")
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import "context"
+
+// WithHashBucketPut splits the pool's store into buckets indexed by content:
+// Put files an item under hashFn(item) modulo buckets, and GetByHash takes
+// from the bucket for a given hash, so a caller gets back the item last used
+// for, say, the same file, with its buffers already sized for it. Unlike
+// WithHashDispatch the buckets share the pool's options and accounting; only
+// where idle items wait differs. Get takes from whichever bucket has an
+// item. It panics if buckets is less than 1, and NewTypedPool panics if it
+// is combined with WithCustomStore.
+func WithHashBucketPut[T any](hashFn func(T) int, buckets int) PoolOption[T] {
+	if hashFn == nil || buckets < 1 {
+		panic("WithHashBucketPut: hashFn must not be nil and buckets must be at least 1")
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.putHashFn, cfg.putHashBuckets = hashFn, buckets
+	}
+}
+
+// hashStore is the WithHashBucketPut store: one store of the pool's kind per
+// bucket.
+type hashStore[T any] struct {
+	hashFn  func(T) int
+	buckets []idleStore[T]
+}
+
+func newHashStore[T any](cfg *poolConfig[T]) *hashStore[T] {
+	hs := &hashStore[T]{hashFn: cfg.putHashFn, buckets: make([]idleStore[T], cfg.putHashBuckets)}
+	for i := range hs.buckets {
+		if cfg.ordering == FIFO {
+			hs.buckets[i] = new(fifoStore[T])
+		} else {
+			hs.buckets[i] = newSyncStore[T](cfg.itemPool)
+		}
+	}
+	return hs
+}
+
+// bucket returns the bucket for hash.
+func (hs *hashStore[T]) bucket(hash int) idleStore[T] {
+	return hs.buckets[uint(hash)%uint(len(hs.buckets))]
+}
+
+func (hs *hashStore[T]) get() (T, bool) {
+	for _, b := range hs.buckets {
+		if v, ok := b.get(); ok {
+			return v, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+func (hs *hashStore[T]) put(v T) {
+	hs.bucket(hs.hashFn(v)).put(v)
+}
+
+// removeWhere filters every bucket, which must all be filterStores.
+func (hs *hashStore[T]) removeWhere(remove func(T) bool) []T {
+	var removed []T
+	for _, b := range hs.buckets {
+		removed = append(removed, b.(filterStore[T]).removeWhere(remove)...)
+	}
+	return removed
+}
+
+// filterable returns the pool's store as a filterStore, if it can filter
+// itself.
+func (tp *TypedPool[T]) filterable() (filterStore[T], bool) {
+	switch s := tp.pool.(type) {
+	case *fifoStore[T]:
+		return s, true
+	case *hashStore[T]:
+		if _, ok := s.buckets[0].(filterStore[T]); ok {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// GetByHash is Get, preferring an item Put with the given hash. If its
+// bucket is empty it takes from any other before constructing. Without
+// WithHashBucketPut it is Get.
+func (tp *TypedPool[T]) GetByHash(hash int) T {
+	hs, ok := tp.pool.(*hashStore[T])
+	if !ok || tp.classes != nil {
+		return tp.Get()
+	}
+	v, _, err := tp.getFrom(context.Background(), 0, hs.bucket(hash))
+	return tp.orNoItem(v, err)
+}
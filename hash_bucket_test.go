@@ -0,0 +1,52 @@
+package main
+
+import "testing"
+
+type csvBuffer struct {
+	file string
+	buf  []byte
+}
+
+func TestHashBucketPut(t *testing.T) {
+	hash := func(b *csvBuffer) int { return len(b.file) }
+	pool := NewTypedPool(func() *csvBuffer { return new(csvBuffer) },
+		WithHashBucketPut(hash, 4),
+		WithFIFO[*csvBuffer](),
+		WithStats[*csvBuffer](),
+	)
+
+	a, b := &csvBuffer{file: "a"}, &csvBuffer{file: "bb"}
+	pool.Put(a)
+	pool.Put(b)
+	if got := pool.Len(); got != 2 {
+		t.Fatalf("Len() = %d, want 2", got)
+	}
+
+	if got := pool.GetByHash(2); got != b {
+		t.Fatal("GetByHash(2) did not return the item Put with hash 2")
+	}
+	if got := pool.GetByHash(6); got != a {
+		t.Fatal("GetByHash on an empty bucket did not fall back to another")
+	}
+	pool.GetByHash(1)
+	if s := pool.Stats(); s.Hits != 2 || s.Misses != 1 {
+		t.Fatalf("hits, misses = %d, %d; want 2, 1", s.Hits, s.Misses)
+	}
+
+	pool.Put(a)
+	if got := pool.Get(); got != a {
+		t.Fatal("Get did not take from the buckets")
+	}
+}
+
+func TestHashBucketPutRejectsCustomStore(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("NewTypedPool accepted WithHashBucketPut with WithCustomStore")
+		}
+	}()
+	NewTypedPool(func() *int { return new(int) },
+		WithHashBucketPut(func(*int) int { return 0 }, 2),
+		WithCustomStore[*int](NewLIFOStore[*int]()),
+	)
+}
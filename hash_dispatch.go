@@ -0,0 +1,58 @@
+package main
+
+// WithHashDispatch splits the pool into shards indexed by key, so that
+// GetByKey tends to return the item last used for the same key, with its
+// caches still warm. The shard for a key is hashFn(key) modulo shards. Each
+// shard is a plain TypedPool with the pool's constructor and Ordering; the
+// pool's other options do not apply to it. Get and Put keep using the unsharded
+// store. It panics if shards is less than 1.
+func WithHashDispatch[T any](hashFn func(key uint64) int, shards int) PoolOption[T] {
+	if shards < 1 {
+		panic("WithHashDispatch: shards must be at least 1")
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.hashFn = hashFn
+		cfg.shards = shards
+	}
+}
+
+// newShards builds the WithHashDispatch shards.
+func (tp *TypedPool[T]) newShards() []*TypedPool[T] {
+	shards := make([]*TypedPool[T], tp.cfg.shards)
+	for i := range shards {
+		shards[i] = NewTypedPool(tp.newFn, WithOrdering[T](tp.cfg.ordering))
+	}
+	return shards
+}
+
+// shard returns the shard for key.
+func (tp *TypedPool[T]) shard(key uint64) *TypedPool[T] {
+	return tp.shards[uint(tp.cfg.hashFn(key))%uint(len(tp.shards))]
+}
+
+// GetByKey returns an item from key's shard, constructing one if it is
+// empty. Without WithHashDispatch it is Get.
+func (tp *TypedPool[T]) GetByKey(key uint64) T {
+	if tp.shards == nil {
+		return tp.Get()
+	}
+	return tp.shard(key).Get()
+}
+
+// GetInfoByKey is GetByKey, also reporting whether the item was constructed
+// for this call.
+func (tp *TypedPool[T]) GetInfoByKey(key uint64) (T, Origin) {
+	if tp.shards == nil {
+		return tp.GetInfo()
+	}
+	return tp.shard(key).GetInfo()
+}
+
+// PutByKey returns v to key's shard. Without WithHashDispatch it is Put.
+func (tp *TypedPool[T]) PutByKey(key uint64, v T) {
+	if tp.shards == nil {
+		tp.Put(v)
+		return
+	}
+	tp.shard(key).Put(v)
+}
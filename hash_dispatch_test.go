@@ -0,0 +1,43 @@
+package main
+
+import "testing"
+
+func TestHashDispatchReturnsItemForSameKey(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithFIFO[*int](),
+		WithHashDispatch[*int](func(key uint64) int { return int(key) }, 4))
+
+	a, b := pool.GetByKey(1), pool.GetByKey(2)
+	*a, *b = 1, 2
+	pool.PutByKey(1, a)
+	pool.PutByKey(2, b)
+
+	for _, key := range []uint64{1, 5} { // 5 lands in key 1's shard
+		if got := pool.GetByKey(key); got != a {
+			t.Fatalf("GetByKey(%d) = %d, want the item put under key 1", key, *got)
+		}
+		pool.PutByKey(key, a)
+	}
+	if got := *pool.GetByKey(2); got != 2 {
+		t.Fatalf("GetByKey(2) = %d, want 2", got)
+	}
+	if got := *pool.GetByKey(3); got != 0 {
+		t.Fatalf("GetByKey(3) = %d, want a fresh item", got)
+	}
+}
+
+func TestHashDispatchNegativeHash(t *testing.T) {
+	pool := NewTypedPool(func() int { return 0 },
+		WithHashDispatch[int](func(uint64) int { return -7 }, 3))
+	pool.PutByKey(1, 1)
+	pool.GetByKey(1)
+}
+
+func TestHashDispatchPanicsWithoutShards(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("no panic for 0 shards")
+		}
+	}()
+	WithHashDispatch[int](func(uint64) int { return 0 }, 0)
+}
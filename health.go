@@ -0,0 +1,70 @@
+package main
+
+// WithHealthCheck registers fn to judge idle items during HealthCheck.
+// Items for which fn returns false are discarded. fn must not call back into
+// the pool.
+func WithHealthCheck[T any](fn func(T) bool) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.healthCheck = fn
+	}
+}
+
+// HealthCheck runs the WithHealthCheck function over every idle item, keeps
+// the healthy ones, and returns how many were discarded. Without a health
+// check function it does nothing.
+func (tp *TypedPool[T]) HealthCheck() int {
+	if tp.cfg.healthCheck == nil {
+		return 0
+	}
+
+	var healthy []T
+	discarded := 0
+	for i := 0; ; i++ {
+		tp.cfg.staggerBefore(i)
+		item, ok := tp.take()
+		if !ok {
+			break
+		}
+		if tp.cfg.healthCheck(item) {
+			healthy = append(healthy, item)
+			continue
+		}
+		tp.discard(item)
+		discarded++
+	}
+
+	for i, item := range healthy {
+		tp.cfg.staggerBefore(i)
+		tp.putBack(item)
+	}
+	return discarded
+}
+
+// HealthCheck runs the WithHealthCheck function over every idle item, keeps
+// the healthy ones in their original order, and returns how many were
+// discarded. Without a health check function it does nothing.
+func (bp *BoundedPool[T]) HealthCheck() int {
+	if bp.cfg.healthCheck == nil {
+		return 0
+	}
+
+	bp.mu.Lock()
+	var (
+		discarded int
+		freed     int64
+	)
+	for range bp.idle.len() {
+		it, _ := bp.idle.popFront()
+		if bp.cfg.healthCheck(it.v) {
+			bp.idle.pushBack(it)
+			continue
+		}
+		bp.discard(it.v)
+		freed += bp.forget(it)
+		discarded++
+	}
+	bp.mu.Unlock()
+
+	bp.release(freed)
+	return discarded
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// defaultMaxDump is how many bytes DumpHex shows unless WithMaxDumpBytes
+// says otherwise.
+const defaultMaxDump = 4 << 10
+
+// hexDumpRow is the length of a full row of AppendHexDump output.
+const hexDumpRow = 79
+
+// WithMaxDumpBytes sets how many bytes of a payload DumpHex shows before it
+// truncates the dump. The default is 4 KiB.
+func WithMaxDumpBytes(n int) LoggerOption {
+	return func(l *Logger) {
+		l.maxDump = n
+	}
+}
+
+// AppendHexDump appends the dump of data that hex.Dump would return, 16
+// bytes per row with the offset, the bytes in hex and the printable ones as
+// ASCII:
+//
+//	00000000  68 65 6c 6c 6f 0a                                 |hello.|
+//
+// Unlike hex.Dump it allocates only if dst has to grow.
+func AppendHexDump(dst, data []byte) []byte {
+	const digits = "0123456789abcdef"
+	for off := 0; off < len(data); off += 16 {
+		row := data[off:min(off+16, len(data))]
+
+		for shift := 28; shift >= 0; shift -= 4 {
+			dst = append(dst, digits[uint32(off)>>shift&0xf])
+		}
+		dst = append(dst, ' ', ' ')
+		for i := range 16 {
+			if i < len(row) {
+				dst = append(dst, digits[row[i]>>4], digits[row[i]&0xf], ' ')
+			} else {
+				dst = append(dst, ' ', ' ', ' ')
+			}
+			if i == 7 {
+				dst = append(dst, ' ')
+			}
+		}
+		dst = append(dst, ' ', '|')
+		for _, c := range row {
+			if c < 32 || c > 126 {
+				c = '.'
+			}
+			dst = append(dst, c)
+		}
+		dst = append(dst, '|', '\n')
+	}
+	return dst
+}
+
+// DumpHex logs label at level with the payload's length, followed by a hex
+// dump of data in the layout of AppendHexDump. Past WithMaxDumpBytes the
+// dump stops with a "... (N more bytes)" row. In JSON mode the dump is the
+// "dump" field. In text mode the dump is rendered straight into the pooled
+// line buffer, without allocating.
+func (l *Logger) DumpHex(level Level, label string, data []byte) error {
+	if !l.renders(level) {
+		return nil
+	}
+	if (l.sampler != nil || l.dedup != nil) && !l.admit(level, label, nil) {
+		return nil
+	}
+	pc := l.callerPC()
+
+	limit := l.maxDump
+	if limit <= 0 {
+		limit = defaultMaxDump
+	}
+	shown := data[:min(len(data), limit)]
+
+	b := buffPool.Get()
+	b.Reset()
+	if l.json {
+		scratch := buffPool.Get()
+		scratch.Reset()
+		appendDump(scratch, shown, len(data))
+		l.appendLine(b, level, label, []any{"bytes", len(data), "dump", bytesString(scratch.Bytes())}, pc)
+		buffPool.Put(scratch)
+	} else {
+		// appendLine would box the length, which allocates past 255.
+		l.appendHeader(b)
+		b.WriteString(level.String())
+		b.WriteString(l.separator)
+		l.appendCaller(b, pc)
+		l.appendMessage(b, label)
+		b.Write(l.static)
+		b.WriteString(" bytes=")
+		b.Write(strconv.AppendInt(b.AvailableBuffer(), int64(len(data)), 10))
+		l.appendSeq(b)
+		b.WriteByte('\n')
+		appendDump(b, shown, len(data))
+	}
+	return l.emit(level, b)
+}
+
+// appendDump writes the rows for shown, out of total bytes, and the
+// truncation row if any were left out.
+func appendDump(b *bytes.Buffer, shown []byte, total int) {
+	b.Grow((len(shown) + 15) / 16 * hexDumpRow)
+	b.Write(AppendHexDump(b.AvailableBuffer(), shown))
+	if rest := total - len(shown); rest > 0 {
+		b.WriteString("... (")
+		b.Write(strconv.AppendInt(b.AvailableBuffer(), int64(rest), 10))
+		b.WriteString(" more bytes)\n")
+	}
+}
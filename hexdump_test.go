@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+)
+
+func TestAppendHexDumpLayout(t *testing.T) {
+	data := []byte("GET / HTTP/1.1\r\nHost: x\r\n\x00\xff")
+	want := "" +
+		"00000000  47 45 54 20 2f 20 48 54  54 50 2f 31 2e 31 0d 0a  |GET / HTTP/1.1..|\n" +
+		"00000010  48 6f 73 74 3a 20 78 0d  0a 00 ff                 |Host: x....|\n"
+	if got := string(AppendHexDump(nil, data)); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestAppendHexDumpMatchesEncodingHex(t *testing.T) {
+	data := make([]byte, 300)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	for n := range len(data) {
+		if got, want := string(AppendHexDump([]byte("keep"), data[:n])), "keep"+hex.Dump(data[:n]); got != want {
+			t.Fatalf("%d bytes: got:\n%s\nwant:\n%s", n, got, want)
+		}
+	}
+}
+
+func TestDumpHex(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(&buf, WithTimeLayout(""), WithMaxDumpBytes(20))
+
+	logger.DumpHex(LevelWarn, "frame", []byte("0123456789abcdefghijklmnopqrstuvwxyz"))
+	logger.DumpHex(LevelDebug, "hidden", []byte("x"))
+
+	want := "WARN : frame bytes=36\n" +
+		"00000000  30 31 32 33 34 35 36 37  38 39 61 62 63 64 65 66  |0123456789abcdef|\n" +
+		"00000010  67 68 69 6a                                       |ghij|\n" +
+		"... (16 more bytes)\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestDumpHexJSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(&buf, WithJSON(), WithTimeLayout(""))
+
+	logger.DumpHex(LevelInfo, "frame", []byte("hi"))
+
+	var line struct {
+		Msg   string `json:"msg"`
+		Bytes int    `json:"bytes"`
+		Dump  string `json:"dump"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("%v in %q", err, buf.String())
+	}
+	if line.Msg != "frame" || line.Bytes != 2 || line.Dump != hex.Dump([]byte("hi")) {
+		t.Fatalf("line = %+v", line)
+	}
+}
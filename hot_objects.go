@@ -0,0 +1,88 @@
+package main
+
+import (
+	"cmp"
+	"container/heap"
+	"slices"
+)
+
+// WithRecordHotObjects keeps track of the items reused most, by the
+// WithOnReuse counts, so HotObjects can report the n hottest idle items,
+// to guide pre-warming or spot cache-hot objects. The count table holds a
+// reference to each idle item, so items the GC clears from the pool stay
+// reachable until a miss a few collections later. Only pointer-like item
+// types are tracked. It panics if n is less than 1.
+func WithRecordHotObjects[T any](n int) PoolOption[T] {
+	if n < 1 {
+		panic("WithRecordHotObjects: n must be at least 1")
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.hotObjects = n
+	}
+}
+
+// HotObjects returns up to the WithRecordHotObjects n idle items reused
+// most, hottest first, or nil without the option. The items stay in the
+// pool: they are for inspection, not to be used or Put.
+func (tp *TypedPool[T]) HotObjects() []T {
+	n := tp.cfg.hotObjects
+	if n == 0 {
+		return nil
+	}
+	// A min-heap of the hottest n seen so far, so each candidate only has to
+	// beat the coolest of them.
+	var top hotHeap[T]
+	tp.reuse.m.Range(func(_, value any) bool {
+		e := value.(*reuseEntry)
+		item := e.item.Load()
+		if item == nil || !e.idle.Load() {
+			return true
+		}
+		h := hotObject[T]{item: (*item).(T), reuses: e.n.Load()}
+		if len(top) < n {
+			heap.Push(&top, h)
+		} else if h.reuses > top[0].reuses {
+			top[0] = h
+			heap.Fix(&top, 0)
+		}
+		return true
+	})
+	slices.SortFunc(top, func(a, b hotObject[T]) int { return cmp.Compare(b.reuses, a.reuses) })
+	items := make([]T, len(top))
+	for i, h := range top {
+		items[i] = h.item
+	}
+	return items
+}
+
+// recordHot remembers v in its reuse entry as it goes idle.
+func (tp *TypedPool[T]) recordHot(v T) {
+	if tp.cfg.hotObjects == 0 {
+		return
+	}
+	if e, ok := tp.reuse.m.Load(uintptr(itemIdentity(v))); ok {
+		var item any = v
+		e.(*reuseEntry).item.Store(&item)
+	}
+}
+
+// hotObject is an idle item and the number of times it has been reused.
+type hotObject[T any] struct {
+	item   T
+	reuses int64
+}
+
+// hotHeap is a min-heap of hotObjects by reuse count.
+type hotHeap[T any] []hotObject[T]
+
+func (h hotHeap[T]) Len() int           { return len(h) }
+func (h hotHeap[T]) Less(i, j int) bool { return h[i].reuses < h[j].reuses }
+func (h hotHeap[T]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *hotHeap[T]) Push(x any)        { *h = append(*h, x.(hotObject[T])) }
+
+func (h *hotHeap[T]) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
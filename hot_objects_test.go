@@ -0,0 +1,38 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRecordHotObjects(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) }, WithRecordHotObjects[*int](2), WithFIFO[*int]())
+
+	// cycle reuses v, the only idle item, n times and checks it out again.
+	cycle := func(v *int, n int) {
+		pool.Put(v)
+		for range n {
+			pool.Put(pool.Get())
+		}
+		if pool.Get() != v {
+			t.Fatal("Get did not return the only idle item")
+		}
+	}
+	a, b, c := pool.Get(), pool.Get(), pool.Get()
+	cycle(c, 3) // 4 reuses
+	cycle(b, 2) // 3
+	cycle(a, 0) // 1
+	if got := pool.HotObjects(); len(got) != 0 {
+		t.Fatalf("HotObjects with nothing idle = %v", got)
+	}
+
+	pool.Put(a)
+	pool.Put(b)
+	pool.Put(c)
+	if got := pool.HotObjects(); !slices.Equal(got, []*int{c, b}) {
+		t.Fatalf("HotObjects = %p, want c then b (%p %p)", got, c, b)
+	}
+	if NewTypedPool(func() *int { return new(int) }).HotObjects() != nil {
+		t.Fatal("HotObjects without WithRecordHotObjects is not nil")
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// WithIdleCapLimit discards items that have sat idle in the pool for max or
+// more since their last Put. A background scan on the pool's Scheduler
+// and Clock checks every max/2 until Close, so an item may linger for up to
+// half of max more. Unlike a cap on age since construction, an item in
+// steady use never expires, which suits connection pools whose idle
+// connections hold server-side resources. Like WithSoftTimeout it tracks
+// only pointer-like item types. A WithFIFO store is scanned in place; other
+// stores at most half at a time, as evictWhere describes.
+func WithIdleCapLimit[T any](max time.Duration) PoolOption[T] {
+	if max <= 0 {
+		panic("WithIdleCapLimit: max must be positive")
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.idleCap = max
+	}
+}
+
+// idleSince records when each idle item was last Put.
+type idleSince struct {
+	m sync.Map // uintptr -> *idleStamp
+}
+
+// idleStamp is one idle item's WithIdleCapLimit stamp.
+type idleStamp struct {
+	idleGen
+	at time.Time
+}
+
+// stampIdle records that v was just pooled.
+func (tp *TypedPool[T]) stampIdle(v T) {
+	if tp.idleSince == nil {
+		return
+	}
+	if id := uintptr(itemIdentity(v)); id != 0 {
+		tp.idleSince.stamp(id, tp.cfg.now())
+	}
+}
+
+// stamp records that the item at id went idle at now.
+func (s *idleSince) stamp(id uintptr, now time.Time) {
+	e := &idleStamp{at: now}
+	e.setIdle(true)
+	s.m.Store(id, e)
+}
+
+// unstampIdle forgets when v was pooled, as it leaves the store.
+func (tp *TypedPool[T]) unstampIdle(v T) {
+	if tp.idleSince != nil {
+		tp.idleSince.m.Delete(uintptr(itemIdentity(v)))
+	}
+}
+
+// pruneIdleSince forgets the stamps of items idle since collection cutoff,
+// for pruneGone: the items behind them are gone.
+func (tp *TypedPool[T]) pruneIdleSince(cutoff int64) {
+	if tp.idleSince == nil {
+		return
+	}
+	tp.idleSince.m.Range(func(key, value any) bool {
+		if value.(*idleStamp).gone(cutoff) {
+			tp.idleSince.m.Delete(key)
+		}
+		return true
+	})
+}
+
+// evictIdle discards the items idle for at least the WithIdleCapLimit
+// and returns how many there were. The rest go back to the store with their
+// stamps, and so their idle time, intact; one found without a stamp, which
+// only pointer-like items get, is stamped now and so evicted a full limit
+// later.
+func (tp *TypedPool[T]) evictIdle() int {
+	now := tp.cfg.now()
+	deadline := now.Add(-tp.cfg.idleCap)
+	return tp.evictWhere(func(item T) bool {
+		id := uintptr(itemIdentity(item))
+		since, ok := tp.idleSince.m.Load(id)
+		if !ok && id != 0 {
+			tp.idleSince.stamp(id, now)
+		}
+		return ok && !since.(*idleStamp).at.After(deadline)
+	})
+}
+
+// evictWhere drops the idle items evict reports true for and returns how
+// many it dropped. Stores that can filter themselves, as the FIFO store can,
+// do so in one pass under their lock, so Gets keep finding the items that
+// stay. Other stores, sync.Pool among them, can only be scanned by taking
+// items out and restoring them, so each pass takes at most half the idle
+// items: which half is up to the store, and sync.Pool hands back its most
+// recent Puts first.
+func (tp *TypedPool[T]) evictWhere(evict func(T) bool) int {
+	if fs, ok := tp.filterable(); ok {
+		evicted := fs.removeWhere(evict)
+		for _, item := range evicted {
+			tp.taken(item)
+			tp.drop(item)
+		}
+		return len(evicted)
+	}
+
+	var (
+		keep    []T
+		evicted int
+	)
+	// Only look at what is idle now, so items Put during the scan are not
+	// taken over and over.
+	for range max(tp.inPool.Load()/2, 1) {
+		item, ok := tp.take()
+		if !ok {
+			break
+		}
+		if evict(item) {
+			tp.drop(item)
+			evicted++
+			continue
+		}
+		keep = append(keep, item)
+	}
+	for _, item := range keep {
+		tp.restore(item)
+	}
+	return evicted
+}
+
+// restore returns an item taken by take to the store without counting a
+// Put.
+func (tp *TypedPool[T]) restore(v T) {
+	tp.admitWeight(tp.cfg.objectLimit.weightOf(v))
+	tp.inPool.Add(1)
+	tp.restoreCount()
+	tp.retain(tp.sizeOf(v))
+	tp.noteIdle(v)
+	tp.pool.put(v)
+}
+
+// startIdleCap runs evictIdle every half WithIdleCapLimit.
+func (tp *TypedPool[T]) startIdleCap() {
+	tp.bg.run(tp.cfg.schedule, func(stop <-chan struct{}) {
+		for {
+			select {
+			case <-stop:
+				return
+			case <-after(tp.cfg.clock, tp.cfg.idleCap/2):
+				tp.evictIdle()
+			}
+		}
+	})
+}
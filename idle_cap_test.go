@@ -0,0 +1,98 @@
+package main
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ArditZubaku/go-sync-pool/pooltest"
+)
+
+func TestIdleCapLimit(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Unix(0, 0))
+	var discarded atomic.Int64
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithIdleCapLimit[*int](10*time.Second),
+		WithPoolClock[*int](clock),
+		WithOnDiscard(func(*int) { discarded.Add(1) }),
+		WithStats[*int](),
+		WithFIFO[*int](),
+	)
+	defer pool.Close()
+
+	old, recent := pool.Get(), pool.Get()
+	pool.Put(old)
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	clock.Advance(5 * time.Second) // the first scan: nothing has expired
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	pool.Put(recent)
+	if n := discarded.Load(); n != 0 {
+		t.Fatalf("discarded %d before anything expired", n)
+	}
+
+	clock.Advance(5 * time.Second) // old has been idle 10s, recent 5s
+	waitFor(t, func() bool { return discarded.Load() == 1 })
+	// The eviction counts as a discarded Put; keeping recent counts nothing.
+	if s := pool.Stats(); s.Puts != 3 || s.Discards != 1 {
+		t.Fatalf("Puts = %d, Discards = %d, want 3 and 1", s.Puts, s.Discards)
+	}
+	if got := pool.Get(); got != recent {
+		t.Fatal("Get did not return the item still within the cap")
+	}
+}
+
+func TestIdleCapLimitStampsUnstampedItems(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Unix(0, 0))
+	store := NewLIFOStore[*int]()
+	var discarded atomic.Int64
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithIdleCapLimit[*int](10*time.Second),
+		WithPoolClock[*int](clock),
+		WithOnDiscard(func(*int) { discarded.Add(1) }),
+		WithCustomStore[*int](store),
+	)
+	defer pool.Close()
+
+	// An item whose stamp was lost, here by never having one.
+	store.Put(new(int))
+	pool.inPool.Add(1)
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	clock.Advance(5 * time.Second) // the scan stamps it
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	clock.Advance(5 * time.Second)
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	if n := discarded.Load(); n != 0 {
+		t.Fatalf("discarded %d, want the item kept until a full cap after the stamp", n)
+	}
+	clock.Advance(5 * time.Second)
+	waitFor(t, func() bool { return discarded.Load() == 1 })
+}
+
+func TestEvictWhereLeavesItemsIdle(t *testing.T) {
+	for _, bc := range []struct {
+		name    string
+		opt     PoolOption[*int]
+		minIdle int
+	}{
+		{"fifo", WithFIFO[*int](), 4},
+		{"custom", WithCustomStore[*int](NewLIFOStore[*int]()), 2},
+	} {
+		t.Run(bc.name, func(t *testing.T) {
+			pool := NewTypedPool(func() *int { return new(int) }, bc.opt)
+			items := []*int{pool.Get(), pool.Get(), pool.Get(), pool.Get()}
+			for _, v := range items {
+				pool.Put(v)
+			}
+
+			evicted := pool.evictWhere(func(v *int) bool {
+				if n := pool.Len(); n < bc.minIdle {
+					t.Errorf("%d items idle during the scan, want at least %d", n, bc.minIdle)
+				}
+				return v == items[3]
+			})
+			if evicted != 1 || pool.Len() != 3 {
+				t.Fatalf("evicted %d leaving %d, want 1 leaving 3", evicted, pool.Len())
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import "time"
+
+// idleItem is an idle object together with the time it was Put and its
+// WithObjectLimit weight.
+type idleItem[T any] struct {
+	v      T
+	since  time.Time
+	weight int64
+}
+
+// idleRing is a fixed-capacity deque of idle objects ordered by Put time.
+// It is not safe for concurrent use.
+type idleRing[T any] struct {
+	items []idleItem[T]
+	head  int
+	n     int
+}
+
+func newIdleRing[T any](capacity int) idleRing[T] {
+	return idleRing[T]{items: make([]idleItem[T], capacity)}
+}
+
+func (r *idleRing[T]) len() int { return r.n }
+
+func (r *idleRing[T]) full() bool { return r.n == len(r.items) }
+
+// pushBack appends it and reports whether there was room for it.
+func (r *idleRing[T]) pushBack(it idleItem[T]) bool {
+	if r.full() {
+		return false
+	}
+	r.items[(r.head+r.n)%len(r.items)] = it
+	r.n++
+	return true
+}
+
+func (r *idleRing[T]) front() (idleItem[T], bool) {
+	if r.n == 0 {
+		return idleItem[T]{}, false
+	}
+	return r.items[r.head], true
+}
+
+func (r *idleRing[T]) popFront() (idleItem[T], bool) {
+	if r.n == 0 {
+		return idleItem[T]{}, false
+	}
+	it := r.items[r.head]
+	r.items[r.head] = idleItem[T]{}
+	r.head = (r.head + 1) % len(r.items)
+	r.n--
+	return it, true
+}
+
+func (r *idleRing[T]) popBack() (idleItem[T], bool) {
+	if r.n == 0 {
+		return idleItem[T]{}, false
+	}
+	i := (r.head + r.n - 1) % len(r.items)
+	it := r.items[i]
+	r.items[i] = idleItem[T]{}
+	r.n--
+	return it, true
+}
+
+// at returns the i-th item counted from the front.
+func (r *idleRing[T]) at(i int) idleItem[T] {
+	return r.items[(r.head+i)%len(r.items)]
+}
+
+// removeAt removes and returns the i-th item counted from the front,
+// shifting the items behind it forward.
+func (r *idleRing[T]) removeAt(i int) idleItem[T] {
+	it := r.at(i)
+	for j := i; j < r.n-1; j++ {
+		r.items[(r.head+j)%len(r.items)] = r.items[(r.head+j+1)%len(r.items)]
+	}
+	r.items[(r.head+r.n-1)%len(r.items)] = idleItem[T]{}
+	r.n--
+	return it
+}
+
+func (r *idleRing[T]) capacity() int { return len(r.items) }
+
+// resize moves the items into a ring of the given capacity, which must be at
+// least r.len().
+func (r *idleRing[T]) resize(capacity int) {
+	items := make([]idleItem[T], capacity)
+	for i := range r.n {
+		items[i] = r.at(i)
+	}
+	r.items = items
+	r.head = 0
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrInitCheck is wrapped by the error a Get returns, or panics with, when
+// every constructed item fails the WithInitCheck function.
+var ErrInitCheck = errors.New("pool: init check failed")
+
+// WithInitCheck validates newly constructed items, never recycled ones, for
+// constructors that can succeed yet return an unusable item, such as a
+// connection whose TLS handshake failed. An item fn rejects is discarded,
+// through the OnDiscard hook, and the constructor runs again, up to the
+// WithRetry attempts. If every attempt fails, TryGet and GetContext return
+// an error wrapping ErrInitCheck and fn's last error, and Get panics with
+// it. WithHealthCheck is the Put-time counterpart.
+func WithInitCheck[T any](fn func(T) error) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.initCheck = fn
+	}
+}
+
+// WithRetry sets how many times a Get may run the constructor before
+// giving up on WithInitCheck failures; the default is one attempt. It panics
+// if maxAttempts is less than 1.
+func WithRetry[T any](maxAttempts int) PoolOption[T] {
+	if maxAttempts < 1 {
+		panic("WithRetry: maxAttempts must be at least 1")
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.maxAttempts = maxAttempts
+	}
+}
+
+// constructChecked runs construct until an item passes the init check, or
+// the attempts run out.
+func (tp *TypedPool[T]) constructChecked(ctx context.Context, served int64, hint int) (T, error) {
+	item := tp.construct(ctx, served, hint)
+	if tp.cfg.initCheck == nil {
+		return item, nil
+	}
+	attempts := max(tp.cfg.maxAttempts, 1)
+	for attempt := 1; ; attempt++ {
+		err := tp.cfg.initCheck(item)
+		if err == nil {
+			return item, nil
+		}
+		tp.discard(item)
+		if attempt == attempts {
+			var zero T
+			return zero, fmt.Errorf("%w after %d attempts: %w", ErrInitCheck, attempts, err)
+		}
+		item = tp.construct(ctx, served, hint)
+	}
+}
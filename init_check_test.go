@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+var errHandshake = errors.New("handshake failed")
+
+func TestInitCheckRetriesConstruction(t *testing.T) {
+	built := 0
+	var discarded []int
+	pool := NewTypedPool(func() *int { built++; v := built; return &v },
+		WithFIFO[*int](),
+		WithInitCheck(func(v *int) error {
+			if *v < 3 {
+				return errHandshake
+			}
+			return nil
+		}),
+		WithRetry[*int](3),
+		WithOnDiscard(func(v *int) { discarded = append(discarded, *v) }),
+	)
+
+	v, err := pool.TryGet()
+	if err != nil || *v != 3 {
+		t.Fatalf("TryGet = %v, %v; want the third item", v, err)
+	}
+	if len(discarded) != 2 {
+		t.Fatalf("discarded %v, want the two failed items", discarded)
+	}
+
+	// Recycled items are not checked again.
+	*v = 0
+	pool.Put(v)
+	if got := pool.Get(); got != v {
+		t.Fatal("Get re-checked a recycled item")
+	}
+}
+
+func TestInitCheckGivesUp(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithInitCheck(func(*int) error { return errHandshake }),
+		WithRetry[*int](2),
+	)
+
+	_, err := pool.TryGet()
+	if !errors.Is(err, ErrInitCheck) || !errors.Is(err, errHandshake) {
+		t.Fatalf("TryGet = %v, want ErrInitCheck wrapping the check's error", err)
+	}
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrInitCheck) {
+			t.Fatalf("Get panicked with %v, want ErrInitCheck", err)
+		}
+	}()
+	pool.Get()
+}
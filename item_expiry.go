@@ -0,0 +1,25 @@
+package main
+
+import "time"
+
+// WithItemExpiry discards idle items whose ExpiresAt has passed, by the
+// pool's Clock, as Get comes to them, passing them through the OnDiscard
+// hook; Get moves on to the next idle item or the constructor. It suits
+// items that carry their own deadline, such as a token or a lease, with no
+// policy to configure. Items are checked on Get, and also in the background
+// under WithItemTTLSweeper, so an idle pool lets go of them too.
+func WithItemExpiry[T interface{ ExpiresAt() time.Time }]() PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.expiresAt = T.ExpiresAt
+	}
+}
+
+// checkExpiry drops v if its WithItemExpiry deadline has passed, and
+// reports whether Get may return it.
+func (tp *TypedPool[T]) checkExpiry(v T) bool {
+	if tp.cfg.expiresAt == nil || tp.cfg.now().Before(tp.cfg.expiresAt(v)) {
+		return true
+	}
+	tp.drop(v)
+	return false
+}
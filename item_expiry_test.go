@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ArditZubaku/go-sync-pool/pooltest"
+)
+
+type lease struct{ until time.Time }
+
+func (l *lease) ExpiresAt() time.Time { return l.until }
+
+func TestItemExpiry(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Unix(0, 0))
+	var discarded []*lease
+	pool := NewTypedPool(func() *lease { return &lease{until: clock.Now().Add(time.Minute)} },
+		WithItemExpiry[*lease](),
+		WithPoolClock[*lease](clock),
+		WithOnDiscard(func(l *lease) { discarded = append(discarded, l) }),
+		WithFIFO[*lease](),
+		WithStats[*lease](),
+	)
+
+	old := pool.Get()
+	clock.Advance(30 * time.Second)
+	young := pool.Get()
+	pool.Put(old)
+	pool.Put(young)
+
+	clock.Advance(30 * time.Second) // old expires now, young in 30s
+	if got := pool.Get(); got != young {
+		t.Fatal("Get did not skip the expired lease")
+	}
+	if len(discarded) != 1 || discarded[0] != old {
+		t.Fatalf("discarded %v, want the expired lease", discarded)
+	}
+	if s := pool.Stats(); s.Discards != 1 {
+		t.Fatalf("Discards = %d, want 1", s.Discards)
+	}
+}
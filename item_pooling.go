@@ -0,0 +1,28 @@
+package main
+
+// PoolAware is implemented by items that know which pool they came from, so
+// they can put themselves back without a separate pool reference:
+//
+//	func (c *Conn) Close() { c.Pool().Put(c) }
+type PoolAware[T any] interface {
+	Pool() *TypedPool[T]
+	SetPool(*TypedPool[T])
+}
+
+// WithItemPooling tells each item which pool handed it out: Get calls its
+// SetPool with the pool before returning it, so its Pool method can report
+// it. Items keep the reference while idle, and an item rescued from a
+// WithRecoveryPool or WithStackedPools level is bound to the pool that
+// served it, where its Put belongs.
+func WithItemPooling[T PoolAware[T]]() PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.setPool = T.SetPool
+	}
+}
+
+// bindPool hands v a reference to the pool under WithItemPooling.
+func (tp *TypedPool[T]) bindPool(v T) {
+	if tp.cfg.setPool != nil {
+		tp.cfg.setPool(v, tp)
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import "testing"
+
+type pooledConn struct {
+	pool *TypedPool[*pooledConn]
+}
+
+func (c *pooledConn) Pool() *TypedPool[*pooledConn]     { return c.pool }
+func (c *pooledConn) SetPool(p *TypedPool[*pooledConn]) { c.pool = p }
+func (c *pooledConn) Close()                            { c.Pool().Put(c) }
+
+func TestItemPooling(t *testing.T) {
+	spare := NewTypedPool(func() *pooledConn { return new(pooledConn) }, WithFIFO[*pooledConn]())
+	pool := NewTypedPool(func() *pooledConn { return new(pooledConn) },
+		WithItemPooling[*pooledConn](),
+		WithRecoveryPool(spare),
+		WithFIFO[*pooledConn](),
+	)
+
+	c := pool.Get()
+	if c.Pool() != pool {
+		t.Fatal("a new item does not know its pool")
+	}
+	c.Close()
+	if got := pool.Get(); got != c || got.Pool() != pool {
+		t.Fatal("the item did not put itself back")
+	}
+
+	r := new(pooledConn)
+	spare.Put(r)
+	if got := pool.Get(); got != r || got.Pool() != pool {
+		t.Fatal("a rescued item is not bound to the pool that served it")
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+// WithItemSizeCap discards slices Put with a capacity above maxCap, so a
+// buffer that append grew for one large payload does not stay pooled.
+// Together with WithObjectSize it keeps pooled capacities within
+// [minCap, maxCap].
+func WithItemSizeCap[T ~[]byte](maxCap int) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		byteCapWindow(cfg).max = maxCap
+	}
+}
+
+// WithObjectSize discards slices Put with a capacity below minCap, which
+// would have to grow again on their next use.
+func WithObjectSize[T ~[]byte](minCap int) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		byteCapWindow(cfg).min = minCap
+	}
+}
+
+// capWindow is the capacity range set by WithObjectSize and
+// WithItemSizeCap. A max of 0 leaves it open above.
+type capWindow[T any] struct {
+	min, max int
+	capOf    func(T) int
+}
+
+// byteCapWindow returns cfg's capacity window, adding one if need be.
+func byteCapWindow[T ~[]byte](cfg *poolConfig[T]) *capWindow[T] {
+	if cfg.capWindow == nil {
+		cfg.capWindow = &capWindow[T]{capOf: func(v T) int { return cap(v) }}
+	}
+	return cfg.capWindow
+}
+
+// fits reports whether v's capacity is within the window.
+func (w *capWindow[T]) fits(v T) bool {
+	if w == nil {
+		return true
+	}
+	c := w.capOf(v)
+	return c >= w.min && (w.max == 0 || c <= w.max)
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestItemSizeCapWindow(t *testing.T) {
+	var discarded []int
+	pool := NewTypedPool(func() []byte { return make([]byte, 0, 64) },
+		WithObjectSize[[]byte](32),
+		WithItemSizeCap[[]byte](256),
+		WithOnDiscard(func(b []byte) { discarded = append(discarded, cap(b)) }),
+		WithFIFO[[]byte](),
+	)
+
+	for _, c := range []int{16, 32, 256, 257, 4096} {
+		pool.Put(make([]byte, 0, c))
+	}
+
+	if want := []int{16, 257, 4096}; !slices.Equal(discarded, want) {
+		t.Fatalf("discarded capacities %v, want %v", discarded, want)
+	}
+	if got := pool.Len(); got != 2 {
+		t.Fatalf("Len = %d, want the 2 slices within the window", got)
+	}
+}
+
+func TestItemSizeCapAlone(t *testing.T) {
+	type payload []byte
+	pool := NewTypedPool(func() payload { return nil },
+		WithItemSizeCap[payload](8), WithFIFO[payload]())
+
+	pool.Put(make(payload, 0, 4))
+	pool.Put(make(payload, 0, 9))
+	if got := pool.Len(); got != 1 {
+		t.Fatalf("Len = %d, want 1", got)
+	}
+}
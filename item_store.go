@@ -0,0 +1,195 @@
+package main
+
+import (
+	"container/heap"
+	"sync"
+	"sync/atomic"
+)
+
+// ItemStore holds a TypedPool's idle items. Get removes one, reporting
+// false when there is none, and Len counts them. Implementations must be
+// safe for concurrent use. An item a store drops instead of keeping, as
+// sync.Pool does under GC, is simply forgotten: the pool's idle estimate
+// catches up on its next miss.
+type ItemStore[T any] interface {
+	Put(v T)
+	Get() (T, bool)
+	Len() int
+}
+
+// WithCustomStore makes the pool keep its idle items in store instead of a
+// sync.Pool: a ChannelStore, LIFOStore or PriorityStore from this package,
+// or a fake that lets tests of pool logic run without the GC in the way.
+// It cannot be combined with WithFIFO.
+func WithCustomStore[T any](store ItemStore[T]) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.store = store
+	}
+}
+
+// customStore adapts an ItemStore to the pool's idleStore.
+type customStore[T any] struct {
+	s ItemStore[T]
+}
+
+func (c customStore[T]) get() (T, bool) { return c.s.Get() }
+func (c customStore[T]) put(v T)        { c.s.Put(v) }
+
+// SyncPoolStore is the default store, a sync.Pool, as an ItemStore. Like
+// any sync.Pool it drops its contents over GC cycles, so Len is an
+// estimate that only catches up once Get finds it empty.
+type SyncPoolStore[T any] struct {
+	s *syncStore[T]
+	n atomic.Int64
+}
+
+// NewSyncPoolStore returns an empty SyncPoolStore.
+func NewSyncPoolStore[T any]() *SyncPoolStore[T] {
+	return &SyncPoolStore[T]{s: newSyncStore[T](nil)}
+}
+
+func (s *SyncPoolStore[T]) Put(v T) {
+	s.n.Add(1)
+	s.s.put(v)
+}
+
+func (s *SyncPoolStore[T]) Get() (T, bool) {
+	v, ok := s.s.get()
+	if !ok {
+		s.n.Store(0)
+		return v, false
+	}
+	s.n.Add(-1)
+	return v, true
+}
+
+func (s *SyncPoolStore[T]) Len() int { return int(max(s.n.Load(), 0)) }
+
+// ChannelStore keeps up to a fixed number of items in a buffered channel,
+// handing them out in FIFO order. Put drops the item when the channel is
+// full, without OnDiscard, so pair it with a WithMaxItems limit no higher
+// than its capacity.
+type ChannelStore[T any] struct {
+	ch chan T
+}
+
+// NewChannelStore returns an empty ChannelStore holding up to capacity
+// items.
+func NewChannelStore[T any](capacity int) *ChannelStore[T] {
+	return &ChannelStore[T]{ch: make(chan T, capacity)}
+}
+
+func (s *ChannelStore[T]) Put(v T) {
+	select {
+	case s.ch <- v:
+	default:
+	}
+}
+
+func (s *ChannelStore[T]) Get() (T, bool) {
+	select {
+	case v := <-s.ch:
+		return v, true
+	default:
+		var zero T
+		return zero, false
+	}
+}
+
+func (s *ChannelStore[T]) Len() int { return len(s.ch) }
+
+// LIFOStore is an unbounded stack: Get returns the most recently Put item,
+// which is the likeliest to still be in CPU caches.
+type LIFOStore[T any] struct {
+	mu    sync.Mutex
+	items []T
+}
+
+// NewLIFOStore returns an empty LIFOStore.
+func NewLIFOStore[T any]() *LIFOStore[T] {
+	return new(LIFOStore[T])
+}
+
+func (s *LIFOStore[T]) Put(v T) {
+	s.mu.Lock()
+	s.items = append(s.items, v)
+	s.mu.Unlock()
+}
+
+func (s *LIFOStore[T]) Get() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var zero T
+	n := len(s.items)
+	if n == 0 {
+		return zero, false
+	}
+	v := s.items[n-1]
+	s.items[n-1] = zero
+	s.items = s.items[:n-1]
+	return v, true
+}
+
+func (s *LIFOStore[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.items)
+}
+
+// PriorityStore is an unbounded heap: Get returns the item that sorts first
+// by less, such as the largest buffer or the most recently used connection.
+type PriorityStore[T any] struct {
+	mu sync.Mutex
+	h  priorityHeap[T]
+}
+
+// NewPriorityStore returns an empty PriorityStore ordered by less.
+func NewPriorityStore[T any](less func(a, b T) bool) *PriorityStore[T] {
+	return &PriorityStore[T]{h: priorityHeap[T]{less: less}}
+}
+
+func (s *PriorityStore[T]) Put(v T) {
+	s.mu.Lock()
+	heap.Push(&s.h, v)
+	s.mu.Unlock()
+}
+
+func (s *PriorityStore[T]) Get() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return heap.Pop(&s.h).(T), true
+}
+
+func (s *PriorityStore[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.h.items)
+}
+
+// priorityHeap implements heap.Interface for PriorityStore.
+type priorityHeap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+func (h *priorityHeap[T]) Len() int           { return len(h.items) }
+func (h *priorityHeap[T]) Less(i, j int) bool { return h.less(h.items[i], h.items[j]) }
+func (h *priorityHeap[T]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *priorityHeap[T]) Push(x any)         { h.items = append(h.items, x.(T)) }
+
+func (h *priorityHeap[T]) Pop() any {
+	n := len(h.items)
+	v := h.items[n-1]
+	var zero T
+	h.items[n-1] = zero
+	h.items = h.items[:n-1]
+	return v
+}
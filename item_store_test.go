@@ -0,0 +1,62 @@
+package main
+
+import "testing"
+
+func TestCustomStoreOrders(t *testing.T) {
+	// Each store is given 2, 1, 3 in that order.
+	for name, tc := range map[string]struct {
+		store ItemStore[int]
+		want  []int
+	}{
+		"lifo":     {NewLIFOStore[int](), []int{3, 1, 2}},
+		"channel":  {NewChannelStore[int](2), []int{2, 1}}, // 3 overflows
+		"priority": {NewPriorityStore(func(a, b int) bool { return a > b }), []int{3, 2, 1}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			pool := NewTypedPool(func() int { return 0 }, WithCustomStore(tc.store))
+			for _, v := range []int{2, 1, 3} {
+				pool.Put(v)
+			}
+			if n := tc.store.Len(); n != len(tc.want) {
+				t.Fatalf("Len = %d, want %d", n, len(tc.want))
+			}
+			for _, want := range tc.want {
+				if got := pool.Get(); got != want {
+					t.Fatalf("Get = %d, want %d", got, want)
+				}
+			}
+			if got := pool.Get(); got != 0 {
+				t.Fatalf("Get from the empty store = %d, want a new item", got)
+			}
+		})
+	}
+}
+
+func TestSyncPoolStore(t *testing.T) {
+	s := NewSyncPoolStore[[]byte]()
+	s.Put(make([]byte, 8))
+	if s.Len() != 1 {
+		t.Fatalf("Len = %d, want 1", s.Len())
+	}
+	// sync.Pool may drop the item, but never hands out another one.
+	if v, ok := s.Get(); ok && len(v) != 8 {
+		t.Fatalf("Get = %v, want the Put buffer", v)
+	}
+	for {
+		if _, ok := s.Get(); !ok {
+			break
+		}
+	}
+	if s.Len() != 0 {
+		t.Fatalf("Len = %d after a miss, want 0", s.Len())
+	}
+}
+
+func TestCustomStoreExcludesFIFO(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("NewTypedPool accepted WithCustomStore and WithFIFO")
+		}
+	}()
+	NewTypedPool(func() int { return 0 }, WithCustomStore[int](NewLIFOStore[int]()), WithFIFO[int]())
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// WithJitter delays the first run of the pool's background work by a random
+// duration in [0, maxJitter), so pools sharing an interval do not all fire at
+// once.
+func WithJitter[T any](maxJitter time.Duration) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.jitter = newJitterSource(maxJitter)
+	}
+}
+
+// jitterSource draws jitter from a per-pool random source.
+type jitterSource struct {
+	mu  sync.Mutex
+	rng *rand.Rand
+	max time.Duration
+}
+
+func newJitterSource(max time.Duration) *jitterSource {
+	return &jitterSource{
+		rng: rand.New(rand.NewSource(time.Now().UnixNano())),
+		max: max,
+	}
+}
+
+// next returns a duration in [0, max), or 0 for a nil source.
+func (j *jitterSource) next() time.Duration {
+	if j == nil || j.max <= 0 {
+		return 0
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return time.Duration(j.rng.Int63n(int64(j.max)))
+}
+
+// sleepJitter blocks for the pool's jitter before background work starts.
+func (cfg *poolConfig[T]) sleepJitter() {
+	if d := cfg.jitter.next(); d > 0 {
+		time.Sleep(d)
+	}
+}
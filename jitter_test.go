@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJitterSourceRange(t *testing.T) {
+	const max = 50 * time.Millisecond
+	j := newJitterSource(max)
+
+	distinct := make(map[time.Duration]bool)
+	for range 1000 {
+		d := j.next()
+		if d < 0 || d >= max {
+			t.Fatalf("jitter %v outside [0, %v)", d, max)
+		}
+		distinct[d] = true
+	}
+	if len(distinct) < 2 {
+		t.Fatal("jitter never varies")
+	}
+}
+
+func TestJitterDisabled(t *testing.T) {
+	var j *jitterSource
+	if d := j.next(); d != 0 {
+		t.Fatalf("nil source returned %v, want 0", d)
+	}
+	if d := newJitterSource(0).next(); d != 0 {
+		t.Fatalf("zero max returned %v, want 0", d)
+	}
+}
+
+func TestWarmupAsyncWithJitter(t *testing.T) {
+	pool := NewBoundedPool(2, func() int { return 1 }, WithJitter[int](time.Millisecond))
+	<-pool.WarmupAsync(2)
+
+	if got := pool.Len(); got != 2 {
+		t.Fatalf("Len() = %d, want 2", got)
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// WithJSON switches the Logger to one JSON object per line:
+//
+//	{"ts":"...","level":"info","msg":"...","key":value,...}
+//
+// Lines are appended straight into the pooled buffer; encoding/json is only
+// used for values of types the encoder does not know. The prefix and
+// separator are not used in this mode, and unless WithTimeLayout is given the
+// timestamp uses time.RFC3339Nano.
+func WithJSON() LoggerOption {
+	return func(l *Logger) {
+		l.json = true
+	}
+}
+
+// appendJSONString appends s as a quoted JSON string. Control characters are
+// escaped, and invalid UTF-8 is replaced with U+FFFD so the output is always
+// valid JSON.
+func appendJSONString(b *bytes.Buffer, s string) {
+	b.WriteByte('"')
+	appendEscaped(b, s, escapeJSON)
+	b.WriteByte('"')
+}
+
+// appendJSONFloat appends f as a JSON number, or as a string for NaN and
+// infinities, which JSON cannot represent.
+func appendJSONFloat(b *bytes.Buffer, f float64, bits int) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		b.WriteByte('"')
+		b.Write(strconv.AppendFloat(b.AvailableBuffer(), f, 'g', -1, bits))
+		b.WriteByte('"')
+		return
+	}
+	b.Write(strconv.AppendFloat(b.AvailableBuffer(), f, 'g', -1, bits))
+}
+
+// appendJSONValue appends v as a JSON value.
+func appendJSONValue(b *bytes.Buffer, v any) {
+	switch v := v.(type) {
+	case string:
+		appendJSONString(b, v)
+	case int:
+		b.Write(strconv.AppendInt(b.AvailableBuffer(), int64(v), 10))
+	case int64:
+		b.Write(strconv.AppendInt(b.AvailableBuffer(), v, 10))
+	case int32:
+		b.Write(strconv.AppendInt(b.AvailableBuffer(), int64(v), 10))
+	case uint:
+		b.Write(strconv.AppendUint(b.AvailableBuffer(), uint64(v), 10))
+	case uint64:
+		b.Write(strconv.AppendUint(b.AvailableBuffer(), v, 10))
+	case uint32:
+		b.Write(strconv.AppendUint(b.AvailableBuffer(), uint64(v), 10))
+	case float64:
+		appendJSONFloat(b, v, 64)
+	case float32:
+		appendJSONFloat(b, float64(v), 32)
+	case bool:
+		b.Write(strconv.AppendBool(b.AvailableBuffer(), v))
+	case time.Duration:
+		// Nanoseconds, as log/slog's JSONHandler does.
+		b.Write(strconv.AppendInt(b.AvailableBuffer(), int64(v), 10))
+	case time.Time:
+		b.WriteByte('"')
+		b.Write(v.AppendFormat(b.AvailableBuffer(), time.RFC3339Nano))
+		b.WriteByte('"')
+	case error:
+		appendJSONString(b, v.Error())
+	case nil:
+		b.WriteString("null")
+	default:
+		if enc, err := json.Marshal(v); err == nil {
+			b.Write(enc)
+			return
+		}
+		appendJSONString(b, fmt.Sprint(v))
+	}
+}
+
+// appendJSONKVs appends alternating key-value args as ,"key":value members,
+// with the same !BADKEY handling as the text format.
+func appendJSONKVs(b *bytes.Buffer, args []any) {
+	for len(args) > 0 {
+		var (
+			key string
+			val any
+		)
+		key, val, args = nextKV(args)
+
+		b.WriteByte(',')
+		appendJSONString(b, key)
+		b.WriteByte(':')
+		appendJSONValue(b, val)
+	}
+}
+
+// appendJSON renders one JSON line into b. msg is either a plain message
+// or, when format is true, a format string for args. fields holds members
+// already rendered, such as an Event's.
+func (l *Logger) appendJSON(b *bytes.Buffer, level Level, msg string, args []any, format bool, fields []byte, pc uintptr) {
+	b.WriteByte('{')
+	if l.layout != "" {
+		b.WriteString(`"ts":"`)
+		l.appendTime(b)
+		b.WriteString(`",`)
+	}
+	b.WriteString(`"level":"`)
+	b.WriteString(level.jsonName())
+	if pc != 0 {
+		b.WriteString(`","caller":"`)
+		appendCallerFrame(b, pc)
+	}
+	b.WriteString(`","msg":`)
+	if format {
+		scratch := buffPool.Get()
+		scratch.Reset()
+		fmt.Fprintf(scratch, msg, args...)
+		appendJSONString(b, bytesString(scratch.Bytes()))
+		buffPool.Put(scratch)
+		b.Write(l.static)
+	} else {
+		appendJSONString(b, msg)
+		b.Write(l.static)
+		appendJSONKVs(b, args)
+	}
+	b.Write(fields)
+	l.appendJSONSeq(b)
+	b.WriteString("}\n")
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ArditZubaku/go-sync-pool/pooltest"
+)
+
+func newJSONLogger(buf *bytes.Buffer) *Logger {
+	clock := pooltest.NewFakeClock(time.Date(2024, 3, 9, 14, 5, 7, 123000000, time.UTC))
+	return NewLogger(buf, WithJSON(), WithUTC(), WithClock(clock))
+}
+
+func TestJSONLoggerExactOutput(t *testing.T) {
+	var buf bytes.Buffer
+	newJSONLogger(&buf).Info("request done", "method", "GET", "status", 200, "dur", time.Millisecond)
+
+	want := `{"ts":"2024-03-09T14:05:07.123Z","level":"info","msg":"request done","method":"GET","status":200,"dur":1000000}` + "\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestJSONStringEscaping(t *testing.T) {
+	tests := []struct {
+		in      string
+		encoded string
+		decoded string
+	}{
+		{`plain`, `"plain"`, `plain`},
+		{`say "hi"`, `"say \"hi\""`, `say "hi"`},
+		{`back\slash`, `"back\\slash"`, `back\slash`},
+		{"line\nbreak\r\ttab", `"line\nbreak\r\ttab"`, "line\nbreak\r\ttab"},
+		{"nul\x00bell\x07", `"nul\u0000bell\u0007"`, "nul\x00bell\x07"},
+		{"unit\x1fsep", `"unit\u001fsep"`, "unit\x1fsep"},
+		{"héllo, 世界", `"héllo, 世界"`, "héllo, 世界"},
+		{"bad\xffutf8", `"bad\ufffdutf8"`, "bad\ufffdutf8"},
+		{"cut\xe4\xb8", `"cut\ufffd\ufffd"`, "cut\ufffd\ufffd"},
+		{"sep\u2028par\u2029", `"sep\u2028par\u2029"`, "sep\u2028par\u2029"},
+	}
+
+	for _, tt := range tests {
+		var b bytes.Buffer
+		appendJSONString(&b, tt.in)
+		if got := b.String(); got != tt.encoded {
+			t.Errorf("appendJSONString(%q) = %s, want %s", tt.in, got, tt.encoded)
+		}
+
+		var decoded string
+		if err := json.Unmarshal(b.Bytes(), &decoded); err != nil {
+			t.Errorf("appendJSONString(%q) produced invalid JSON %s: %v", tt.in, b.String(), err)
+		} else if decoded != tt.decoded {
+			t.Errorf("round trip of %q = %q, want %q", tt.in, decoded, tt.decoded)
+		}
+	}
+}
+
+func TestJSONValuesRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	newJSONLogger(&buf).Warn("values",
+		"int", -42,
+		"int64", int64(math.MaxInt64),
+		"uint64", uint64(math.MaxUint64),
+		"float", 0.1,
+		"tiny", 5e-324,
+		"huge", 1e300,
+		"f32", float32(3.14),
+		"nan", math.NaN(),
+		"inf", math.Inf(-1),
+		"bool", false,
+		"nil", nil,
+		"err", errors.New(`quote " inside`),
+		"struct", point{1, 2},
+		42,
+	)
+
+	var got map[string]json.RawMessage
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("line is not JSON: %v\n%s", err, buf.String())
+	}
+
+	want := map[string]string{
+		"level":   `"warn"`,
+		"int":     `-42`,
+		"int64":   `9223372036854775807`,
+		"uint64":  `18446744073709551615`,
+		"float":   `0.1`,
+		"tiny":    `5e-324`,
+		"huge":    `1e+300`,
+		"f32":     `3.14`,
+		"nan":     `"NaN"`,
+		"inf":     `"-Inf"`,
+		"bool":    `false`,
+		"nil":     `null`,
+		"err":     `"quote \" inside"`,
+		"struct":  `{"X":1,"Y":2}`,
+		"!BADKEY": `42`,
+	}
+	for key, w := range want {
+		if string(got[key]) != w {
+			t.Errorf("%s = %s, want %s", key, got[key], w)
+		}
+	}
+
+	var floats struct{ Float, Tiny, Huge float64 }
+	json.Unmarshal(buf.Bytes(), &floats)
+	if floats.Float != 0.1 || floats.Tiny != 5e-324 || floats.Huge != 1e300 {
+		t.Errorf("floats did not round-trip: %+v", floats)
+	}
+}
+
+func TestJSONLogf(t *testing.T) {
+	var buf bytes.Buffer
+	newJSONLogger(&buf).Logf("user %d said %q", 7, "hi")
+
+	var got struct{ Msg string }
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if want := `user 7 said "hi"`; got.Msg != want {
+		t.Fatalf("msg = %q, want %q", got.Msg, want)
+	}
+}
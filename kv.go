@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// badKey replaces keys that are missing or not strings, as log/slog does.
+const badKey = "!BADKEY"
+
+// appendKVs renders alternating key-value args as " key=value" pairs.
+// A non-string key, or a trailing key with no value, is logged as the value
+// of !BADKEY.
+func appendKVs(b *bytes.Buffer, args []any) {
+	for len(args) > 0 {
+		var (
+			key string
+			val any
+		)
+		key, val, args = nextKV(args)
+
+		b.WriteByte(' ')
+		appendText(b, key)
+		b.WriteByte('=')
+		appendValue(b, val)
+	}
+}
+
+// nextKV takes the next key-value pair from args and returns the rest.
+func nextKV(args []any) (key string, val any, rest []any) {
+	key, ok := args[0].(string)
+	switch {
+	case !ok:
+		return badKey, args[0], args[1:]
+	case len(args) == 1:
+		return badKey, args[0], nil
+	default:
+		return key, args[1], args[2:]
+	}
+}
+
+// appendValue appends v using strconv where possible and fmt otherwise.
+func appendValue(b *bytes.Buffer, v any) {
+	switch v := v.(type) {
+	case string:
+		appendText(b, v)
+	case int:
+		b.Write(strconv.AppendInt(b.AvailableBuffer(), int64(v), 10))
+	case int64:
+		b.Write(strconv.AppendInt(b.AvailableBuffer(), v, 10))
+	case int32:
+		b.Write(strconv.AppendInt(b.AvailableBuffer(), int64(v), 10))
+	case uint:
+		b.Write(strconv.AppendUint(b.AvailableBuffer(), uint64(v), 10))
+	case uint64:
+		b.Write(strconv.AppendUint(b.AvailableBuffer(), v, 10))
+	case uint32:
+		b.Write(strconv.AppendUint(b.AvailableBuffer(), uint64(v), 10))
+	case float64:
+		b.Write(strconv.AppendFloat(b.AvailableBuffer(), v, 'g', -1, 64))
+	case float32:
+		b.Write(strconv.AppendFloat(b.AvailableBuffer(), float64(v), 'g', -1, 32))
+	case bool:
+		b.Write(strconv.AppendBool(b.AvailableBuffer(), v))
+	case time.Duration:
+		b.WriteString(v.String())
+	case time.Time:
+		b.Write(v.AppendFormat(b.AvailableBuffer(), time.RFC3339Nano))
+	case error:
+		appendText(b, v.Error())
+	case nil:
+		b.WriteString("<nil>")
+	default:
+		appendText(b, fmt.Sprint(v))
+	}
+}
+
+// appendText appends s, quoted if it is empty or contains spaces, '=', quotes,
+// or anything unprintable.
+func appendText(b *bytes.Buffer, s string) {
+	if needsQuoting(s) {
+		b.Write(strconv.AppendQuote(b.AvailableBuffer(), s))
+		return
+	}
+	b.WriteString(s)
+}
+
+func needsQuoting(s string) bool {
+	if s == "" {
+		return true
+	}
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			if c <= ' ' || c == '=' || c == '"' || c == 0x7f {
+				return true
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError || !strconv.IsPrint(r) {
+			return true
+		}
+		i += size
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+type point struct{ X, Y int }
+
+func TestLoggerKeyValues(t *testing.T) {
+	tests := []struct {
+		name string
+		args []any
+		want string
+	}{
+		{"string", []any{"method", "GET"}, " method=GET"},
+		{"quoted string", []any{"path", "/a b", "q", "x=1", "empty", ""}, ` path="/a b" q="x=1" empty=""`},
+		{"ints", []any{"status", 200, "n", int64(-7), "u", uint(3)}, " status=200 n=-7 u=3"},
+		{"floats", []any{"ratio", 0.25, "f32", float32(1.5)}, " ratio=0.25 f32=1.5"},
+		{"bool", []any{"ok", true}, " ok=true"},
+		{"duration", []any{"dur", 1500 * time.Millisecond}, " dur=1.5s"},
+		{"time", []any{"at", time.Date(2024, 3, 9, 14, 5, 7, 0, time.UTC)}, " at=2024-03-09T14:05:07Z"},
+		{"error", []any{"err", errors.New("broken pipe")}, ` err="broken pipe"`},
+		{"nil", []any{"v", nil}, " v=<nil>"},
+		{"fallback", []any{"p", point{1, 2}}, ` p="{1 2}"`},
+		{"quoted key", []any{"a key", 1}, ` "a key"=1`},
+		{"non-string key", []any{42, "x", 1}, " !BADKEY=42 x=1"},
+		{"odd args", []any{"k", 1, "dangling"}, " k=1 !BADKEY=dangling"},
+		{"control chars", []any{"v", "a\nb"}, ` v="a\nb"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := NewLogger(&buf, WithTimeLayout(""))
+
+			logger.Info("done", tt.args...)
+			if got, want := buf.String(), "INFO : done"+tt.want+"\n"; got != want {
+				t.Fatalf("got %q, want %q", got, want)
+			}
+		})
+	}
+}
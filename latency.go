@@ -0,0 +1,87 @@
+package main
+
+import (
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+// WithMaxPutLatency calls onSlow, on the pool's Scheduler, whenever a Put
+// takes longer than d from entry to return, including any admission checks
+// and the OnDiscard hook. It points at Put-time work that has grown into a
+// bottleneck for the goroutines releasing items.
+func WithMaxPutLatency[T any](d time.Duration, onSlow func()) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.maxPutLatency = d
+		cfg.onSlowPut = onSlow
+	}
+}
+
+// checkPutLatency reports a Put that started at start and ran too long.
+func (tp *TypedPool[T]) checkPutLatency(start time.Time) {
+	if time.Since(start) > tp.cfg.maxPutLatency {
+		tp.cfg.schedule(tp.cfg.onSlowPut)
+	}
+}
+
+// latencyBuckets is the number of WithLatencyHistogram buckets, the last of
+// which counts the Gets beyond every bound.
+const latencyBuckets = 16
+
+// WithLatencyHistogram times every Get from entry to return and counts it
+// in the first bucket whose bound it does not exceed, or in a final
+// overflow bucket, reported as Stats().GetLatencyHistogram. It shows whether
+// Gets are consistently fast or have a long tail, such as from misses that
+// construct. It panics unless bounds holds 1 to 15 durations in increasing
+// order. ResetStats clears it.
+func WithLatencyHistogram[T any](bounds []time.Duration) PoolOption[T] {
+	if len(bounds) == 0 || len(bounds) >= latencyBuckets || !slices.IsSorted(bounds) {
+		panic("WithLatencyHistogram: bounds must hold 1 to 15 durations in increasing order")
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.latencyBounds = slices.Clone(bounds)
+	}
+}
+
+// latencyHistogram is the WithLatencyHistogram state. A nil
+// *latencyHistogram records nothing.
+type latencyHistogram struct {
+	bounds []time.Duration
+	counts [latencyBuckets]atomic.Uint64 // one per bound, then the overflow
+}
+
+func newLatencyHistogram(bounds []time.Duration) *latencyHistogram {
+	if bounds == nil {
+		return nil
+	}
+	return &latencyHistogram{bounds: bounds}
+}
+
+// observe counts a Get that started at start. Gets beyond the last bound
+// fall in the last bucket, however many bounds there are.
+func (h *latencyHistogram) observe(start time.Time) {
+	i, _ := slices.BinarySearch(h.bounds, time.Since(start))
+	if i == len(h.bounds) {
+		i = latencyBuckets - 1
+	}
+	h.counts[i].Add(1)
+}
+
+// snapshot returns the counts, all zero without WithLatencyHistogram.
+func (h *latencyHistogram) snapshot() [latencyBuckets]uint64 {
+	var out [latencyBuckets]uint64
+	if h != nil {
+		for i := range h.counts {
+			out[i] = h.counts[i].Load()
+		}
+	}
+	return out
+}
+
+func (h *latencyHistogram) reset() {
+	if h != nil {
+		for i := range h.counts {
+			h.counts[i].Store(0)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithMaxPutLatency(t *testing.T) {
+	slow := 0
+	inline := SchedulerFunc(func(fn func()) { fn() })
+	pool := NewTypedPool(func() int { return 0 },
+		WithScheduler[int](inline),
+		WithMaxPutLatency[int](5*time.Millisecond, func() { slow++ }),
+		WithMaxItems[int](1),
+		WithOnDiscard(func(int) { time.Sleep(10 * time.Millisecond) }),
+	)
+
+	pool.Put(1) // pooled quickly
+	if slow != 0 {
+		t.Fatalf("fast Put reported slow")
+	}
+	pool.Put(2) // discarded through the slow hook
+	if slow != 1 {
+		t.Fatalf("onSlow ran %d times, want 1", slow)
+	}
+}
+
+func TestLatencyHistogramBinsGets(t *testing.T) {
+	pool := NewTypedPool(func() *int { time.Sleep(30 * time.Millisecond); return new(int) },
+		WithFIFO[*int](),
+		WithLatencyHistogram[*int]([]time.Duration{15 * time.Millisecond, time.Hour}),
+	)
+
+	pool.Put(pool.Get()) // a slow miss
+	for range 3 {
+		pool.Put(pool.Get()) // fast hits
+	}
+
+	h := pool.Stats().GetLatencyHistogram
+	if h[0] != 3 || h[1] != 1 || h[latencyBuckets-1] != 0 {
+		t.Fatalf("histogram = %v, want 3 fast Gets, 1 slow and none beyond the bounds", h)
+	}
+	pool.ResetStats()
+	if h := pool.Stats().GetLatencyHistogram; h != ([latencyBuckets]uint64{}) {
+		t.Fatalf("histogram after ResetStats = %v, want zero", h)
+	}
+}
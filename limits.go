@@ -0,0 +1,65 @@
+package main
+
+// WithMaxItems caps how many items the pool holds; Puts beyond the cap are
+// discarded. The count is an estimate: items the GC clears from the
+// underlying sync.Pool are only forgotten on the first miss after the
+// collection that cleared them.
+func WithMaxItems[T any](n int) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.maxItems = n
+	}
+}
+
+// WithSoftMaxItems asks evictFn about every Put made while the pool holds n
+// or more items. The item is discarded if evictFn returns true and pooled
+// anyway otherwise, so hot items can be kept while cold ones overflow.
+func WithSoftMaxItems[T any](n int, evictFn func(T) bool) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.softMaxItems = n
+		cfg.softEvictFn = evictFn
+	}
+}
+
+// WithOnDiscard registers fn to be called with every item the pool refuses
+// to keep.
+func WithOnDiscard[T any](fn func(T)) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.onDiscard = fn
+	}
+}
+
+// WithSingletonGet turns recycling off while keeping the pool's other
+// behavior: every Get constructs a new item and every Put discards its item,
+// passing it to the OnDiscard hook. Code written against Pool[T] can switch
+// it on to measure what recycling buys.
+func WithSingletonGet[T any]() PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.singleton = true
+	}
+}
+
+// admit reports whether v may be pooled under the configured item limits.
+func (tp *TypedPool[T]) admit(v T) bool {
+	if !tp.acceptable(v) || tp.full() {
+		return false
+	}
+
+	n := int(tp.inPool.Load())
+	if tp.cfg.softMaxItems > 0 && n >= tp.cfg.softMaxItems {
+		return !tp.cfg.softEvictFn(v)
+	}
+
+	return true
+}
+
+// acceptable reports whether v may be pooled at all, however many items the
+// pool holds.
+func (tp *TypedPool[T]) acceptable(v T) bool {
+	return !tp.cfg.singleton && tp.cfg.capWindow.fits(v) && !tp.barred()
+}
+
+// full reports whether the pool holds its WithMaxItems limit.
+func (tp *TypedPool[T]) full() bool {
+	limit := tp.maxItems.Load()
+	return limit > 0 && tp.inPool.Load() >= limit
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWithMaxItemsDiscardsOverflow(t *testing.T) {
+	var discarded []int
+	pool := NewTypedPool(func() int { return 0 },
+		WithMaxItems[int](2),
+		WithOnDiscard(func(v int) { discarded = append(discarded, v) }),
+	)
+
+	for i := 1; i <= 3; i++ {
+		pool.Put(i)
+	}
+
+	if !slices.Equal(discarded, []int{3}) {
+		t.Fatalf("discarded %v, want [3]", discarded)
+	}
+}
+
+func TestWithSoftMaxItemsConsultsEvictFn(t *testing.T) {
+	var discarded []int
+	isCold := func(v int) bool { return v%2 == 1 }
+	pool := NewTypedPool(func() int { return 0 },
+		WithSoftMaxItems(1, isCold),
+		WithOnDiscard(func(v int) { discarded = append(discarded, v) }),
+	)
+
+	for i := 1; i <= 5; i++ {
+		pool.Put(i)
+	}
+
+	// 1 fits under the soft limit; 2 and 4 are hot and kept past it.
+	if !slices.Equal(discarded, []int{3, 5}) {
+		t.Fatalf("discarded %v, want [3 5]", discarded)
+	}
+}
+
+func TestBoundedPoolOnDiscard(t *testing.T) {
+	var discarded []int
+	pool := NewBoundedPool(1, func() int { return 0 },
+		WithOnDiscard(func(v int) { discarded = append(discarded, v) }),
+	)
+
+	pool.Put(1)
+	pool.Put(2)
+
+	if !slices.Equal(discarded, []int{2}) {
+		t.Fatalf("discarded %v, want [2]", discarded)
+	}
+}
+
+func TestWithSingletonGet(t *testing.T) {
+	var (
+		built     int
+		discarded []int
+	)
+	var pool Pool[int] = NewTypedPool(func() int { built++; return built },
+		WithSingletonGet[int](),
+		WithStats[int](),
+		WithOnDiscard(func(v int) { discarded = append(discarded, v) }),
+	)
+
+	for range 3 {
+		pool.Put(pool.Get())
+	}
+
+	if built != 3 || !slices.Equal(discarded, []int{1, 2, 3}) {
+		t.Fatalf("built %d, discarded %v; want 3 and [1 2 3]", built, discarded)
+	}
+	s := pool.(*TypedPool[int]).Stats()
+	if s.Hits != 0 || s.Misses != 3 || s.Discards != 3 || s.Puts != 3 {
+		t.Fatalf("stats %+v", s)
+	}
+}
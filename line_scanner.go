@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"io"
+)
+
+// maxJoinedKeep caps the scratch space a released LineScanner keeps for
+// joining long lines.
+const maxJoinedKeep = 4 * chunkSize
+
+// scannerPool recycles LineScanners.
+var scannerPool = NewTypedPool(func() *LineScanner {
+	return new(LineScanner)
+})
+
+// LineScanner reads lines like a bufio.Scanner with ScanLines, but from a
+// pooled 16 KiB read buffer and with no limit on line length: a line that
+// does not fit the buffer is collected in pooled ChunkedBuffer blocks
+// instead of failing with bufio.ErrTooLong. Lines end at "\n" or "\r\n",
+// neither of which is returned, and a final line without a newline is
+// returned as well. Get one with NewLineScanner and hand it back with
+// Release.
+type LineScanner struct {
+	r     io.Reader
+	buf   *[chunkSize]byte
+	start int // the unread bytes are buf[start:end]
+	end   int
+	eof   bool
+	err   error
+
+	line   []byte        // the current line, if it fits the buffer
+	long   ChunkedBuffer // the current line, if it did not
+	isLong bool
+	joined []byte // long, made contiguous by Bytes
+	joinOK bool   // joined holds the current line
+}
+
+// NewLineScanner returns a pooled LineScanner reading from r.
+func NewLineScanner(r io.Reader) *LineScanner {
+	s := scannerPool.Get()
+	s.r = r
+	s.buf = chunkPool.Get()
+	return s
+}
+
+// Scan advances to the next line, which Bytes then returns. It returns false
+// at the end of the input or on a read error, which Err reports.
+func (s *LineScanner) Scan() bool {
+	s.line = nil
+	if s.isLong {
+		s.long.Reset()
+		s.isLong, s.joinOK = false, false
+	}
+
+	for {
+		if i := bytes.IndexByte(s.buf[s.start:s.end], '\n'); i >= 0 {
+			s.take(s.buf[s.start : s.start+i])
+			s.start += i + 1
+			return true
+		}
+		if s.eof || s.err != nil {
+			if s.err == nil && (s.start < s.end || s.isLong) {
+				s.take(s.buf[s.start:s.end])
+				s.start = s.end
+				return true
+			}
+			return false
+		}
+		s.fill()
+	}
+}
+
+// take makes part, the end of the line, the current line.
+func (s *LineScanner) take(part []byte) {
+	if s.isLong {
+		s.long.Write(part)
+		return
+	}
+	if n := len(part); n > 0 && part[n-1] == '\r' {
+		part = part[:n-1]
+	}
+	s.line = part
+}
+
+// fill reads more input into the buffer, first making room by moving the
+// unread bytes to its front or, if the buffer holds nothing but part of a
+// line, by moving that part to the long-line blocks.
+func (s *LineScanner) fill() {
+	switch {
+	case s.start > 0:
+		s.end = copy(s.buf[:], s.buf[s.start:s.end])
+		s.start = 0
+	case s.end == len(s.buf):
+		s.long.Write(s.buf[:s.end])
+		s.isLong = true
+		s.start, s.end = 0, 0
+	}
+
+	n, err := s.r.Read(s.buf[s.end:])
+	s.end += n
+	if err == io.EOF {
+		s.eof = true
+	} else if err != nil {
+		s.err = err
+	}
+}
+
+// Bytes returns the current line without its line ending. The slice is only
+// valid until the next Scan or Release. Lines that fit the read buffer are
+// returned in place; longer ones are joined into scratch space the scanner
+// keeps, on the first call for the line.
+func (s *LineScanner) Bytes() []byte {
+	if !s.isLong {
+		return s.line
+	}
+	if !s.joinOK {
+		s.joined = s.long.AppendTo(s.joined[:0])
+		s.joined = bytes.TrimSuffix(s.joined, []byte{'\r'})
+		s.joinOK = true
+	}
+	return s.joined
+}
+
+// Err returns the first read error other than io.EOF.
+func (s *LineScanner) Err() error {
+	return s.err
+}
+
+// Release returns the scanner and its buffers to their pools. The scanner
+// and any slice returned by Bytes must not be used afterwards.
+func (s *LineScanner) Release() {
+	chunkPool.Put(s.buf)
+	s.long.Reset()
+	s.r, s.buf = nil, nil
+	s.start, s.end, s.eof, s.err = 0, 0, false, nil
+	s.line, s.isLong, s.joinOK = nil, false, false
+	s.joined = s.joined[:0]
+	if cap(s.joined) > maxJoinedKeep {
+		s.joined = nil
+	}
+	scannerPool.Put(s)
+}
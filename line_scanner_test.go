@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func scanAll(t *testing.T, r io.Reader) []string {
+	t.Helper()
+	s := NewLineScanner(r)
+	defer s.Release()
+
+	var lines []string
+	for s.Scan() {
+		lines = append(lines, string(s.Bytes()))
+	}
+	if err := s.Err(); err != nil {
+		t.Fatalf("Err = %v", err)
+	}
+	return lines
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLineScannerLineEndings(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{"\n", []string{""}},
+		{"a\nb\n", []string{"a", "b"}},
+		{"a\r\nb\r\n", []string{"a", "b"}},
+		{"a\nlast", []string{"a", "last"}},
+		{"a\r\nlast\r", []string{"a", "last"}},
+		{"\r\n\r\n", []string{"", ""}},
+		{"mid\rdle\n", []string{"mid\rdle"}},
+	} {
+		for name, r := range map[string]io.Reader{
+			"whole":   strings.NewReader(tc.in),
+			"onebyte": iotest.OneByteReader(strings.NewReader(tc.in)),
+		} {
+			if got := scanAll(t, r); !equalLines(got, tc.want) {
+				t.Errorf("%s %q: got %q, want %q", name, tc.in, got, tc.want)
+			}
+		}
+	}
+}
+
+func TestLineScannerLongLines(t *testing.T) {
+	long := strings.Repeat("x", 3*chunkSize+17)
+	exact := strings.Repeat("y", chunkSize)
+	in := "short\n" + long + "\r\n" + exact + "\n" + strings.Repeat("z", chunkSize-1) + "\r\n" + long
+	want := []string{"short", long, exact, strings.Repeat("z", chunkSize-1), long}
+
+	for name, r := range map[string]io.Reader{
+		"whole":    strings.NewReader(in),
+		"halfread": iotest.HalfReader(strings.NewReader(in)),
+	} {
+		got := scanAll(t, r)
+		if len(got) != len(want) {
+			t.Fatalf("%s: got %d lines, want %d", name, len(got), len(want))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s: line %d has length %d, want %d", name, i, len(got[i]), len(want[i]))
+			}
+		}
+	}
+}
+
+func TestLineScannerCRAcrossRefill(t *testing.T) {
+	// The \r of a long line's ending is the last byte of a full buffer.
+	line := strings.Repeat("a", chunkSize-1)
+	if got := scanAll(t, strings.NewReader(line+"\r\nnext")); !equalLines(got, []string{line, "next"}) {
+		t.Fatalf("got %d lines, want the line without its \\r and next", len(got))
+	}
+	line = strings.Repeat("b", 2*chunkSize-1)
+	if got := scanAll(t, strings.NewReader(line+"\r\n")); len(got) != 1 || got[0] != line {
+		t.Fatal("the \\r split from its \\n by a spill was kept")
+	}
+}
+
+func TestLineScannerReadError(t *testing.T) {
+	boom := errors.New("boom")
+	s := NewLineScanner(io.MultiReader(strings.NewReader("ok\npartial"), iotest.ErrReader(boom)))
+	defer s.Release()
+	if !s.Scan() || string(s.Bytes()) != "ok" {
+		t.Fatal("first line not scanned")
+	}
+	if s.Scan() {
+		t.Fatalf("Scan returned %q past a read error", s.Bytes())
+	}
+	if !errors.Is(s.Err(), boom) {
+		t.Fatalf("Err = %v, want boom", s.Err())
+	}
+}
+
+// go test -run '^$' -bench LineScanner -benchmem
+// Results (GOMAXPROCS=1), against a bufio.Scanner given a 1 MiB buffer up
+// front; the allocation is the bytes.Reader:
+//
+//	BenchmarkLineScanner/LineScanner     1000	 1172056 ns/op	3578.60 MB/s	 48 B/op	 1 allocs/op
+//	BenchmarkLineScanner/bufio.Scanner   1112	 1211821 ns/op	3461.17 MB/s	 48 B/op	 1 allocs/op
+func BenchmarkLineScanner(b *testing.B) {
+	var in bytes.Buffer
+	for i := 0; in.Len() < 4<<20; i++ {
+		in.WriteString(strings.Repeat("field ", 1+i%20))
+		in.WriteString("\n")
+	}
+	data := in.Bytes()
+
+	b.Run("LineScanner", func(b *testing.B) {
+		b.SetBytes(int64(len(data)))
+		for b.Loop() {
+			s := NewLineScanner(bytes.NewReader(data))
+			for s.Scan() {
+				_ = s.Bytes()
+			}
+			s.Release()
+		}
+	})
+	b.Run("bufio.Scanner", func(b *testing.B) {
+		b.SetBytes(int64(len(data)))
+		buf := make([]byte, 1<<20)
+		for b.Loop() {
+			s := bufio.NewScanner(bytes.NewReader(data))
+			s.Buffer(buf, len(buf))
+			for s.Scan() {
+				_ = s.Bytes()
+			}
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package main
+
+import "sync"
+
+// WithLocalCache keeps up to size items per goroutine, emulating
+// goroutine-local storage: Put parks the item with the calling goroutine
+// until it holds size, and that goroutine's Gets take them back, most
+// recent first, before touching the shared store. It is WithStickyPool
+// keyed by goroutine ID, with the same 1024-item cap across goroutines;
+// use one or the other. The ID is parsed from runtime.Stack, which
+// costs about a microsecond per Get and Put, so it only pays off where the
+// shared store is contended and goroutines live long enough to reuse what
+// they park. It panics if size is less than 1.
+func WithLocalCache[T any](size int) PoolOption[T] {
+	if size < 1 {
+		panic("WithLocalCache: size must be at least 1")
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.stickyID = goroutineID
+		cfg.localCache = size
+	}
+}
+
+// localSlot holds one owner's WithStickyPool item, or WithLocalCache items.
+type localSlot[T any] struct {
+	mu    sync.Mutex
+	items []T
+	gen   int64 // the collection count at the last park
+	dead  bool  // removed from the table; park must start a new slot
+}
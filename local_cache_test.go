@@ -0,0 +1,30 @@
+package main
+
+import "testing"
+
+func TestLocalCache(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) }, WithLocalCache[*int](2), WithFIFO[*int]())
+	a, b, c := new(int), new(int), new(int)
+	pool.Put(a)
+	pool.Put(b)
+	pool.Put(c) // the local cache is full
+	if got := pool.Len(); got != 1 {
+		t.Fatalf("Len() = %d, want only the overflow in the shared store", got)
+	}
+
+	other := make(chan *int)
+	go func() { other <- pool.Get() }()
+	if got := <-other; got != c {
+		t.Fatal("another goroutine did not get the shared item")
+	}
+
+	if got := pool.Get(); got != b {
+		t.Fatal("first local Get did not return the item parked last")
+	}
+	if got := pool.Get(); got != a {
+		t.Fatal("second local Get did not return the item parked first")
+	}
+	if got := pool.Get(); got == a || got == b || got == c {
+		t.Fatal("Get on an empty pool reused an item")
+	}
+}
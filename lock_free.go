@@ -0,0 +1,136 @@
+package main
+
+import (
+	"math/rand/v2"
+	"sync/atomic"
+)
+
+// WithKindaLockFree gives a FixedPool a lock-free fast path: idle objects
+// sit in an array of slots, one per object the pool was built with, which
+// Get and Put claim with compare-and-swap instead of taking the pool's
+// mutex. A CAS only moves a slot between empty, busy and full, and whoever
+// moves it to busy owns its object until moving it on, so a slot emptied
+// and refilled in between cannot be mistaken for the one first seen (no ABA
+// problem) and Put does not allocate. A caller that finds no
+// slot to claim falls back to the mutex: Get waits there while every object
+// is checked out, and objects from a growing Resize circulate through the
+// locked list. The fast path ignores the pool's Ordering. It only applies to
+// FixedPool.
+func WithKindaLockFree[T any]() PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.lockFree = true
+	}
+}
+
+// Slot states of lockFreeSlots.
+const (
+	slotEmpty int32 = iota
+	slotBusy        // claimed by a take or offer in progress
+	slotFull
+)
+
+// lockFreeSlot is one WithKindaLockFree slot. v belongs to whoever moved
+// state to slotBusy, and is otherwise only read while state is slotFull.
+type lockFreeSlot[T any] struct {
+	state atomic.Int32
+	v     T
+}
+
+// lockFreeSlots is the WithKindaLockFree slot array.
+type lockFreeSlots[T any] struct {
+	slots   []lockFreeSlot[T]
+	hole    atomic.Int64 // the slot take emptied last, where offer looks first
+	n       atomic.Int64 // objects in slots
+	waiters atomic.Int64 // Gets holding or waiting on the mutex
+	over    atomic.Bool  // size exceeds max after a shrinking Resize
+}
+
+func newLockFreeSlots[T any](size int) *lockFreeSlots[T] {
+	return &lockFreeSlots[T]{slots: make([]lockFreeSlot[T], size)}
+}
+
+// take claims an object from any slot. Scans start at a random slot so
+// concurrent callers spread out.
+func (s *lockFreeSlots[T]) take() (T, bool) {
+	if s != nil {
+		start := rand.IntN(len(s.slots))
+		for i := range s.slots {
+			slot := &s.slots[(start+i)%len(s.slots)]
+			if slot.state.Load() == slotFull && slot.state.CompareAndSwap(slotFull, slotBusy) {
+				v := slot.v
+				var zero T
+				slot.v = zero
+				slot.state.Store(slotEmpty)
+				s.hole.Store(int64((start + i) % len(s.slots)))
+				s.n.Add(-1)
+				return v, true
+			}
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// offer stores v in an empty slot and reports whether it found one.
+func (s *lockFreeSlots[T]) offer(v T) bool {
+	start := int(s.hole.Load())
+	for i := range s.slots {
+		slot := &s.slots[(start+i)%len(s.slots)]
+		if slot.state.Load() == slotEmpty && slot.state.CompareAndSwap(slotEmpty, slotBusy) {
+			slot.v = v
+			slot.state.Store(slotFull)
+			s.n.Add(1)
+			return true
+		}
+	}
+	return false
+}
+
+// len returns the number of objects in slots.
+func (s *lockFreeSlots[T]) len() int {
+	if s == nil {
+		return 0
+	}
+	return int(s.n.Load())
+}
+
+// wait records a Get entering (+1) or leaving (-1) the mutex path.
+func (s *lockFreeSlots[T]) wait(delta int64) {
+	if s != nil {
+		s.waiters.Add(delta)
+	}
+}
+
+// putFast pools v through the slots, waking a Get on the mutex path if
+// there is one, and reports whether it did.
+func (fp *FixedPool[T]) putFast(v T) bool {
+	s := fp.free
+	if s == nil || s.over.Load() || !s.offer(v) {
+		return false
+	}
+	// The Get increments waiters before it scans the slots, so either it
+	// finds v or this sees it waiting.
+	if s.waiters.Load() > 0 {
+		fp.mu.Lock()
+		fp.ready.Signal()
+		fp.mu.Unlock()
+	}
+	return true
+}
+
+// takeLocked takes an idle object from the locked list or, failing that,
+// the slots. fp.mu must be held.
+func (fp *FixedPool[T]) takeLocked() (T, bool) {
+	if fp.idle.len() > 0 {
+		return fp.popLocked(), true
+	}
+	return fp.free.take()
+}
+
+// markOver records whether a shrinking Resize still has objects to discard,
+// which must take the mutex path. fp.mu must be held.
+func (fp *FixedPool[T]) markOver() {
+	if fp.free != nil {
+		fp.free.over.Store(fp.size > fp.max)
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestKindaLockFreeBlocksUntilPut(t *testing.T) {
+	pool := NewFixedPool(1, func() *int { return new(int) }, WithKindaLockFree[*int](), WithStats[*int]())
+
+	v := pool.Get()
+	got := make(chan *int)
+	go func() { got <- pool.Get() }()
+	select {
+	case <-got:
+		t.Fatal("Get returned while every object was checked out")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	pool.Put(v)
+	if w := <-got; w != v {
+		t.Fatal("the waiting Get did not receive the object put back")
+	}
+	if s := pool.Stats(); s.Hits != 2 || s.Puts != 1 {
+		t.Fatalf("hits, puts = %d, %d; want 2, 1", s.Hits, s.Puts)
+	}
+}
+
+func TestKindaLockFreeConcurrent(t *testing.T) {
+	const size = 4
+	pool := NewFixedPool(size, func() *int { return new(int) }, WithKindaLockFree[*int]())
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		seen = make(map[*int]bool)
+	)
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 1000 {
+				v := pool.Get()
+				mu.Lock()
+				seen[v] = true
+				mu.Unlock()
+				pool.Put(v)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := pool.Len(); got != size {
+		t.Fatalf("Len() = %d after the run, want %d", got, size)
+	}
+	if len(seen) != size {
+		t.Fatalf("%d distinct objects circulated, want %d", len(seen), size)
+	}
+}
+
+func TestKindaLockFreeResize(t *testing.T) {
+	var discarded int
+	pool := NewFixedPool(4, func() *int { return new(int) },
+		WithKindaLockFree[*int](),
+		WithOnDiscard(func(*int) { discarded++ }),
+	)
+
+	a := pool.Get()
+	if err := pool.Resize(2); err != nil {
+		t.Fatal(err)
+	}
+	if got := pool.Len(); got != 1 || discarded != 2 {
+		t.Fatalf("Len() = %d with %d discarded after shrinking, want 1 and 2", got, discarded)
+	}
+	pool.Put(a)
+	if got := pool.Len(); got != 2 || discarded != 2 {
+		t.Fatalf("Len() = %d with %d discarded after the Put, want 2 and 2", got, discarded)
+	}
+
+	if err := pool.Resize(3); err != nil {
+		t.Fatal(err)
+	}
+	if got := pool.Len(); got != 3 {
+		t.Fatalf("Len() = %d after growing, want 3", got)
+	}
+}
+
+// BenchmarkFixedPool compares the mutex and WithKindaLockFree paths under
+// RunParallel. The difference is contention, so it only shows with -cpu set
+// to several cores; on one, both cost about the same.
+func BenchmarkFixedPool(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		opts []PoolOption[*int]
+	}{
+		{"Mutex", nil},
+		{"KindaLockFree", []PoolOption[*int]{WithKindaLockFree[*int]()}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			pool := NewFixedPool(64, func() *int { return new(int) }, bc.opts...)
+			b.SetParallelism(4) // low to medium concurrency: 4 goroutines per P
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					pool.Put(pool.Get())
+				}
+			})
+		})
+	}
+}
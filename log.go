@@ -2,27 +2,50 @@ package main
 
 import (
 	"bytes"
-	"fmt"
 	"io"
-	"time"
+	"os"
 )
 
-var buffPool = NewTypedPool(
-	func() *bytes.Buffer {
-		fmt.Println("New buffer is created")
-		return new(bytes.Buffer)
-	},
+// buffPool is the shared pool of line buffers.
+var buffPool = Global("log.buffers", newLogBuffer,
+	WithStats[*bytes.Buffer](),
+	WithSizeFunc(func(b *bytes.Buffer) int64 { return int64(b.Cap()) }),
 )
 
-func log(w io.Writer, val string) {
-	// var b bytes.Buffer
+func newLogBuffer() *bytes.Buffer {
+	return new(bytes.Buffer)
+}
+
+var defaultLogger = NewLogger(os.Stdout)
+
+// log writes a timestamped message to w using the default Logger's format,
+// without a level or trailing newline.
+func log(w io.Writer, val string) error {
+	return defaultLogger.print(w, val, defaultLogger.callerPC())
+}
+
+// print writes the line header and msg to w, retrying short writes. A
+// *bytes.Buffer w takes the line directly.
+func (l *Logger) print(w io.Writer, msg string, pc uintptr) error {
+	if b, ok := w.(*bytes.Buffer); ok {
+		l.appendPrint(b, msg, pc)
+		return nil
+	}
+
 	b := buffPool.Get()
 	b.Reset()
 
-	b.Write(time.Now().AppendFormat(b.AvailableBuffer(), "15:04:05"))
-	b.WriteString(" : ")
-	b.WriteString(val)
-	w.Write(b.Bytes())
+	l.appendPrint(b, msg, pc)
+	err := writeFull(w, b.Bytes())
+
+	releaseWritten(b)
+	return l.handleError(err)
+}
 
-	buffPool.Put(b)
+// appendPrint renders print's line into b.
+func (l *Logger) appendPrint(b *bytes.Buffer, msg string, pc uintptr) {
+	l.appendHeader(b)
+	l.appendCaller(b, pc)
+	l.appendMessage(b, msg)
+	l.appendSeq(b)
 }
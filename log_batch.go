@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"runtime"
+	"sync/atomic"
+)
+
+// WithBatchSize sets how many bytes a Batch collects before it flushes on
+// its own. The default is 32 KiB.
+func WithBatchSize(n int) LoggerOption {
+	return func(l *Logger) {
+		l.batchSize = n
+	}
+}
+
+// abandonedBatches counts Batches collected with lines never flushed.
+var abandonedBatches atomic.Int64
+
+// AbandonedBatches returns how many Batches were garbage collected with
+// lines still in them. Their lines are lost, but their buffers go back to
+// the pool. It only counts in builds with the debug tag, which watch every
+// Batch holding lines; otherwise it is always 0.
+func AbandonedBatches() int64 {
+	return abandonedBatches.Load()
+}
+
+// Batch renders lines into one pooled buffer and writes them with a single
+// Write on Flush, or once WithBatchSize bytes have built up. Lines keep the
+// order they were added in. A Batch is not safe for concurrent use, and
+// must be flushed when done with, or its lines are lost.
+//
+// All lines of a batch go to the Logger's main writer. A writer added
+// WithLevelWriter gets the batch only if every line in it reaches its level.
+type Batch struct {
+	l       *Logger
+	buf     *bytes.Buffer // nil while the batch is empty
+	min     Level         // the lowest level in buf
+	cleanup runtime.Cleanup
+}
+
+// Batch returns an empty Batch writing through l.
+func (l *Logger) Batch() *Batch {
+	return &Batch{l: l}
+}
+
+// Debug adds msg at LevelDebug, followed by args as key=value pairs.
+func (b *Batch) Debug(msg string, args ...any) error {
+	return b.add(LevelDebug, msg, args, false, b.l.callerPC())
+}
+
+// Info adds msg at LevelInfo, followed by args as key=value pairs.
+func (b *Batch) Info(msg string, args ...any) error {
+	return b.add(LevelInfo, msg, args, false, b.l.callerPC())
+}
+
+// Warn adds msg at LevelWarn, followed by args as key=value pairs.
+func (b *Batch) Warn(msg string, args ...any) error {
+	return b.add(LevelWarn, msg, args, false, b.l.callerPC())
+}
+
+// Error adds msg at LevelError, followed by args as key=value pairs.
+func (b *Batch) Error(msg string, args ...any) error {
+	return b.add(LevelError, msg, args, false, b.l.callerPC())
+}
+
+// Logf adds a message formatted like fmt.Printf at LevelInfo.
+func (b *Batch) Logf(format string, args ...any) error {
+	return b.add(LevelInfo, format, args, true, b.l.callerPC())
+}
+
+// Debugf adds a formatted message at LevelDebug.
+func (b *Batch) Debugf(format string, args ...any) error {
+	return b.add(LevelDebug, format, args, true, b.l.callerPC())
+}
+
+// Infof adds a formatted message at LevelInfo.
+func (b *Batch) Infof(format string, args ...any) error {
+	return b.add(LevelInfo, format, args, true, b.l.callerPC())
+}
+
+// Warnf adds a formatted message at LevelWarn.
+func (b *Batch) Warnf(format string, args ...any) error {
+	return b.add(LevelWarn, format, args, true, b.l.callerPC())
+}
+
+// Errorf adds a formatted message at LevelError.
+func (b *Batch) Errorf(format string, args ...any) error {
+	return b.add(LevelError, format, args, true, b.l.callerPC())
+}
+
+// Flush writes the collected lines with one Write and returns the buffer
+// to the pool. The Batch stays usable. A Flush with no lines does nothing.
+func (b *Batch) Flush() error {
+	if b.buf == nil {
+		return nil
+	}
+	if debugBuild {
+		b.cleanup.Stop()
+	}
+	buf := b.buf
+	b.buf = nil
+	return b.l.emit(b.min, buf)
+}
+
+// add renders one line into the batch, past the Logger's level and
+// filters, and flushes once the batch reaches its size.
+func (b *Batch) add(level Level, msg string, args []any, format bool, pc uintptr) error {
+	l := b.l
+	if !l.Enabled(level) {
+		return nil
+	}
+	if l.sampler != nil || l.dedup != nil {
+		kvs := args
+		if format {
+			kvs = nil
+		}
+		if !l.admit(level, msg, kvs) {
+			return nil
+		}
+	}
+
+	if b.buf == nil {
+		b.buf = buffPool.Get()
+		b.buf.Reset()
+		b.min = level
+		if debugBuild {
+			b.cleanup = runtime.AddCleanup(b, abandonBatch, b.buf)
+		}
+	}
+	b.min = min(b.min, level)
+	if format {
+		l.appendLinef(b.buf, level, msg, args, pc)
+	} else {
+		l.appendLine(b.buf, level, msg, args, pc)
+	}
+
+	limit := l.batchSize
+	if limit <= 0 {
+		limit = defaultFlushSize
+	}
+	if b.buf.Len() >= limit {
+		return b.Flush()
+	}
+	return nil
+}
+
+// abandonBatch recovers the buffer of a Batch collected before its Flush.
+func abandonBatch(buf *bytes.Buffer) {
+	abandonedBatches.Add(1)
+	buffPool.Put(buf)
+}
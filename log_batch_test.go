@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// writeLog records each Write it gets as one entry.
+type writeLog struct {
+	writes []string
+}
+
+func (w *writeLog) Write(p []byte) (int, error) {
+	w.writes = append(w.writes, string(p))
+	return len(p), nil
+}
+
+func TestBatchOrderAndSingleWrite(t *testing.T) {
+	var out writeLog
+	logger := NewLogger(&out, WithTimeLayout(""))
+
+	batch := logger.Batch()
+	batch.Info("first", "n", 1)
+	batch.Debug("dropped below the level")
+	batch.Warnf("second %d", 2)
+	batch.Error("third")
+	if len(out.writes) != 0 {
+		t.Fatalf("wrote %q before Flush", out.writes)
+	}
+
+	if err := batch.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	want := "INFO : first n=1\nWARN : second 2\nERROR : third\n"
+	if len(out.writes) != 1 || out.writes[0] != want {
+		t.Fatalf("writes = %q, want one write of %q", out.writes, want)
+	}
+
+	batch.Flush()
+	if len(out.writes) != 1 {
+		t.Fatalf("an empty Flush wrote %q", out.writes[1:])
+	}
+}
+
+func TestBatchSizeFlush(t *testing.T) {
+	var out writeLog
+	logger := NewLogger(&out, WithTimeLayout(""), WithBatchSize(40))
+
+	batch := logger.Batch()
+	batch.Info("line one")   // 16 bytes
+	batch.Info("line two")   // 32
+	batch.Info("line three") // 50, past the size
+	batch.Info("line four")
+	if len(out.writes) != 1 {
+		t.Fatalf("writes = %q, want the first three lines flushed", out.writes)
+	}
+	batch.Flush()
+
+	want := []string{
+		"INFO : line one\nINFO : line two\nINFO : line three\n",
+		"INFO : line four\n",
+	}
+	if strings.Join(out.writes, "|") != strings.Join(want, "|") {
+		t.Fatalf("writes = %q, want %q", out.writes, want)
+	}
+}
+
+func TestBatchRoutes(t *testing.T) {
+	var main, errs writeLog
+	logger := NewLogger(&main, WithTimeLayout(""), WithLevelWriter(LevelError, &errs))
+
+	batch := logger.Batch()
+	batch.Info("mixed")
+	batch.Error("failed")
+	batch.Flush()
+	batch.Error("only errors")
+	batch.Flush()
+
+	if len(main.writes) != 2 {
+		t.Fatalf("main writes = %q, want both batches", main.writes)
+	}
+	if len(errs.writes) != 1 || errs.writes[0] != "ERROR : only errors\n" {
+		t.Fatalf("level writer writes = %q, want only the all-error batch", errs.writes)
+	}
+}
+
+func TestBatchOneBufferPerBatch(t *testing.T) {
+	logger := NewLogger(&bytes.Buffer{}, WithTimeLayout(""))
+
+	before := buffPool.Stats().Gets
+	for range 10 {
+		batch := logger.Batch()
+		for range 20 {
+			batch.Info("request step", "ok", true)
+		}
+		batch.Flush()
+	}
+	if gets := buffPool.Stats().Gets - before; gets != 10 {
+		t.Fatalf("%d buffer Gets for 10 batches of 20 lines, want 10", gets)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Logf formats a message like fmt.Printf and logs it at LevelInfo.
+//
+// The message is formatted straight into the pooled buffer, but each argument
+// is boxed into an interface by the call itself, which usually allocates;
+// see the Logf benchmarks for the cost per argument count.
+func (l *Logger) Logf(format string, args ...any) error {
+	if !l.renders(LevelInfo) {
+		return nil
+	}
+	return l.writef(LevelInfo, format, args, l.callerPC())
+}
+
+// Debugf logs a formatted message at LevelDebug.
+func (l *Logger) Debugf(format string, args ...any) error {
+	if !l.renders(LevelDebug) {
+		return nil
+	}
+	return l.writef(LevelDebug, format, args, l.callerPC())
+}
+
+// Infof logs a formatted message at LevelInfo.
+func (l *Logger) Infof(format string, args ...any) error {
+	if !l.renders(LevelInfo) {
+		return nil
+	}
+	return l.writef(LevelInfo, format, args, l.callerPC())
+}
+
+// Warnf logs a formatted message at LevelWarn.
+func (l *Logger) Warnf(format string, args ...any) error {
+	if !l.renders(LevelWarn) {
+		return nil
+	}
+	return l.writef(LevelWarn, format, args, l.callerPC())
+}
+
+// Errorf logs a formatted message at LevelError.
+func (l *Logger) Errorf(format string, args ...any) error {
+	if !l.renders(LevelError) {
+		return nil
+	}
+	return l.writef(LevelError, format, args, l.callerPC())
+}
+
+func (l *Logger) writef(level Level, format string, args []any, pc uintptr) error {
+	if (l.sampler != nil || l.dedup != nil) && !l.admit(level, format, nil) {
+		return nil
+	}
+
+	b := l.lineBuffer()
+	l.appendLinef(b, level, format, args, pc)
+	return l.emit(level, b)
+}
+
+// appendLinef renders one formatted line into b in the Logger's format.
+func (l *Logger) appendLinef(b *bytes.Buffer, level Level, format string, args []any, pc uintptr) {
+	if l.json {
+		l.appendJSON(b, level, format, args, true, nil, pc)
+		return
+	}
+
+	l.appendHeader(b)
+	b.WriteString(level.String())
+	b.WriteString(l.separator)
+	l.appendCaller(b, pc)
+	if l.raw {
+		fmt.Fprintf(b, format, args...)
+	} else {
+		scratch := buffPool.Get()
+		scratch.Reset()
+		fmt.Fprintf(scratch, format, args...)
+		appendEscaped(b, bytesString(scratch.Bytes()), escapeText)
+		buffPool.Put(scratch)
+	}
+	b.Write(l.static)
+	l.appendSeq(b)
+	b.WriteByte('\n')
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultTimeLayout = "15:04:05"
+	defaultSeparator  = " : "
+)
+
+// Logger writes leveled log lines through pooled buffers. Lines below the
+// minimum level return before touching the pool or the clock, unless
+// WithRingBuffer keeps them.
+//
+// Each line is handed to the writer in full: short writes are retried, and
+// a write error is both returned and passed to the WithErrorHandler hook.
+// The slice passed to Write goes back to the pool when Write returns, so a
+// writer that needs the bytes later must copy them, as io.Writer requires;
+// WithAsync and FlushingWriter do. Builds with the debug tag poison released
+// buffers, which makes a writer that keeps the slice visible. A Logger whose
+// only output is a *bytes.Buffer renders lines straight into it instead.
+type Logger struct {
+	sinks     []*sink
+	routes    []route
+	level     *atomic.Int32
+	layout    string
+	prefix    string
+	separator string
+	utc       bool
+	clock     Clock
+	json      bool
+	layoutSet bool
+	async     *asyncConfig
+	onError   func(error)
+	caller    bool
+	stamp     *atomic.Pointer[cachedStamp]
+	seq       *atomic.Uint64
+	raw       bool
+	recent    *recentRing
+
+	sampler *sampler
+	dedup   *dedup
+
+	batchSize int
+	maxDump   int
+
+	staticKVs []any
+	static    []byte // staticKVs rendered in the Logger's format
+
+	precision     TimePrecision
+	fracAt        int // index in layout after the seconds, or -1
+	preciseLayout string
+	subSec        bool // the layout has fractional seconds of its own
+
+	direct *bytes.Buffer // the only output, when lines are rendered into it
+}
+
+// LoggerOption configures a Logger.
+type LoggerOption func(*Logger)
+
+// WithTimeLayout sets the time.Format layout of the timestamp. An empty
+// layout omits the timestamp and the separator after it.
+func WithTimeLayout(layout string) LoggerOption {
+	return func(l *Logger) {
+		l.layout = layout
+		l.layoutSet = true
+	}
+}
+
+// WithPrefix sets a string written verbatim at the start of every line.
+func WithPrefix(prefix string) LoggerOption {
+	return func(l *Logger) {
+		l.prefix = prefix
+	}
+}
+
+// WithSeparator sets the string written between the fields of a line.
+func WithSeparator(sep string) LoggerOption {
+	return func(l *Logger) {
+		l.separator = sep
+	}
+}
+
+// WithUTC formats timestamps in UTC instead of local time.
+func WithUTC() LoggerOption {
+	return func(l *Logger) {
+		l.utc = true
+	}
+}
+
+// NewLogger creates a Logger writing to w at LevelInfo.
+func NewLogger(w io.Writer, opts ...LoggerOption) *Logger {
+	l := &Logger{
+		layout:    defaultTimeLayout,
+		separator: defaultSeparator,
+		clock:     realClock{},
+		level:     new(atomic.Int32),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	if l.json && !l.layoutSet {
+		l.layout = time.RFC3339Nano
+	}
+	l.initPrecision()
+	l.static = l.renderStatic(l.staticKVs)
+	l.SetLevel(LevelInfo)
+	l.sinks = append(l.sinks, l.newSink(route{min: LevelDebug, w: w}))
+	for _, r := range l.routes {
+		l.sinks = append(l.sinks, l.newSink(r))
+	}
+	if b, ok := w.(*bytes.Buffer); ok && len(l.sinks) == 1 && l.async == nil && l.recent == nil {
+		l.direct = b
+	}
+	return l
+}
+
+// SetLevel changes the minimum level written. It is safe to call while other
+// goroutines are logging.
+func (l *Logger) SetLevel(level Level) {
+	l.level.Store(int32(level))
+}
+
+// Enabled reports whether lines at level are written.
+func (l *Logger) Enabled(level Level) bool {
+	return level >= Level(l.level.Load())
+}
+
+// renders reports whether a line at level is rendered at all: it is either
+// written or kept by WithRingBuffer.
+func (l *Logger) renders(level Level) bool {
+	return l.recent != nil || l.Enabled(level)
+}
+
+// Debug logs msg at LevelDebug, followed by args as key=value pairs.
+func (l *Logger) Debug(msg string, args ...any) error {
+	if !l.renders(LevelDebug) {
+		return nil
+	}
+	return l.write(LevelDebug, msg, args, l.callerPC())
+}
+
+// Info logs msg at LevelInfo, followed by args as key=value pairs.
+func (l *Logger) Info(msg string, args ...any) error {
+	if !l.renders(LevelInfo) {
+		return nil
+	}
+	return l.write(LevelInfo, msg, args, l.callerPC())
+}
+
+// Warn logs msg at LevelWarn, followed by args as key=value pairs.
+func (l *Logger) Warn(msg string, args ...any) error {
+	if !l.renders(LevelWarn) {
+		return nil
+	}
+	return l.write(LevelWarn, msg, args, l.callerPC())
+}
+
+// Error logs msg at LevelError, followed by args as key=value pairs.
+func (l *Logger) Error(msg string, args ...any) error {
+	if !l.renders(LevelError) {
+		return nil
+	}
+	return l.write(LevelError, msg, args, l.callerPC())
+}
+
+func (l *Logger) write(level Level, msg string, args []any, pc uintptr) error {
+	if (l.sampler != nil || l.dedup != nil) && !l.admit(level, msg, args) {
+		return nil
+	}
+	return l.writeLine(level, msg, args, pc)
+}
+
+// writeLine renders and emits one line, past the Logger's filters.
+func (l *Logger) writeLine(level Level, msg string, args []any, pc uintptr) error {
+	b := l.lineBuffer()
+	l.appendLine(b, level, msg, args, pc)
+	return l.emit(level, b)
+}
+
+// appendLine renders one line into b in the Logger's format.
+func (l *Logger) appendLine(b *bytes.Buffer, level Level, msg string, args []any, pc uintptr) {
+	if l.json {
+		l.appendJSON(b, level, msg, args, false, nil, pc)
+		return
+	}
+
+	l.appendHeader(b)
+	b.WriteString(level.String())
+	b.WriteString(l.separator)
+	l.appendCaller(b, pc)
+	l.appendMessage(b, msg)
+	b.Write(l.static)
+	appendKVs(b, args)
+	l.appendSeq(b)
+	b.WriteByte('\n')
+}
+
+// appendHeader writes the prefix and timestamp, each followed by whatever
+// comes next in the line.
+func (l *Logger) appendHeader(b *bytes.Buffer) {
+	b.WriteString(l.prefix)
+	if l.layout == "" {
+		return
+	}
+
+	l.appendTime(b)
+	b.WriteString(l.separator)
+}
+
+// timestamp returns the current time in the Logger's zone.
+func (l *Logger) timestamp() time.Time {
+	t := l.clock.Now()
+	if l.utc {
+		t = t.UTC()
+	}
+	return t
+}
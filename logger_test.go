@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/ArditZubaku/go-sync-pool/pooltest"
+)
+
+// timestamp matches the "15:04:05" layout at the start of a log line.
+var timestamp = regexp.MustCompile(`(?m)^\d\d:\d\d:\d\d`)
+
+func stripTime(s string) string {
+	return timestamp.ReplaceAllString(s, "TIME")
+}
+
+func TestLoggerLevels(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(&buf)
+	logger.SetLevel(LevelDebug)
+
+	tests := []struct {
+		log  func(string, ...any) error
+		msg  string
+		want string
+	}{
+		{logger.Debug, "d", "TIME : DEBUG : d\n"},
+		{logger.Info, "i", "TIME : INFO : i\n"},
+		{logger.Warn, "w", "TIME : WARN : w\n"},
+		{logger.Error, "e", "TIME : ERROR : e\n"},
+	}
+
+	for _, tt := range tests {
+		buf.Reset()
+		tt.log(tt.msg)
+		if got := stripTime(buf.String()); got != tt.want {
+			t.Errorf("got %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestLoggerSetLevelSuppresses(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(&buf)
+	logger.SetLevel(LevelWarn)
+
+	logger.Debug("d")
+	logger.Info("i")
+	logger.Warn("w")
+
+	if got, want := stripTime(buf.String()), "TIME : WARN : w\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestLoggerOptions(t *testing.T) {
+	at := time.Date(2024, 3, 9, 14, 5, 7, 123456789, time.FixedZone("CET", 3600))
+
+	tests := []struct {
+		name string
+		opts []LoggerOption
+		want string
+	}{
+		{"default", nil, "14:05:07 : INFO : msg\n"},
+		{"empty prefix", []LoggerOption{WithPrefix("")}, "14:05:07 : INFO : msg\n"},
+		{"prefix", []LoggerOption{WithPrefix("[api] ")}, "[api] 14:05:07 : INFO : msg\n"},
+		{"separator", []LoggerOption{WithSeparator(" | ")}, "14:05:07 | INFO | msg\n"},
+		{"utc", []LoggerOption{WithUTC()}, "13:05:07 : INFO : msg\n"},
+		{
+			"rfc3339nano",
+			[]LoggerOption{WithTimeLayout(time.RFC3339Nano), WithSeparator(" ")},
+			"2024-03-09T14:05:07.123456789+01:00 INFO msg\n",
+		},
+		{
+			"rfc3339nano utc",
+			[]LoggerOption{WithTimeLayout(time.RFC3339Nano), WithUTC()},
+			"2024-03-09T13:05:07.123456789Z : INFO : msg\n",
+		},
+		{"no timestamp", []LoggerOption{WithTimeLayout(""), WithPrefix("> ")}, "> INFO : msg\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			opts := append([]LoggerOption{WithClock(pooltest.NewFakeClock(at))}, tt.opts...)
+			logger := NewLogger(&buf, opts...)
+
+			logger.Info("msg")
+			if got := buf.String(); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogCompatibility(t *testing.T) {
+	var buf bytes.Buffer
+	log(&buf, "debug-string-1")
+
+	if got, want := stripTime(buf.String()), "TIME : debug-string-1"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestLoggerLogf(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(&buf)
+	logger.SetLevel(LevelDebug)
+
+	logger.Logf("user %d failed after %v", 42, 1500*time.Millisecond)
+	logger.Debugf("%s=%q", "key", "value")
+	logger.Errorf("no args")
+
+	want := "TIME : INFO : user 42 failed after 1.5s\n" +
+		"TIME : DEBUG : key=\"value\"\n" +
+		"TIME : ERROR : no args\n"
+	if got := stripTime(buf.String()); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestLoggerFakeClockExactOutput(t *testing.T) {
+	var buf bytes.Buffer
+	clock := pooltest.NewFakeClock(time.Date(2024, 3, 9, 23, 59, 58, 0, time.UTC))
+	logger := NewLogger(&buf, WithClock(clock), WithUTC(), WithCachedTime())
+
+	logger.Info("start", "n", 1)
+	clock.Advance(500 * time.Millisecond)
+	logger.Warn("same second")
+	clock.Advance(1500 * time.Millisecond)
+	logger.Errorf("rolled over to %s", "midnight")
+
+	want := "23:59:58 : INFO : start n=1\n" +
+		"23:59:58 : WARN : same second\n" +
+		"00:00:00 : ERROR : rolled over to midnight\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestLoggerRenderedBytes(t *testing.T) {
+	at := time.Date(2024, 3, 9, 23, 59, 58, 123456789, time.UTC)
+	tests := []struct {
+		name string
+		opts []LoggerOption
+		want string
+	}{
+		{"default", nil, "23:59:58 : INFO : hello k=v\n"},
+		{"cached", []LoggerOption{WithCachedTime()}, "23:59:58 : INFO : hello k=v\n"},
+		{"cached fraction layout",
+			[]LoggerOption{WithCachedTime(), WithTimeLayout("2006-01-02T15:04:05.000Z07:00")},
+			"2024-03-09T23:59:58.123Z : INFO : hello k=v\n"},
+		{"cached precision",
+			[]LoggerOption{WithCachedTime(), WithTimePrecision(PrecisionMicrosecond)},
+			"23:59:58.123456 : INFO : hello k=v\n"},
+		{"long names",
+			[]LoggerOption{WithTimeLayout("Monday, January 2 2006 15:04:05 MST")},
+			"Saturday, March 9 2024 23:59:58 UTC : INFO : hello k=v\n"},
+		{"prefix and separator",
+			[]LoggerOption{WithPrefix("[app] "), WithSeparator(" | ")},
+			"[app] 23:59:58 | INFO | hello k=v\n"},
+		{"json", []LoggerOption{WithJSON()},
+			`{"ts":"2024-03-09T23:59:58.123456789Z","level":"info","msg":"hello","k":"v"}` + "\n"},
+	}
+	for _, tt := range tests {
+		for _, direct := range []bool{true, false} {
+			t.Run(fmt.Sprintf("%s/direct=%t", tt.name, direct), func(t *testing.T) {
+				var buf bytes.Buffer
+				// A plain io.Writer gets pooled lines; the buffer itself
+				// has them rendered into it.
+				var w io.Writer = struct{ io.Writer }{&buf}
+				if direct {
+					w = &buf
+				}
+				opts := append([]LoggerOption{WithClock(pooltest.NewFakeClock(at)), WithUTC()}, tt.opts...)
+				logger := NewLogger(w, opts...)
+
+				// The second line reuses a pooled buffer the first one grew.
+				logger.Info("hello", "k", "v")
+				logger.Info("hello", "k", "v")
+				if got, want := buf.String(), tt.want+tt.want; got != want {
+					t.Fatalf("got:\n%q\nwant:\n%q", got, want)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import "sync"
+
+// WithConstructorMemo shares items between callers instead of handing each
+// its own: every Get asks keyFn for a key and, if the pool has already
+// built an item for that key, returns that same item without checking it
+// out. Otherwise it constructs one and remembers it. Put of a remembered
+// item does nothing, so callers may treat memoized and pooled items alike.
+// An empty key makes the Get an ordinary one. Memoized items are recognized
+// by address on Put, so the option suits pointer-like item types, and stay
+// until the pool is dropped.
+func WithConstructorMemo[T any](keyFn func() string) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.memoKey = keyFn
+	}
+}
+
+// memo is the WithConstructorMemo table.
+type memo struct {
+	byKey sync.Map // string -> T
+	ids   sync.Map // item identity -> struct{}
+}
+
+// memoGet returns the item memoized for the current key, building it on
+// first use. It reports false for an empty key.
+func (tp *TypedPool[T]) memoGet() (T, Origin, bool) {
+	key := tp.cfg.memoKey()
+	if key == "" {
+		var zero T
+		return zero, OriginNew, false
+	}
+	if v, ok := tp.memo.byKey.Load(key); ok {
+		tp.stats.hit()
+		return v.(T), OriginReused, true
+	}
+
+	tp.stats.miss()
+	built := tp.newFn()
+	v, loaded := tp.memo.byKey.LoadOrStore(key, built)
+	if loaded {
+		// Another Get built the item for key first.
+		tp.discard(built)
+	} else if id := itemIdentity(built); id != 0 {
+		tp.memo.ids.Store(id, struct{}{})
+	}
+	return v.(T), OriginNew, true
+}
+
+// memoized reports whether v is a WithConstructorMemo item.
+func (tp *TypedPool[T]) memoized(v T) bool {
+	if tp.memo == nil {
+		return false
+	}
+	id := itemIdentity(v)
+	if id == 0 {
+		return false
+	}
+	_, ok := tp.memo.ids.Load(id)
+	return ok
+}
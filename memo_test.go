@@ -0,0 +1,64 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestConstructorMemoSharesByKey(t *testing.T) {
+	key := "tenant-a"
+	news := 0
+	pool := NewTypedPool(func() *int { news++; n := news; return &n },
+		WithConstructorMemo[*int](func() string { return key }), WithStats[*int]())
+
+	a1, a2 := pool.Get(), pool.Get()
+	if a1 != a2 {
+		t.Fatal("two Gets for the same key returned different items")
+	}
+	key = "tenant-b"
+	if b := pool.Get(); b == a1 || *b != 2 {
+		t.Fatalf("Get for another key = %d, want a second item", *b)
+	}
+
+	pool.Put(a1)
+	pool.Put(a1)
+	if s := pool.Stats(); s.Puts != 0 || s.Hits != 1 || s.Misses != 2 {
+		t.Fatalf("stats = %+v, want 1 hit, 2 misses and no Puts", s)
+	}
+	if got := pool.Len(); got != 0 {
+		t.Fatalf("Len after Putting a memoized item = %d, want 0", got)
+	}
+
+	key = ""
+	plain := pool.Get()
+	if plain == a1 || *plain != 3 {
+		t.Fatal("an empty key did not make an ordinary Get")
+	}
+}
+
+func TestConstructorMemoConcurrentFirstUse(t *testing.T) {
+	var discarded sync.Map
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithConstructorMemo[*int](func() string { return "k" }),
+		WithOnDiscard(func(v *int) { discarded.Store(v, true) }))
+
+	items := make([]*int, 16)
+	var wg sync.WaitGroup
+	wg.Add(len(items))
+	for i := range items {
+		go func() {
+			defer wg.Done()
+			items[i] = pool.Get()
+		}()
+	}
+	wg.Wait()
+
+	for _, v := range items {
+		if v != items[0] {
+			t.Fatal("concurrent Gets for one key got different items")
+		}
+	}
+	if _, ok := discarded.Load(items[0]); ok {
+		t.Fatal("the shared item was discarded")
+	}
+}
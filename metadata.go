@@ -0,0 +1,114 @@
+package main
+
+import (
+	"maps"
+	"sync"
+	"sync/atomic"
+)
+
+// WithItemMetadata calls fn once for each item the pool constructs and keeps
+// the map it returns with the item for as long as the pool holds on to it,
+// for dashboards asking why a buffer was created. Metadata returns an
+// item's map and AllMetadata those of the idle items. Like WithOnReuse
+// counts the maps live in a side table keyed by address, so only
+// pointer-like item types carry metadata.
+func WithItemMetadata[T any](fn func(T) map[string]any) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.metadataFn = fn
+	}
+}
+
+// metadataEntry is one item's metadata.
+type metadataEntry struct {
+	idleGen
+	m  map[string]any
+	id uint64 // under WithMonotonicID
+}
+
+// itemMetadata is the side table of WithItemMetadata and WithMonotonicID.
+type itemMetadata struct {
+	m      sync.Map // uintptr -> *metadataEntry
+	lastID atomic.Uint64
+}
+
+// Metadata returns a copy of the map WithItemMetadata recorded when v was
+// constructed, or nil if the pool did not build v, has discarded it, or has
+// no metadata function.
+func (tp *TypedPool[T]) Metadata(v T) map[string]any {
+	if tp.metadata == nil {
+		return nil
+	}
+	e, ok := tp.metadata.m.Load(uintptr(itemIdentity(v)))
+	if !ok {
+		return nil
+	}
+	return maps.Clone(e.(*metadataEntry).m)
+}
+
+// AllMetadata returns copies of the metadata of the items idle in the pool,
+// in no particular order. Items the GC has cleared may still be included
+// until a miss a few collections later.
+func (tp *TypedPool[T]) AllMetadata() []map[string]any {
+	if tp.cfg.metadataFn == nil {
+		return nil
+	}
+	var all []map[string]any
+	tp.metadata.m.Range(func(_, value any) bool {
+		if e := value.(*metadataEntry); e.idle.Load() {
+			all = append(all, maps.Clone(e.m))
+		}
+		return true
+	})
+	return all
+}
+
+// recordMetadata stores the metadata and ID of a newly constructed item.
+func (tp *TypedPool[T]) recordMetadata(v T) {
+	if tp.metadata == nil {
+		return
+	}
+	addr := uintptr(itemIdentity(v))
+	if addr == 0 {
+		return
+	}
+	e := new(metadataEntry)
+	if tp.cfg.metadataFn != nil {
+		e.m = tp.cfg.metadataFn(v)
+	}
+	if tp.cfg.monotonicID {
+		e.id = tp.metadata.lastID.Add(1)
+	}
+	tp.metadata.m.Store(addr, e)
+}
+
+// markIdle notes whether v is idle in the pool or checked out.
+func (tp *TypedPool[T]) markIdle(v T, idle bool) {
+	if tp.metadata == nil {
+		return
+	}
+	if e, ok := tp.metadata.m.Load(uintptr(itemIdentity(v))); ok {
+		e.(*metadataEntry).setIdle(idle)
+	}
+}
+
+// forgetMetadata drops the metadata of a discarded item.
+func (tp *TypedPool[T]) forgetMetadata(v T) {
+	if tp.metadata != nil {
+		tp.metadata.m.Delete(uintptr(itemIdentity(v)))
+	}
+}
+
+// pruneMetadata drops the metadata of items idle since collection cutoff,
+// for pruneGone: they are gone, and the GC may hand their addresses to new
+// items. Items still idle keep theirs, and so their WithMonotonicID.
+func (tp *TypedPool[T]) pruneMetadata(cutoff int64) {
+	if tp.metadata == nil {
+		return
+	}
+	tp.metadata.m.Range(func(key, value any) bool {
+		if value.(*metadataEntry).gone(cutoff) {
+			tp.metadata.m.Delete(key)
+		}
+		return true
+	})
+}
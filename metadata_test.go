@@ -0,0 +1,61 @@
+package main
+
+import "testing"
+
+func TestItemMetadata(t *testing.T) {
+	built := 0
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithItemMetadata(func(v *int) map[string]any {
+			built++
+			return map[string]any{"n": built}
+		}),
+		WithFIFO[*int](),
+	)
+
+	a, b := pool.Get(), pool.Get()
+	if got := pool.Metadata(a)["n"]; got != 1 {
+		t.Fatalf("Metadata(a) n = %v, want 1", got)
+	}
+	if all := pool.AllMetadata(); len(all) != 0 {
+		t.Fatalf("AllMetadata with nothing idle = %v", all)
+	}
+
+	pool.Put(b)
+	all := pool.AllMetadata()
+	if len(all) != 1 || all[0]["n"] != 2 {
+		t.Fatalf("AllMetadata = %v, want b's", all)
+	}
+	all[0]["n"] = "changed"
+	if got := pool.Metadata(b)["n"]; got != 2 {
+		t.Fatalf("Metadata(b) n = %v after editing the copy, want 2", got)
+	}
+
+	// A reused item keeps the metadata it was built with.
+	if again := pool.Get(); again != b || pool.Metadata(again)["n"] != 2 {
+		t.Fatalf("re-Get metadata = %v, want n 2", pool.Metadata(again))
+	}
+	if built != 2 {
+		t.Fatalf("metadata built %d times, want 2", built)
+	}
+}
+
+func TestItemMetadataForgottenOnDiscard(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithItemMetadata(func(*int) map[string]any { return map[string]any{"k": "v"} }),
+		WithItemReuseLimit[*int](1),
+		WithFIFO[*int](),
+	)
+
+	v := pool.Get()
+	pool.Put(v)
+	if pool.Get() != v {
+		t.Fatal("Get did not reuse the pooled item")
+	}
+	pool.Put(v) // worn out, so dropped
+	if m := pool.Metadata(v); m != nil {
+		t.Fatalf("Metadata after discard = %v, want nil", m)
+	}
+	if m := NewTypedPool(func() *int { return new(int) }).Metadata(v); m != nil {
+		t.Fatalf("Metadata without WithItemMetadata = %v, want nil", m)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import "time"
+
+// WithMinIdle keeps at least n objects idle in a BoundedPool, capped at its
+// max. NewBoundedPool builds them before returning, and with
+// WithRefillInterval a background refiller tops the pool back up after
+// bursts of Gets, so the constructor runs off the request path. Objects
+// built this way count as Prefills in Stats, not as Misses. A TypedPool has
+// no refiller, so NewTypedPool panics if it is set.
+func WithMinIdle[T any](n int) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.minIdle = n
+	}
+}
+
+// WithRefillInterval runs the WithMinIdle refiller every d on the pool's
+// Scheduler and Clock, until Close. Without it the idle count is only
+// topped up at construction and by Refill. Like WithMinIdle, it is only for
+// a BoundedPool.
+func WithRefillInterval[T any](d time.Duration) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.refillInterval = d
+	}
+}
+
+// Refill constructs objects until the pool holds its WithMinIdle count, or
+// its max, and returns how many it built. It stops early if the pool turns
+// one of them away, such as for a group budget.
+func (bp *BoundedPool[T]) Refill() int {
+	built := 0
+	for {
+		bp.mu.Lock()
+		short := bp.idle.len() < bp.cfg.minIdle && !bp.idle.full()
+		bp.mu.Unlock()
+		if !short {
+			return built
+		}
+
+		bp.stats.prefill()
+		built++
+		if !bp.store(bp.newFn()) {
+			return built
+		}
+	}
+}
+
+// startRefiller runs Refill every WithRefillInterval.
+func (bp *BoundedPool[T]) startRefiller() {
+	bp.bg.run(bp.cfg.schedule, func(stop <-chan struct{}) {
+		for {
+			select {
+			case <-stop:
+				return
+			case <-after(bp.cfg.clock, bp.cfg.refillInterval):
+				bp.Refill()
+			}
+		}
+	})
+}
+
+// Close stops the WithMinIdle refiller and the WithItemTTLSweeper and waits
+// for them to return. The pool remains usable.
+func (bp *BoundedPool[T]) Close() {
+	bp.bg.close()
+}
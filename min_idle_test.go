@@ -0,0 +1,81 @@
+package main
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ArditZubaku/go-sync-pool/pooltest"
+)
+
+func TestMinIdleRefillsInBackground(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Unix(0, 0))
+	var built atomic.Int64
+	pool := NewBoundedPool(8, func() *int { built.Add(1); return new(int) },
+		WithMinIdle[*int](4),
+		WithRefillInterval[*int](time.Second),
+		WithPoolClock[*int](clock),
+		WithStats[*int](),
+	)
+	defer pool.Close()
+
+	if n := pool.Len(); n != 4 {
+		t.Fatalf("Len after NewBoundedPool = %d, want 4", n)
+	}
+	for range 3 {
+		pool.Get()
+	}
+	if n := pool.Len(); n != 1 {
+		t.Fatalf("Len after 3 Gets = %d, want 1", n)
+	}
+
+	for cycle := range 2 {
+		waitFor(t, func() bool { return clock.Waiters() == 1 })
+		clock.Advance(time.Second)
+		waitFor(t, func() bool { return pool.Len() == 4 })
+		if cycle == 0 {
+			for range 4 {
+				pool.Get()
+			}
+		}
+	}
+
+	s := pool.Stats()
+	if s.Misses != 0 || s.Hits != 7 {
+		t.Fatalf("hits, misses = %d, %d; want 7, 0", s.Hits, s.Misses)
+	}
+	if s.Prefills != 11 || built.Load() != 11 {
+		t.Fatalf("prefills = %d, constructor calls = %d; want 11, 11", s.Prefills, built.Load())
+	}
+}
+
+func TestMinIdleRefillCappedAtMax(t *testing.T) {
+	pool := NewBoundedPool(2, func() *int { return new(int) }, WithMinIdle[*int](5), WithStats[*int]())
+	if n := pool.Len(); n != 2 {
+		t.Fatalf("Len = %d, want the max of 2", n)
+	}
+	if got := pool.Refill(); got != 0 {
+		t.Fatalf("Refill on a full pool built %d, want 0", got)
+	}
+
+	pool.Get()
+	if got := pool.Refill(); got != 1 {
+		t.Fatalf("Refill built %d, want 1", got)
+	}
+	if s := pool.Stats(); s.Prefills != 3 || s.Puts != 0 {
+		t.Fatalf("prefills, puts = %d, %d; want 3, 0", s.Prefills, s.Puts)
+	}
+}
+
+func TestMinIdleRejectsTypedPool(t *testing.T) {
+	for _, opt := range []PoolOption[*int]{WithMinIdle[*int](2), WithRefillInterval[*int](time.Second)} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("NewTypedPool accepted a BoundedPool refill option")
+				}
+			}()
+			NewTypedPool(func() *int { return new(int) }, opt)
+		}()
+	}
+}
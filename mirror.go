@@ -0,0 +1,26 @@
+package main
+
+// WithMirror shadows every Put into other: each item Put is also cloned and
+// the copy Put into other, whose own options then decide what to keep. That
+// way a pool with a candidate configuration sees production traffic and can
+// be checked by Getting from it, without the main pool's callers noticing.
+// clone must return an item safe to use independently of the original; it
+// runs on the caller's goroutine on every Put.
+func WithMirror[T any](other *TypedPool[T], clone func(T) T) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.mirror = &mirror[T]{other: other, clone: clone}
+	}
+}
+
+// mirror is the WithMirror configuration.
+type mirror[T any] struct {
+	other *TypedPool[T]
+	clone func(T) T
+}
+
+// shadow Puts a clone of v into the mirror pool, if any.
+func (m *mirror[T]) shadow(v T) {
+	if m != nil {
+		m.other.Put(m.clone(v))
+	}
+}
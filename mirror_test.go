@@ -0,0 +1,30 @@
+package main
+
+import "testing"
+
+func TestMirrorShadowsPuts(t *testing.T) {
+	shadow := NewTypedPool(func() *[]int { return new([]int) },
+		WithStats[*[]int](), WithFIFO[*[]int]())
+	clone := func(s *[]int) *[]int {
+		c := append([]int(nil), *s...)
+		return &c
+	}
+	pool := NewTypedPool(func() *[]int { return new([]int) },
+		WithMirror(shadow, clone), WithFIFO[*[]int]())
+
+	item := pool.Get()
+	*item = append(*item, 1, 2, 3)
+	pool.Put(item)
+
+	copied := shadow.Get()
+	if copied == item || len(*copied) != 3 {
+		t.Fatalf("mirror got %v (same item: %t), want a copy of [1 2 3]", *copied, copied == item)
+	}
+	(*copied)[0] = 9
+	if got := pool.Get(); got != item || (*got)[0] != 1 {
+		t.Fatalf("main pool served %v, want the original item untouched", *got)
+	}
+	if s := shadow.Stats(); s.Puts != 1 {
+		t.Fatalf("mirror Puts = %d, want 1", s.Puts)
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+// WithMonotonicID numbers the items the pool constructs 1, 2, 3 and so on,
+// so traces can follow one item through its Gets, Puts and the spans in
+// between. IDOf returns an item's ID, which stays the same however often it
+// is recycled, and the pool's audit and event logs record it. Like
+// WithItemMetadata the IDs live in a side table keyed by address, so only
+// pointer-like item types are numbered.
+func WithMonotonicID[T any]() PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.monotonicID = true
+	}
+}
+
+// IDOf returns the WithMonotonicID number of v, or 0 if the pool did not
+// build v, has discarded it, or does not number its items.
+func (tp *TypedPool[T]) IDOf(v T) uint64 {
+	if !tp.cfg.monotonicID {
+		return 0
+	}
+	e, ok := tp.metadata.m.Load(uintptr(itemIdentity(v)))
+	if !ok {
+		return 0
+	}
+	return e.(*metadataEntry).id
+}
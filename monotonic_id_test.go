@@ -0,0 +1,49 @@
+package main
+
+import "testing"
+
+func TestMonotonicID(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithMonotonicID[*int](), WithEventLog[*int](8), WithFIFO[*int]())
+
+	a, b := pool.Get(), pool.Get()
+	if pool.IDOf(a) != 1 || pool.IDOf(b) != 2 {
+		t.Fatalf("IDs = %d, %d, want 1, 2", pool.IDOf(a), pool.IDOf(b))
+	}
+	pool.Put(a)
+	if again := pool.Get(); again != a || pool.IDOf(again) != 1 {
+		t.Fatalf("recycled item has ID %d, want 1", pool.IDOf(again))
+	}
+	if pool.IDOf(new(int)) != 0 {
+		t.Fatal("an item the pool did not build has an ID")
+	}
+	for _, ev := range pool.EventLog() {
+		if want := map[uint64]uint64{itemIdentity(a): 1, itemIdentity(b): 2}[ev.Item]; ev.ID != want {
+			t.Errorf("event %+v has ID %d, want %d", ev, ev.ID, want)
+		}
+	}
+
+	if plain := NewTypedPool(func() *int { return new(int) }); plain.IDOf(plain.Get()) != 0 {
+		t.Fatal("IDOf without WithMonotonicID is not 0")
+	}
+}
+
+func TestMonotonicIDSurvivesMisses(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) }, WithMonotonicID[*int]())
+
+	a := pool.Get()
+	pool.Put(a)
+	// A miss here says nothing about a, which may sit in another P's cache.
+	collect(t, 1)
+	pool.pruneGone()
+	if id := pool.IDOf(a); id != 1 {
+		t.Fatalf("idle item has ID %d after a miss, want 1", id)
+	}
+
+	// Idle for long enough, a must have been dropped by the sync.Pool.
+	collect(t, goneAfter)
+	pool.pruneGone()
+	if id := pool.IDOf(a); id != 0 {
+		t.Fatalf("collected item has ID %d, want it forgotten", id)
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import "sync"
+
+// WithNewOnce turns the pool into a lazy singleton: the first Get
+// constructs the item and every Get after it returns that same instance,
+// while Put does nothing. Code written against Pool[T] can so switch between
+// a shared instance and recycled ones with a single option. Stats count the
+// first Get as a miss and the rest as hits.
+func WithNewOnce[T any]() PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.newOnce = true
+	}
+}
+
+// singleton is the WithNewOnce instance.
+type singleton[T any] struct {
+	once sync.Once
+	v    T
+}
+
+// onceGet returns the WithNewOnce instance, constructing it on first use.
+func (tp *TypedPool[T]) onceGet() (T, Origin) {
+	origin := OriginReused
+	tp.once.once.Do(func() {
+		origin = OriginNew
+		tp.once.v = tp.newFn()
+	})
+	if origin == OriginNew {
+		tp.stats.miss()
+	} else {
+		tp.stats.hit()
+	}
+	return tp.once.v, origin
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestNewOnceSharesOneInstance(t *testing.T) {
+	built := 0
+	var pool Pool[*int] = NewTypedPool(func() *int { built++; return new(int) },
+		WithNewOnce[*int](),
+		WithStats[*int](),
+	)
+
+	var wg sync.WaitGroup
+	got := make([]*int, 8)
+	for i := range got {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got[i] = pool.Get()
+			pool.Put(got[i])
+		}()
+	}
+	wg.Wait()
+
+	for _, v := range got {
+		if v != got[0] {
+			t.Fatal("Get returned different instances")
+		}
+	}
+	tp := pool.(*TypedPool[*int])
+	if built != 1 || tp.Len() != 0 {
+		t.Fatalf("constructor calls = %d, idle = %d; want 1 and none", built, tp.Len())
+	}
+	if s := tp.Stats(); s.Misses != 1 || s.Hits != 7 || s.Puts != 0 {
+		t.Fatalf("Stats = %+v, want 1 miss, 7 hits and no puts", s)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import "sync/atomic"
+
+// WithObjectCount counts the pool's idle items in counter, which several
+// pools may share to cap their idle items together, such as the open
+// connections of a read and a write pool. Each item a Put pools adds 1 and
+// each item Get takes out subtracts 1; a Put that finds counter at max or
+// above discards its item. The caller creates counter and decides its
+// starting value. As with WithMaxItems, items the GC clears from a sync.Pool
+// are only subtracted once that pool misses after the collection.
+func WithObjectCount[T any](counter *atomic.Int64, max int64) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.objectCount = &objectCount{counter: counter, max: max}
+	}
+}
+
+// objectCount is one pool's share of a WithObjectCount counter.
+type objectCount struct {
+	counter *atomic.Int64
+	max     int64
+	mine    atomic.Int64 // what this pool has added and not yet taken back
+}
+
+// admitCount counts an item into the shared counter and reports whether it
+// was under the cap.
+func (tp *TypedPool[T]) admitCount() bool {
+	oc := tp.cfg.objectCount
+	if oc == nil {
+		return true
+	}
+	for {
+		n := oc.counter.Load()
+		if n >= oc.max {
+			return false
+		}
+		if oc.counter.CompareAndSwap(n, n+1) {
+			oc.mine.Add(1)
+			return true
+		}
+	}
+}
+
+// releaseCount takes an item that left the store, or never entered it, out
+// of the shared counter.
+func (tp *TypedPool[T]) releaseCount() {
+	if oc := tp.cfg.objectCount; oc != nil {
+		oc.mine.Add(-1)
+		oc.counter.Add(-1)
+	}
+}
+
+// restoreCount counts an item back in without checking the cap, as it
+// returns to the store it was just taken from.
+func (tp *TypedPool[T]) restoreCount() {
+	if oc := tp.cfg.objectCount; oc != nil {
+		oc.mine.Add(1)
+		oc.counter.Add(1)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestObjectCountSharedAcrossPools(t *testing.T) {
+	var open atomic.Int64
+	discarded := 0
+	newPool := func() *TypedPool[*int] {
+		return NewTypedPool(func() *int { return new(int) },
+			WithObjectCount[*int](&open, 2),
+			WithOnDiscard(func(*int) { discarded++ }),
+			WithFIFO[*int](),
+		)
+	}
+	reads, writes := newPool(), newPool()
+
+	r1, r2, w := reads.Get(), reads.Get(), writes.Get()
+	reads.Put(r1)
+	writes.Put(w)
+	reads.Put(r2) // the shared cap of 2 is reached
+	if n := open.Load(); n != 2 || discarded != 1 {
+		t.Fatalf("counter = %d with %d discarded, want 2 and 1", n, discarded)
+	}
+
+	if reads.Get() != r1 {
+		t.Fatal("Get did not return the pooled item")
+	}
+	if n := open.Load(); n != 1 {
+		t.Fatalf("counter = %d after a Get, want 1", n)
+	}
+	if reads.Get(); open.Load() != 1 {
+		t.Fatalf("counter = %d after a miss, want the other pool's item only", open.Load())
+	}
+}
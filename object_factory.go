@@ -0,0 +1,19 @@
+package main
+
+// ObjectFactory builds and tears down a pool's items, in the shape the
+// factory providers of DI containers such as Wire, Fx and Dig tend to take.
+type ObjectFactory[T any] interface {
+	Create() T
+	Destroy(T)
+}
+
+// WithObjectFactory builds the pool's items with f.Create, replacing the
+// constructor given to NewTypedPool, which may then be nil, and passes every
+// item the pool refuses to keep to f.Destroy, after any OnDiscard hook.
+// Items the GC clears from the pool are never destroyed.
+func WithObjectFactory[T any](f ObjectFactory[T]) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.newFn = f.Create
+		cfg.factory = f
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import "testing"
+
+type connFactory struct {
+	created   int
+	destroyed []int
+}
+
+func (f *connFactory) Create() *int {
+	f.created++
+	id := f.created
+	return &id
+}
+
+func (f *connFactory) Destroy(c *int) {
+	f.destroyed = append(f.destroyed, *c)
+}
+
+func TestObjectFactory(t *testing.T) {
+	f := new(connFactory)
+	var hooked int
+	pool := NewTypedPool(nil,
+		WithObjectFactory[*int](f),
+		WithMaxItems[*int](1),
+		WithOnDiscard(func(*int) { hooked++ }),
+		WithFIFO[*int](),
+	)
+
+	a, b := pool.Get(), pool.Get()
+	if *a != 1 || *b != 2 || f.created != 2 {
+		t.Fatalf("Get built %d and %d in %d Creates, want 1 and 2 in 2", *a, *b, f.created)
+	}
+
+	pool.Put(a)
+	pool.Put(b)
+	if len(f.destroyed) != 1 || f.destroyed[0] != 2 || hooked != 1 {
+		t.Fatalf("destroyed = %v with %d OnDiscard calls, want [2] with 1", f.destroyed, hooked)
+	}
+	if got := pool.Get(); got != a {
+		t.Fatal("the pooled item was not reused")
+	}
+}
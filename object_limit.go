@@ -0,0 +1,80 @@
+package main
+
+import "sync/atomic"
+
+// WithObjectLimit caps the total weight of pooled items, where sizeOf gives
+// each item's weight in whatever unit fits, such as queries served by a
+// connection. Unlike WithMaxItems it budgets weight rather than count, and
+// unlike WithSizeFunc the weight need not be memory.
+//
+// When a Put would exceed max, BoundedPool evicts the heaviest items, which
+// may be the one being Put. TypedPool cannot pick items out of its sync.Pool,
+// so it discards the incoming item instead.
+func WithObjectLimit[T any](max int64, sizeOf func(T) int64) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.objectLimit = &objectLimit[T]{max: max, sizeOf: sizeOf}
+	}
+}
+
+// objectLimit tracks the weight of a pool's idle items.
+type objectLimit[T any] struct {
+	max    int64
+	sizeOf func(T) int64
+	total  atomic.Int64
+}
+
+// weightOf returns v's weight, or 0 when no limit is configured.
+func (lim *objectLimit[T]) weightOf(v T) int64 {
+	if lim == nil {
+		return 0
+	}
+	return lim.sizeOf(v)
+}
+
+// admitWeight reserves w for a TypedPool Put and reports whether it fits.
+func (tp *TypedPool[T]) admitWeight(w int64) bool {
+	lim := tp.cfg.objectLimit
+	if lim == nil {
+		return true
+	}
+
+	for {
+		total := lim.total.Load()
+		if total+w > lim.max {
+			return false
+		}
+		if lim.total.CompareAndSwap(total, total+w) {
+			return true
+		}
+	}
+}
+
+// releaseWeight gives back the weight of an item leaving a TypedPool.
+func (tp *TypedPool[T]) releaseWeight(v T) {
+	if lim := tp.cfg.objectLimit; lim != nil {
+		lim.total.Add(-lim.sizeOf(v))
+	}
+}
+
+// makeWeightRoom evicts the heaviest idle items until w fits under the limit.
+// It reports false, evicting nothing further, once the incoming item is at
+// least as heavy as everything left. bp.mu must be held.
+func (bp *BoundedPool[T]) makeWeightRoom(w int64) (freed int64, ok bool) {
+	lim := bp.cfg.objectLimit
+	for lim.total.Load()+w > lim.max {
+		heaviest := -1
+		for i := range bp.idle.len() {
+			if heaviest < 0 || bp.idle.at(i).weight > bp.idle.at(heaviest).weight {
+				heaviest = i
+			}
+		}
+		if heaviest < 0 || bp.idle.at(heaviest).weight <= w {
+			return freed, false
+		}
+
+		it := bp.idle.removeAt(heaviest)
+		bp.discard(it.v)
+		freed += bp.forget(it)
+	}
+	return freed, true
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+type conn struct {
+	id      int
+	queries int64
+}
+
+func queries(c *conn) int64 { return c.queries }
+
+func TestBoundedPoolObjectLimitEvictsHeaviest(t *testing.T) {
+	var discarded []int
+	pool := NewBoundedPool(8, func() *conn { return &conn{} },
+		WithObjectLimit(10, queries),
+		WithOnDiscard(func(c *conn) { discarded = append(discarded, c.id) }),
+		WithOrdering[*conn](FIFO),
+	)
+
+	pool.Put(&conn{id: 1, queries: 3})
+	pool.Put(&conn{id: 2, queries: 6})
+	pool.Put(&conn{id: 3, queries: 4}) // evicts 2, the heaviest
+	pool.Put(&conn{id: 4, queries: 5}) // heavier than everything idle: dropped
+
+	if !slices.Equal(discarded, []int{2, 4}) {
+		t.Fatalf("discarded %v, want [2 4]", discarded)
+	}
+	if got := pool.Get().id; got != 1 {
+		t.Fatalf("first idle conn = %d, want 1", got)
+	}
+	if got := pool.Get().id; got != 3 {
+		t.Fatalf("second idle conn = %d, want 3", got)
+	}
+}
+
+func TestTypedPoolObjectLimitDiscardsIncoming(t *testing.T) {
+	var discarded []int
+	pool := NewTypedPool(func() *conn { return &conn{} },
+		WithObjectLimit(10, queries),
+		WithOnDiscard(func(c *conn) { discarded = append(discarded, c.id) }),
+	)
+
+	pool.Put(&conn{id: 1, queries: 6})
+	pool.Put(&conn{id: 2, queries: 6})
+	pool.Put(&conn{id: 3, queries: 4})
+
+	if !slices.Equal(discarded, []int{2}) {
+		t.Fatalf("discarded %v, want [2]", discarded)
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+// WithObjectRecycle turns the pool into a source of copies: Get clones the
+// item it would have returned with cloneFn and puts the original straight
+// back, so the caller owns its copy outright and never calls Put. It suits
+// read-mostly templates, such as a parsed configuration or a prepared
+// request, that many goroutines need their own view of: the cost of
+// copying is paid at Get, and the originals stay pooled for the next one.
+// The original goes back through the pool's Put path, WithReset included,
+// after it has been copied, and the origin GetInfo reports is the
+// original's. Putting a copy would pool it alongside the originals and,
+// under WithResourceGuard, release the guard twice.
+func WithObjectRecycle[T any](cloneFn func(T) T) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.recycle = cloneFn
+	}
+}
+
+// recycled returns a WithObjectRecycle copy of v, which goes back in the
+// pool.
+func (tp *TypedPool[T]) recycled(v T) T {
+	c := tp.cfg.recycle(v)
+	tp.Put(v)
+	return c
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestObjectRecycle(t *testing.T) {
+	type config struct{ values map[string]string }
+	news := 0
+	pool := NewTypedPool(func() *config {
+		news++
+		return &config{values: map[string]string{"region": "eu"}}
+	},
+		WithObjectRecycle(func(c *config) *config { return &config{values: maps.Clone(c.values)} }),
+		WithFIFO[*config](),
+		WithStats[*config](),
+	)
+
+	a := pool.Get()
+	a.values["region"] = "us"
+	b := pool.Get()
+	if a == b {
+		t.Fatal("two Gets returned the same copy")
+	}
+	if b.values["region"] != "eu" {
+		t.Fatalf("copy = %v, want the original untouched by the first caller", b.values)
+	}
+	if news != 1 || pool.Len() != 1 {
+		t.Fatalf("constructor calls = %d, Len = %d; want the original built once and pooled", news, pool.Len())
+	}
+	if s := pool.Stats(); s.Hits != 1 || s.Misses != 1 || s.Puts != 2 {
+		t.Fatalf("hits, misses, puts = %d, %d, %d; want 1, 1, 2", s.Hits, s.Misses, s.Puts)
+	}
+}
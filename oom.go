@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// WithOOMHandler calls fn synchronously whenever the constructor fails to
+// allocate: it returns nil, or it panics with bytes.ErrTooLarge, as a
+// bytes.Buffer that cannot grow does, or with the runtime error of a make or
+// append whose size is out of range. Other panics, a nil dereference or an
+// index out of range say, are re-raised without calling fn. fn might shed load, free a cache or start a graceful shutdown. A
+// panic is re-raised once fn returns, and a nil item is still handed to the
+// caller. The runtime's own out-of-memory failure is fatal and cannot be
+// intercepted, so this is a last-ditch signal, not a guarantee.
+func WithOOMHandler[T any](fn func()) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.onOOM = fn
+	}
+}
+
+// oomGuardedNew wraps newFn so allocation failures reach onOOM.
+func oomGuardedNew[T any](newFn func() T, onOOM func()) func() T {
+	return func() T {
+		defer func() {
+			if r := recover(); r != nil {
+				if allocFailure(r) {
+					onOOM()
+				}
+				panic(r)
+			}
+		}()
+
+		v := newFn()
+		if isNilItem(v) {
+			onOOM()
+		}
+		return v
+	}
+}
+
+// allocPanics are the prefixes of the runtime errors raised by a make or
+// append whose size is out of range.
+var allocPanics = []string{"makeslice: ", "makemap: ", "makechan: ", "growslice: "}
+
+// allocFailure reports whether the panic value r is a failed allocation.
+func allocFailure(r any) bool {
+	err, ok := r.(error)
+	if !ok {
+		return false
+	}
+	if errors.Is(err, bytes.ErrTooLarge) {
+		return true
+	}
+	var rerr runtime.Error
+	if !errors.As(err, &rerr) {
+		return false
+	}
+	msg := strings.TrimPrefix(rerr.Error(), "runtime error: ")
+	for _, prefix := range allocPanics {
+		if strings.HasPrefix(msg, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNilItem reports whether v is a nil pointer, map, chan, func, slice or
+// interface.
+func isNilItem[T any](v T) bool {
+	x := any(v)
+	if x == nil {
+		return true
+	}
+	rv := reflect.ValueOf(x)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Chan, reflect.Func, reflect.Slice, reflect.UnsafePointer:
+		return rv.IsNil()
+	default:
+		return false
+	}
+}
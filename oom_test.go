@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestOOMHandlerNilItem(t *testing.T) {
+	var calls int
+	pool := NewTypedPool(func() *bytes.Buffer { return nil },
+		WithOOMHandler[*bytes.Buffer](func() { calls++ }),
+	)
+
+	if got := pool.Get(); got != nil {
+		t.Fatalf("Get() = %v, want the constructor's nil", got)
+	}
+	if calls != 1 {
+		t.Fatalf("handler ran %d times, want 1", calls)
+	}
+}
+
+func TestOOMHandlerAllocationPanic(t *testing.T) {
+	var calls int
+	n := -1
+	for _, newFn := range []func() any{
+		func() any { return make([]byte, n) },
+		func() any { return make(chan int, n) },
+		func() any { b := new(bytes.Buffer); b.Grow(1 << 62); return b },
+	} {
+		pool := NewTypedPool(newFn, WithOOMHandler[any](func() { calls++ }))
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("the constructor panic was swallowed")
+				}
+			}()
+			pool.Get()
+		}()
+	}
+	if calls != 3 {
+		t.Fatalf("handler ran %d times, want 3", calls)
+	}
+}
+
+func TestOOMHandlerIgnoresOtherPanicsAndValues(t *testing.T) {
+	var calls int
+	pool := NewTypedPool(func() int { return 0 },
+		WithOOMHandler[int](func() { calls++ }),
+	)
+	pool.Get()
+
+	var (
+		nilMap map[string]*int
+		empty  []*int
+		idx    = 3
+	)
+	for _, newFn := range []func() *int{
+		func() *int { panic("not an allocation") },
+		func() *int { nilMap["x"] = new(int); return nil },  // nil map write
+		func() *int { return empty[idx] },                   // index out of range
+		func() *int { var p *struct{ v *int }; return p.v }, // nil dereference
+	} {
+		panicky := NewTypedPool(newFn, WithOOMHandler[*int](func() { calls++ }))
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("the constructor panic was swallowed")
+				}
+			}()
+			panicky.Get()
+		}()
+	}
+
+	if calls != 0 {
+		t.Fatalf("handler ran %d times, want 0", calls)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// PoolOption configures a TypedPool at construction time.
+type PoolOption[T any] func(*poolConfig[T])
+
+// poolConfig collects the settings applied by PoolOptions.
+type poolConfig[T any] struct {
+	profileName string
+	ordering    Ordering
+	idleTTL     time.Duration
+	scheduler   Scheduler
+	jitter      *jitterSource
+	sizeFn      func(T) int64
+	group       *PoolGroup
+
+	maxItems     int
+	softMaxItems int
+	softEvictFn  func(T) bool
+	onDiscard    func(T)
+	healthCheck  func(T) bool
+	poison       *poisonPill[T]
+	objectLimit  *objectLimit[T]
+
+	stats             bool
+	telemetryPrefix   string
+	audit             *auditLog
+	logger            *Logger
+	deadlockTimeout   time.Duration
+	singleton         bool
+	maxPutLatency     time.Duration
+	onSlowPut         func()
+	asyncPutSize      int
+	clock             Clock
+	ctorTimeout       time.Duration
+	ctorFallback      func() T
+	softTimeout       time.Duration
+	onExpiry          func(T)
+	itemPool          *sync.Pool
+	newFn             func() T
+	onOOM             func()
+	buckets           *sizeBuckets[T]
+	concurrency       bool
+	onReuse           func(T, int) error
+	preHeat           *preHeat
+	parallelInit      *parallelInit
+	debugMode         bool
+	debugExpiry       time.Duration
+	hotObjects        int
+	onGC              func(gcCount int)
+	guard             ResourceGuard
+	putHook           func(T) T
+	stackSampler      *stackSampler
+	sentinel          T
+	hasSentinel       bool
+	objectCount       *objectCount
+	expiresAt         func(T) time.Time
+	newRaceDetect     bool
+	store             ItemStore[T]
+	monotonicID       bool
+	snapshotExport    *snapshotExport
+	snapshotRotation  *snapshotRotation
+	coldStart         int
+	prewarmTrigger    func() bool
+	prewarmN          int
+	prewarmPoll       time.Duration
+	stacked           []*TypedPool[T]
+	shutdownSignals   []os.Signal
+	putHashFn         func(T) int
+	putHashBuckets    int
+	setPool           func(T, *TypedPool[T])
+	ctorWorkers       int
+	sampledNewRate    *float64
+	staggerDelay      time.Duration
+	localCache        int
+	batchDelay        time.Duration
+	batchSize         int
+	ctorObserver      ConstructorObserver
+	lockFree          bool
+	recycle           func(T) T
+	fastPath          *sync.Pool
+	getMiddleware     []func(next func() T) T
+	goroutineTracking bool
+	barrierFn         func() bool
+	hashFn            func(uint64) int
+	shards            int
+	drainTimeout      time.Duration
+	autoTune          *autoTune
+	sleepOnEmpty      time.Duration
+	emptyRetries      *int
+	capWindow         *capWindow[T]
+	dynamicNew        *dynamicNew[T]
+	condNew           *conditionalNew[T]
+	reset             func(T)
+	resetOnGet        func(T)
+	reuseLimit        int
+	snapshotEvery     time.Duration
+	snapshotSink      func(PoolSnapshot)
+	mirror            *mirror[T]
+	tracker           *AllocTracker
+	trackerFlush      time.Duration
+	tagger            func(T, map[string]string)
+	sizeHint          *sizeHint[T]
+	recovery          *TypedPool[T]
+	minIdle           int
+	refillInterval    time.Duration
+	sweepInterval     time.Duration
+	factory           ObjectFactory[T]
+	ctorBudget        *int64
+	windowStats       bool
+	version           *int
+	borrowTrace       bool
+	memoKey           func() string
+	destructor        *TypedPool[T]
+	asyncPutWorkers   int
+	parallelPut       bool
+	abortOnGet        func() bool
+	stickyID          func() uint64
+	checksum          *checksum[T]
+	throttle          *throttle
+	initCheck         func(T) error
+	maxAttempts       int
+	chaos             float64 // set only in builds with the chaos tag
+	newOnce           bool
+	latencyBounds     []time.Duration
+	metadataFn        func(T) map[string]any
+	eventLog          int
+	deny              func(T) bool
+	allow             func(T) bool
+	idleCap           time.Duration
+	overflow          chan<- T
+
+	// attach hooks run once NewTypedPool has built the pool; the function
+	// each returns, if not nil, is run by the first Close.
+	attach []func(*TypedPool[T]) func()
+}
@@ -0,0 +1,52 @@
+package main
+
+import "time"
+
+// Ordering selects which idle object a backend with its own idle list hands
+// out next.
+type Ordering int
+
+const (
+	// LIFO returns the most recently Put object first, which keeps caches warm.
+	LIFO Ordering = iota
+	// FIFO returns the longest idle object first, so every cached object is
+	// exercised regularly and ages out in order.
+	FIFO
+)
+
+// String returns the ordering name.
+func (o Ordering) String() string {
+	switch o {
+	case LIFO:
+		return "LIFO"
+	case FIFO:
+		return "FIFO"
+	default:
+		return "Ordering(?)"
+	}
+}
+
+// WithOrdering sets the retrieval order of idle objects. It applies to pools
+// that own their idle list, such as BoundedPool. On a TypedPool, FIFO is the
+// same as WithFIFO and LIFO keeps the default sync.Pool ordering.
+func WithOrdering[T any](o Ordering) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.ordering = o
+	}
+}
+
+// WithFIFO makes a TypedPool hand out its longest-idle item first, spreading
+// use evenly across items that age out, such as connections with a TTL. It
+// trades sync.Pool for a mutex-guarded ring buffer: idle items are no longer
+// dropped by the GC, and Get and Put contend on a single lock.
+func WithFIFO[T any]() PoolOption[T] {
+	return WithOrdering[T](FIFO)
+}
+
+// WithIdleTTL discards idle objects that have sat in the pool longer than d.
+// Like WithOrdering, it applies to pools that own their idle list.
+func WithIdleTTL[T any](d time.Duration) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.idleTTL = d
+	}
+}
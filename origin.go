@@ -0,0 +1,38 @@
+package main
+
+import "context"
+
+// Origin tells where the object returned by a GetInfo call came from.
+type Origin uint8
+
+const (
+	// OriginNew is an object the constructor built for the call.
+	OriginNew Origin = iota
+	// OriginReused is a recycled object, counted as a Stats hit.
+	OriginReused
+)
+
+func (o Origin) String() string {
+	if o == OriginReused {
+		return "reused"
+	}
+	return "new"
+}
+
+// GetInfo is Get, also reporting whether the item was constructed for this
+// call, so a caller can, say, skip revalidating a fresh item. It agrees
+// with Stats: OriginNew for a miss, OriginReused for a hit. Under
+// WithSizeBuckets it reports on the first class, as Get serves it.
+func (tp *TypedPool[T]) GetInfo() (T, Origin) {
+	if tp.classes != nil {
+		return tp.classes[0].GetInfo()
+	}
+	v, origin, err := tp.get(context.Background(), 0)
+	return tp.orNoItem(v, err), origin
+}
+
+// GetInfo is Get, reporting OriginReused: a FixedPool constructs all of its
+// objects up front, so every Get is a hit.
+func (fp *FixedPool[T]) GetInfo() (T, Origin) {
+	return fp.Get(), OriginReused
+}
@@ -0,0 +1,80 @@
+package main
+
+import "testing"
+
+// originPool is the part of each backend GetInfo is checked through.
+type originPool interface {
+	GetInfo() (*int, Origin)
+	Put(*int)
+	Stats() Stats
+}
+
+func TestGetInfoOrigins(t *testing.T) {
+	newInt := func() *int { return new(int) }
+	for name, pool := range map[string]originPool{
+		"typed":   NewTypedPool(newInt, WithFIFO[*int](), WithStats[*int]()),
+		"bounded": NewBoundedPool(4, newInt, WithStats[*int]()),
+		"soft":    NewSoftPool(4, newInt, WithStats[*int]()),
+	} {
+		// Two misses, a hit of the item Put back, then a miss again.
+		var got []Origin
+		a, o := pool.GetInfo()
+		got = append(got, o)
+		_, o = pool.GetInfo()
+		got = append(got, o)
+		pool.Put(a)
+		again, o := pool.GetInfo()
+		got = append(got, o)
+		_, o = pool.GetInfo()
+		got = append(got, o)
+
+		want := []Origin{OriginNew, OriginNew, OriginReused, OriginNew}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("%s: origins = %v, want %v", name, got, want)
+			}
+		}
+		if again != a {
+			t.Fatalf("%s: the reused item is not the one Put", name)
+		}
+		if s := pool.Stats(); s.Hits != 1 || s.Misses != 3 {
+			t.Fatalf("%s: hits, misses = %d, %d; want 1, 3", name, s.Hits, s.Misses)
+		}
+	}
+}
+
+func TestGetInfoByKeySharded(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithHashDispatch[*int](func(key uint64) int { return int(key) }, 2), WithFIFO[*int]())
+
+	v, o := pool.GetInfoByKey(1)
+	if o != OriginNew {
+		t.Fatalf("first Get of shard 1 = %v, want new", o)
+	}
+	pool.PutByKey(1, v)
+	if _, o := pool.GetInfoByKey(0); o != OriginNew {
+		t.Fatalf("Get of the empty shard 0 = %v, want new", o)
+	}
+	if got, o := pool.GetInfoByKey(1); o != OriginReused || got != v {
+		t.Fatalf("Get of shard 1 = %v, want the reused item", o)
+	}
+}
+
+func TestGetInfoFixedAndMemo(t *testing.T) {
+	fp := NewFixedPool(1, func() *int { return new(int) })
+	if _, o := fp.GetInfo(); o != OriginReused {
+		t.Fatalf("FixedPool origin = %v, want reused", o)
+	}
+
+	memo := NewTypedPool(func() *int { return new(int) },
+		WithConstructorMemo[*int](func() string { return "k" }))
+	if _, o := memo.GetInfo(); o != OriginNew {
+		t.Fatalf("first memoized Get = %v, want new", o)
+	}
+	if _, o := memo.GetInfo(); o != OriginReused {
+		t.Fatalf("second memoized Get = %v, want reused", o)
+	}
+	if OriginNew.String() != "new" || OriginReused.String() != "reused" {
+		t.Fatal("Origin.String")
+	}
+}
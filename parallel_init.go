@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// WithParallelInit fills the pool with count items, built by concurrency
+// goroutines, before NewTypedPool returns, for constructors bound by I/O
+// such as opening files or connections. Unlike WithPreHeat, which logs and
+// carries on, a failure is fatal: if the constructor panics or an item fails
+// WithInitCheck after its WithRetry attempts, the items already built are
+// discarded, the pool is closed, and NewTypedPool panics with an error
+// wrapping the first failure.
+func WithParallelInit[T any](concurrency, count int) PoolOption[T] {
+	if concurrency < 1 || count < 0 {
+		panic("WithParallelInit: concurrency must be at least 1 and count at least 0")
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.parallelInit = &parallelInit{concurrency: concurrency, count: count}
+	}
+}
+
+// parallelInit holds the WithParallelInit settings.
+type parallelInit struct {
+	concurrency int
+	count       int
+}
+
+// runParallelInit runs the WithParallelInit warmup.
+func (tp *TypedPool[T]) runParallelInit() {
+	pi := tp.cfg.parallelInit
+	err := tp.preHeatFunc(context.Background(), pi.concurrency, pi.count, func(ctx context.Context) (v T, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("constructor panicked: %v", r)
+			}
+		}()
+		// served is -1 so no WithPoisonPill is ever due.
+		return tp.constructChecked(ctx, -1, 0)
+	})
+	if err != nil {
+		tp.Drain()
+		tp.Close()
+		panic(fmt.Errorf("NewTypedPool: WithParallelInit: %w", err))
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParallelInit(t *testing.T) {
+	var running, peak atomic.Int64
+	pool := NewTypedPool(func() *int {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+		return new(int)
+	}, WithParallelInit[*int](3, 10), WithFIFO[*int]())
+
+	if n := pool.Len(); n != 10 {
+		t.Fatalf("Len = %d after NewTypedPool, want 10", n)
+	}
+	if p := peak.Load(); p > 3 {
+		t.Fatalf("%d constructors ran at once, want at most 3", p)
+	}
+}
+
+func TestParallelInitFailure(t *testing.T) {
+	var built, discarded atomic.Int64
+	defer func() {
+		err, ok := recover().(error)
+		if !ok || !errors.Is(err, ErrInitCheck) {
+			t.Fatalf("NewTypedPool panicked with %v, want an error wrapping ErrInitCheck", err)
+		}
+		if b, d := built.Load(), discarded.Load(); b != d {
+			t.Fatalf("built %d items but discarded %d, want every one discarded", b, d)
+		}
+	}()
+	NewTypedPool(func() *int { return new(int) },
+		WithParallelInit[*int](2, 8),
+		WithInitCheck(func(*int) error {
+			if built.Add(1) == 5 {
+				return errors.New("broken")
+			}
+			return nil
+		}),
+		WithOnDiscard(func(*int) { discarded.Add(1) }),
+		WithFIFO[*int](),
+	)
+	t.Fatal("NewTypedPool returned")
+}
@@ -0,0 +1,35 @@
+package main
+
+import "sync/atomic"
+
+// WithPoisonPill makes the pool hand out pill, once, in place of the first
+// constructor call that happens after `after` Gets have been served. It is
+// meant for tests that need to inject a bad item deterministically; empty
+// the pool or keep it cold so the Get is a miss.
+func WithPoisonPill[T any](pill T, after int) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.poison = &poisonPill[T]{pill: pill, after: int64(after)}
+	}
+}
+
+// poisonPill tracks when the pill is due.
+type poisonPill[T any] struct {
+	pill   T
+	after  int64
+	gets   atomic.Int64
+	issued atomic.Bool
+}
+
+// countGet records a Get and reports the number of Gets before it.
+func (p *poisonPill[T]) countGet() int64 {
+	return p.gets.Add(1) - 1
+}
+
+// take returns the pill if it is due and has not been issued yet.
+func (p *poisonPill[T]) take(served int64) (T, bool) {
+	if served < p.after || !p.issued.CompareAndSwap(false, true) {
+		var zero T
+		return zero, false
+	}
+	return p.pill, true
+}
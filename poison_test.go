@@ -0,0 +1,19 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWithPoisonPill(t *testing.T) {
+	pool := NewTypedPool(func() int { return 1 }, WithPoisonPill(-1, 2))
+
+	got := make([]int, 5)
+	for i := range got {
+		got[i] = pool.Get()
+	}
+
+	if want := []int{1, 1, -1, 1, 1}; !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
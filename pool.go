@@ -0,0 +1,13 @@
+package main
+
+// Pool is the behaviour shared by every pool backend in this package.
+type Pool[T any] interface {
+	Get() T
+	Put(v T)
+}
+
+var (
+	_ Pool[int] = (*TypedPool[int])(nil)
+	_ Pool[int] = (*BoundedPool[int])(nil)
+	_ Pool[int] = (*FixedPool[int])(nil)
+)
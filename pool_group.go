@@ -0,0 +1,228 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// GroupPolicy decides what a PoolGroup does when a Put would exceed its budget.
+type GroupPolicy int
+
+const (
+	// EvictLargest evicts idle objects from the member retaining the most bytes.
+	EvictLargest GroupPolicy = iota
+	// EvictLRU evicts idle objects from the member used least recently.
+	EvictLRU
+	// DiscardNew drops the object being Put and leaves the members untouched.
+	DiscardNew
+)
+
+// PoolGroup manages several pools together: it can snapshot, warm, drain, and
+// health-check all of them at once, and optionally enforce one memory budget
+// across them. Pools join with WithPoolGroup.
+//
+// Only pools that own their idle list, such as BoundedPool, take part in the
+// budget; they must also set WithSizeFunc so their objects can be accounted
+// for. TypedPool members are managed but never counted against the budget.
+type PoolGroup struct {
+	mu      sync.Mutex
+	budget  int64
+	used    int64
+	policy  GroupPolicy
+	members []groupMember
+	budgets []budgetMember
+	tick    atomic.Int64
+}
+
+// groupMember is implemented by every pool that can join a group.
+type groupMember interface {
+	Snapshot() PoolSnapshot
+	Warmup(n int)
+	Drain() int
+	HealthCheck() int
+}
+
+// budgetMember is implemented by pools whose retained bytes the group can
+// account for and evict from.
+type budgetMember interface {
+	RetainedBytes() int64
+	// evictOldest drops the member's oldest idle object and returns its size,
+	// or 0 if the member is empty.
+	evictOldest() int64
+	lastUsed() int64
+}
+
+// GroupOption configures a PoolGroup.
+type GroupOption func(*PoolGroup)
+
+// WithGroupPolicy sets what the group does when a Put would exceed its
+// budget. The default is EvictLargest.
+func WithGroupPolicy(policy GroupPolicy) GroupOption {
+	return func(g *PoolGroup) {
+		g.policy = policy
+	}
+}
+
+// NewPoolGroup creates a group whose members may collectively retain at most
+// budgetBytes of idle objects. A budget of 0 or less means no limit.
+func NewPoolGroup(budgetBytes int64, opts ...GroupOption) *PoolGroup {
+	g := &PoolGroup{budget: budgetBytes}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// WithPoolGroup makes the pool a member of g.
+func WithPoolGroup[T any](g *PoolGroup) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.group = g
+	}
+}
+
+// RetainedBytes returns the total size of idle objects held by members that
+// take part in the budget.
+func (g *PoolGroup) RetainedBytes() int64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.used
+}
+
+// StatsAll returns a snapshot of every member, in the order they joined.
+func (g *PoolGroup) StatsAll() []PoolSnapshot {
+	members := g.list()
+	snaps := make([]PoolSnapshot, len(members))
+	for i, m := range members {
+		snaps[i] = m.Snapshot()
+	}
+	return snaps
+}
+
+// WarmupAll warms every member with n objects.
+func (g *PoolGroup) WarmupAll(n int) {
+	for _, m := range g.list() {
+		m.Warmup(n)
+	}
+}
+
+// DrainAll drains every member and returns the total number of items removed.
+func (g *PoolGroup) DrainAll() int {
+	total := 0
+	for _, m := range g.list() {
+		total += m.Drain()
+	}
+	return total
+}
+
+// HealthCheckAll health-checks every member and returns the total number of
+// items discarded.
+func (g *PoolGroup) HealthCheckAll() int {
+	total := 0
+	for _, m := range g.list() {
+		total += m.HealthCheck()
+	}
+	return total
+}
+
+// list returns a copy of the members, so callers can operate on them without
+// holding g.mu.
+func (g *PoolGroup) list() []groupMember {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return append([]groupMember(nil), g.members...)
+}
+
+func (g *PoolGroup) register(m groupMember) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.members = append(g.members, m)
+	if b, ok := m.(budgetMember); ok {
+		g.budgets = append(g.budgets, b)
+	}
+}
+
+// limited reports whether the group enforces a budget.
+func (g *PoolGroup) limited() bool {
+	return g.budget > 0
+}
+
+// touch returns a logical timestamp for LRU bookkeeping.
+func (g *PoolGroup) touch() int64 {
+	return g.tick.Add(1)
+}
+
+// reserve accounts for size more bytes, evicting from members according to
+// the group policy if needed. It reports false if the object must be dropped.
+// It must not be called with any member's lock held.
+func (g *PoolGroup) reserve(size int64) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.limited() {
+		g.used += size
+		return true
+	}
+	if size > g.budget {
+		return false
+	}
+
+	for g.used+size > g.budget {
+		if g.policy == DiscardNew {
+			return false
+		}
+
+		victim := g.victim()
+		if victim == nil {
+			return false
+		}
+
+		freed := victim.evictOldest()
+		if freed == 0 {
+			return false
+		}
+		g.used -= freed
+	}
+
+	g.used += size
+	return true
+}
+
+// release gives back size bytes previously reserved.
+func (g *PoolGroup) release(size int64) {
+	if size == 0 {
+		return
+	}
+
+	g.mu.Lock()
+	g.used -= size
+	g.mu.Unlock()
+}
+
+// victim picks the member to evict from. g.mu must be held.
+func (g *PoolGroup) victim() budgetMember {
+	var best budgetMember
+	for _, m := range g.budgets {
+		if m.RetainedBytes() == 0 {
+			continue
+		}
+		if best == nil {
+			best = m
+			continue
+		}
+
+		switch g.policy {
+		case EvictLargest:
+			if m.RetainedBytes() > best.RetainedBytes() {
+				best = m
+			}
+		case EvictLRU:
+			if m.lastUsed() < best.lastUsed() {
+				best = m
+			}
+		}
+	}
+	return best
+}
@@ -0,0 +1,131 @@
+package main
+
+import "testing"
+
+func newGroupMember(g *PoolGroup) *BoundedPool[[]byte] {
+	return NewBoundedPool(16,
+		func() []byte { return make([]byte, 0, 10) },
+		WithSizeFunc(func(b []byte) int64 { return int64(cap(b)) }),
+		WithPoolGroup[[]byte](g),
+	)
+}
+
+func TestPoolGroupBudgetIsGlobal(t *testing.T) {
+	g := NewPoolGroup(50, WithGroupPolicy(DiscardNew))
+	a := newGroupMember(g)
+	b := newGroupMember(g)
+
+	for range 3 {
+		a.Put(make([]byte, 0, 10))
+	}
+	for range 3 {
+		b.Put(make([]byte, 0, 10))
+	}
+
+	if got := g.RetainedBytes(); got != 50 {
+		t.Fatalf("group retained %d bytes, want 50", got)
+	}
+	if got := a.RetainedBytes() + b.RetainedBytes(); got != 50 {
+		t.Fatalf("members retained %d bytes, want 50", got)
+	}
+	// Each member alone is well under budget; only the total is capped.
+	if got := b.Len(); got != 2 {
+		t.Fatalf("second member holds %d objects, want 2", got)
+	}
+
+	a.Get()
+	if got := g.RetainedBytes(); got != 40 {
+		t.Fatalf("after Get group retained %d bytes, want 40", got)
+	}
+}
+
+func TestPoolGroupEvictLargest(t *testing.T) {
+	g := NewPoolGroup(40)
+	a := newGroupMember(g)
+	b := newGroupMember(g)
+
+	for range 3 {
+		a.Put(make([]byte, 0, 10))
+	}
+	b.Put(make([]byte, 0, 10))
+	b.Put(make([]byte, 0, 10))
+
+	if got := g.RetainedBytes(); got != 40 {
+		t.Fatalf("group retained %d bytes, want 40", got)
+	}
+	if a.Len() != 2 || b.Len() != 2 {
+		t.Fatalf("member lengths = %d, %d, want 2, 2", a.Len(), b.Len())
+	}
+}
+
+func TestPoolGroupEvictLRU(t *testing.T) {
+	g := NewPoolGroup(30, WithGroupPolicy(EvictLRU))
+	a := newGroupMember(g)
+	b := newGroupMember(g)
+
+	a.Put(make([]byte, 0, 10))
+	b.Put(make([]byte, 0, 10))
+	b.Put(make([]byte, 0, 10))
+	b.Put(make([]byte, 0, 10))
+
+	if a.Len() != 0 || b.Len() != 3 {
+		t.Fatalf("member lengths = %d, %d, want 0, 3", a.Len(), b.Len())
+	}
+}
+
+func TestPoolGroupManagement(t *testing.T) {
+	g := NewPoolGroup(0)
+	// A FIFO store keeps Len exact; a sync.Pool only forgets items the race
+	// detector drops once they are collected.
+	typed := NewTypedPool(func() int { return 1 },
+		WithFIFO[int](),
+		WithPoolGroup[int](g),
+		WithTelemetryPrefix[int]("typed"),
+	)
+	bounded := NewBoundedPool(4, func() int { return 2 },
+		WithPoolGroup[int](g),
+		WithTelemetryPrefix[int]("bounded"),
+		WithHealthCheck(func(v int) bool { return v%2 == 0 }),
+	)
+
+	g.WarmupAll(3)
+	bounded.Put(3)
+
+	snaps := g.StatsAll()
+	if len(snaps) != 2 || snaps[0].Name != "typed" || snaps[1].Name != "bounded" {
+		t.Fatalf("StatsAll() = %+v, want typed and bounded", snaps)
+	}
+	if snaps[1].Idle != 4 {
+		t.Fatalf("bounded idle = %d, want 4", snaps[1].Idle)
+	}
+
+	if got := g.HealthCheckAll(); got != 1 {
+		t.Fatalf("HealthCheckAll() = %d, want 1", got)
+	}
+	if got := bounded.Len(); got != 3 {
+		t.Fatalf("bounded Len() after health check = %d, want 3", got)
+	}
+
+	g.DrainAll()
+	if typed.Len() != 0 || bounded.Len() != 0 {
+		t.Fatalf("after DrainAll lengths = %d, %d, want 0, 0", typed.Len(), bounded.Len())
+	}
+}
+
+func TestRejectedPoolLeavesGroupAlone(t *testing.T) {
+	g := NewPoolGroup(0)
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("NewTypedPool accepted WithMinIdle")
+			}
+		}()
+		NewTypedPool(func() *int { return new(int) },
+			WithPoolGroup[*int](g),
+			WithMinIdle[*int](1),
+		)
+	}()
+	if snaps := g.StatsAll(); len(snaps) != 0 {
+		t.Fatalf("StatsAll() = %+v after a rejected pool, want none", snaps)
+	}
+}
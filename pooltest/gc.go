@@ -0,0 +1,125 @@
+package pooltest
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"runtime/metrics"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// GCReport is what MeasureGC saw while the workload ran.
+type GCReport struct {
+	Ops        int64         // workload calls completed
+	Elapsed    time.Duration // how long the workload ran
+	Cycles     uint64        // GC cycles finished
+	PauseTotal time.Duration // stop-the-world GC pauses, estimated
+	HeapGoal   uint64        // the highest heap goal sampled, in bytes
+	AllocBytes uint64        // bytes allocated on the heap
+}
+
+// String renders r on one line, in the style of a benchmark result, for
+// pasting next to them.
+func (r GCReport) String() string {
+	perOp := 0.0
+	if r.Ops > 0 {
+		perOp = float64(r.AllocBytes) / float64(r.Ops)
+	}
+	return fmt.Sprintf("%10d ops\t%6d gc-cycles\t%12v gc-pause\t%8d heap-goal-KiB\t%8.1f B/op",
+		r.Ops, r.Cycles, r.PauseTotal.Round(time.Microsecond), r.HeapGoal>>10, perOp)
+}
+
+const (
+	metricCycles = "/gc/cycles/total:gc-cycles"
+	metricPauses = "/sched/pauses/total/gc:seconds"
+	metricGoal   = "/gc/heap/goal:bytes"
+	metricAllocs = "/gc/heap/allocs:bytes"
+)
+
+// MeasureGC calls workload in a loop on four goroutines per P for
+// duration, sampling runtime/metrics as it goes, and reports the garbage
+// collection the load caused. Where allocs/op only counts allocations, the
+// report shows what they cost: GC cycles, pause time and heap goal. The
+// pause time is estimated from the runtime's pause histogram.
+func MeasureGC(workload func(), duration time.Duration) GCReport {
+	runtime.GC()
+	before := readGCMetrics()
+
+	var (
+		ops  atomic.Int64
+		stop atomic.Bool
+		wg   sync.WaitGroup
+	)
+	start := time.Now()
+	for range 4 * runtime.GOMAXPROCS(0) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !stop.Load() {
+				workload()
+				ops.Add(1)
+			}
+		}()
+	}
+
+	goal := before[2].Value.Uint64()
+	sample := make([]metrics.Sample, 1)
+	sample[0].Name = metricGoal
+	tick := time.NewTicker(10 * time.Millisecond)
+	deadline := time.After(duration)
+sampling:
+	for {
+		select {
+		case <-tick.C:
+			metrics.Read(sample)
+			goal = max(goal, sample[0].Value.Uint64())
+		case <-deadline:
+			break sampling
+		}
+	}
+	tick.Stop()
+	stop.Store(true)
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	after := readGCMetrics()
+	return GCReport{
+		Ops:        ops.Load(),
+		Elapsed:    elapsed,
+		Cycles:     after[0].Value.Uint64() - before[0].Value.Uint64(),
+		PauseTotal: pauseDelta(before[1].Value.Float64Histogram(), after[1].Value.Float64Histogram()),
+		HeapGoal:   max(goal, after[2].Value.Uint64()),
+		AllocBytes: after[3].Value.Uint64() - before[3].Value.Uint64(),
+	}
+}
+
+// readGCMetrics reads the metrics MeasureGC reports, in the order of the
+// metric constants.
+func readGCMetrics() []metrics.Sample {
+	s := []metrics.Sample{{Name: metricCycles}, {Name: metricPauses}, {Name: metricGoal}, {Name: metricAllocs}}
+	metrics.Read(s)
+	return s
+}
+
+// pauseDelta estimates the total pause time added between two readings of
+// a pause histogram, taking each pause at the middle of its bucket.
+func pauseDelta(before, after *metrics.Float64Histogram) time.Duration {
+	var total float64
+	for i, n := range after.Counts {
+		n -= before.Counts[i]
+		if n == 0 {
+			continue
+		}
+		lo, hi := after.Buckets[i], after.Buckets[i+1]
+		switch {
+		case math.IsInf(lo, -1):
+			lo = hi
+		case math.IsInf(hi, 1):
+			hi = lo
+		}
+		total += float64(n) * (lo + hi) / 2
+	}
+	return time.Duration(total * float64(time.Second))
+}
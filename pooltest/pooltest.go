@@ -0,0 +1,48 @@
+// Package pooltest provides helpers for testing and benchmarking code that
+// uses the pools in this module.
+package pooltest
+
+import "testing"
+
+// Reporter is implemented by pools that can report their own counters.
+type Reporter interface {
+	PoolCounters() (hits, misses, retainedBytes int64)
+}
+
+type counters struct {
+	hits, misses int64
+}
+
+// ReportPoolMetrics snapshots the pools' counters now and, once the benchmark
+// function returns, reports hits/op, misses/op and retained-bytes for the
+// work done in between. Call it before the benchmark loop. Both snapshots are
+// taken outside the timed region, so it adds nothing to the measurements.
+func ReportPoolMetrics(b *testing.B, pools ...Reporter) {
+	b.Helper()
+
+	before := snapshot(pools)
+	b.Cleanup(func() {
+		after := snapshot(pools)
+
+		var hits, misses, retained int64
+		for i, p := range pools {
+			hits += after[i].hits - before[i].hits
+			misses += after[i].misses - before[i].misses
+			_, _, r := p.PoolCounters()
+			retained += r
+		}
+
+		n := float64(b.N)
+		b.ReportMetric(float64(hits)/n, "hits/op")
+		b.ReportMetric(float64(misses)/n, "misses/op")
+		b.ReportMetric(float64(retained), "retained-bytes")
+	})
+}
+
+func snapshot(pools []Reporter) []counters {
+	s := make([]counters, len(pools))
+	for i, p := range pools {
+		s[i].hits, s[i].misses, _ = p.PoolCounters()
+	}
+	return s
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+)
+
+// TimePrecision is the sub-second resolution of a Logger's timestamps.
+type TimePrecision int
+
+const (
+	// PrecisionSecond leaves the layout as it is.
+	PrecisionSecond TimePrecision = iota
+	// PrecisionMillisecond appends three fractional digits: 15:04:05.000.
+	PrecisionMillisecond
+	// PrecisionMicrosecond appends six: 15:04:05.000000.
+	PrecisionMicrosecond
+	// PrecisionNanosecond appends nine: 15:04:05.000000000.
+	PrecisionNanosecond
+)
+
+// digits returns the number of fractional digits p adds.
+func (p TimePrecision) digits() int {
+	return 3 * int(p)
+}
+
+// WithTimePrecision adds fractional seconds of precision p right after the
+// seconds of the time layout, zero-padded to a fixed width, so lines within
+// one second can be ordered. It composes with WithCachedTime: the cached
+// text stops at the seconds and the fraction is appended fresh on each line.
+// Layouts that already have fractional seconds, or no seconds, are left as
+// they are.
+func WithTimePrecision(p TimePrecision) LoggerOption {
+	return func(l *Logger) {
+		l.precision = p
+	}
+}
+
+// initPrecision finds where the fraction goes in the layout. It runs once
+// the options are applied.
+func (l *Logger) initPrecision() {
+	l.fracAt = -1
+	l.preciseLayout = l.layout
+	l.subSec = subSecond(l.layout)
+	if l.precision <= PrecisionSecond || l.subSec {
+		return
+	}
+	i := strings.Index(l.layout, "05")
+	if i < 0 {
+		return
+	}
+	l.fracAt = i + len("05")
+	l.preciseLayout = l.layout[:l.fracAt] + "." + strings.Repeat("0", l.precision.digits()) + l.layout[l.fracAt:]
+}
+
+// appendFraction writes '.' and the first digits of the fraction of a
+// second in ns, zero-padded.
+func appendFraction(b *bytes.Buffer, ns int, digits int) {
+	var buf [10]byte
+	buf[0] = '.'
+	for i := 9; i > digits; i-- {
+		ns /= 10
+	}
+	for i := digits; i > 0; i-- {
+		buf[i] = byte('0' + ns%10)
+		ns /= 10
+	}
+	b.Write(buf[:digits+1])
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ArditZubaku/go-sync-pool/pooltest"
+)
+
+func TestWithTimePrecision(t *testing.T) {
+	// 12:34:56.007008009: leading zeros in every group pin the width.
+	at := time.Date(2024, 1, 1, 12, 34, 56, 7008009, time.UTC)
+	for _, tc := range []struct {
+		p    TimePrecision
+		want string
+	}{
+		{PrecisionSecond, "12:34:56"},
+		{PrecisionMillisecond, "12:34:56.007"},
+		{PrecisionMicrosecond, "12:34:56.007008"},
+		{PrecisionNanosecond, "12:34:56.007008009"},
+	} {
+		for _, cached := range []bool{false, true} {
+			opts := []LoggerOption{WithClock(pooltest.NewFakeClock(at)), WithUTC(), WithTimePrecision(tc.p)}
+			if cached {
+				opts = append(opts, WithCachedTime())
+			}
+			var out bytes.Buffer
+			logger := NewLogger(&out, opts...)
+			logger.Info("a")
+			logger.Info("b") // served from the cache when cached
+
+			for _, line := range strings.SplitAfter(strings.TrimSuffix(out.String(), "\n"), "\n") {
+				stamp, _, _ := strings.Cut(line, " : ")
+				if stamp != tc.want {
+					t.Errorf("precision %d, cached %v: stamp %q, want %q", tc.p, cached, stamp, tc.want)
+				}
+			}
+		}
+	}
+}
+
+func TestWithTimePrecisionMidLayout(t *testing.T) {
+	at := time.Date(2024, 1, 1, 12, 34, 56, 123456789, time.UTC)
+	for _, cached := range []bool{false, true} {
+		opts := []LoggerOption{
+			WithClock(pooltest.NewFakeClock(at)), WithUTC(), WithJSON(),
+			WithTimeLayout(time.RFC3339), WithTimePrecision(PrecisionMillisecond),
+		}
+		if cached {
+			opts = append(opts, WithCachedTime())
+		}
+		var out bytes.Buffer
+		NewLogger(&out, opts...).Info("a")
+
+		if want := `{"ts":"2024-01-01T12:34:56.123Z",`; !strings.HasPrefix(out.String(), want) {
+			t.Errorf("cached %v: got %q, want prefix %q", cached, out.String(), want)
+		}
+	}
+}
+
+func TestWithTimePrecisionKeepsFractionalLayout(t *testing.T) {
+	at := time.Date(2024, 1, 1, 12, 34, 56, 123456789, time.UTC)
+	var out bytes.Buffer
+	logger := NewLogger(&out, WithClock(pooltest.NewFakeClock(at)), WithUTC(),
+		WithTimeLayout("15:04:05.00"), WithTimePrecision(PrecisionNanosecond))
+	logger.Info("a")
+
+	if want := "12:34:56.12 : INFO : a\n"; out.String() != want {
+		t.Fatalf("got %q, want %q", out.String(), want)
+	}
+}
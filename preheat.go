@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// WithPreHeat fills the pool from NewTypedPool, which returns once n items
+// have been constructed by up to concurrency goroutines, so a pool of slow
+// items such as database connections takes about n/concurrency constructor
+// latencies to fill instead of n. If ctx is cancelled first, the remaining
+// constructions are abandoned with a warning on the pool's Logger; items
+// already built stay pooled. Use PreHeatFunc for constructors that can fail.
+func WithPreHeat[T any](ctx context.Context, concurrency, n int) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.preHeat = &preHeat{ctx: ctx, concurrency: concurrency, n: n}
+	}
+}
+
+// preHeat holds the WithPreHeat settings.
+type preHeat struct {
+	ctx         context.Context
+	concurrency int
+	n           int
+}
+
+// PreHeat constructs n items with up to concurrency goroutines and puts them
+// in the pool. It returns ctx.Err() if ctx is cancelled before all are
+// built; the ones already built are kept.
+func (tp *TypedPool[T]) PreHeat(ctx context.Context, concurrency, n int) error {
+	return tp.preHeatFunc(ctx, concurrency, n, func(context.Context) (T, error) {
+		return tp.newFn(), nil
+	})
+}
+
+// PreHeatFunc is PreHeat with a constructor that can fail. The first error
+// abandons the constructions not yet started and is returned once the
+// running ones finish; every item built successfully is pooled.
+func (tp *TypedPool[T]) PreHeatFunc(ctx context.Context, concurrency, n int, newFn func(context.Context) (T, error)) error {
+	return tp.preHeatFunc(ctx, concurrency, n, observedNewCtx(tp.cfg.ctorObserver, newFn))
+}
+
+// preHeatFunc is PreHeatFunc for constructors already reported to any
+// WithConstructorObserver.
+func (tp *TypedPool[T]) preHeatFunc(ctx context.Context, concurrency, n int, newFn func(context.Context) (T, error)) error {
+	concurrency = max(1, min(concurrency, n))
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		claimed  atomic.Int64
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	wg.Add(concurrency)
+	for range concurrency {
+		go func() {
+			defer wg.Done()
+			for claimed.Add(1) <= int64(n) {
+				if err := ctx.Err(); err != nil {
+					fail(err)
+					return
+				}
+				v, err := newFn(ctx)
+				if err != nil {
+					fail(err)
+					return
+				}
+				tp.putBack(v)
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// runPreHeat runs the WithPreHeat warmup.
+func (tp *TypedPool[T]) runPreHeat() {
+	ph := tp.cfg.preHeat
+	if err := tp.PreHeat(ph.ctx, ph.concurrency, ph.n); err != nil {
+		tp.cfg.log().Warn("pool pre-heat abandoned",
+			"pool", tp.cfg.name(),
+			"idle", tp.Len(),
+			"err", err,
+		)
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithPreHeatRunsInParallel(t *testing.T) {
+	const workers = 4
+	var running, peak atomic.Int64
+	var gate sync.WaitGroup
+	gate.Add(workers)
+
+	pool := NewTypedPool(func() *int {
+		n := running.Add(1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		gate.Done() // the first workers constructions wait for each other
+		gate.Wait()
+		running.Add(-1)
+		return new(int)
+	}, WithPreHeat[*int](context.Background(), workers, workers))
+
+	if got := pool.Len(); got != workers {
+		t.Fatalf("Len() = %d, want %d", got, workers)
+	}
+	if got := peak.Load(); got != workers {
+		t.Fatalf("%d constructors ran at once, want %d", got, workers)
+	}
+}
+
+func TestPreHeatFuncStopsOnError(t *testing.T) {
+	errDial := errors.New("dial failed")
+	pool := NewTypedPool(func() *int { return new(int) })
+
+	var calls atomic.Int64
+	err := pool.PreHeatFunc(context.Background(), 1, 10, func(context.Context) (*int, error) {
+		if calls.Add(1) == 3 {
+			return nil, errDial
+		}
+		return new(int), nil
+	})
+	if !errors.Is(err, errDial) {
+		t.Fatalf("PreHeatFunc = %v, want %v", err, errDial)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("constructor ran %d times after the failure, want 3 in total", got)
+	}
+	if got := pool.Len(); got != 2 {
+		t.Fatalf("Len() = %d, want the 2 items built before the error", got)
+	}
+}
+
+func TestPreHeatCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := NewTypedPool(func() *int { return new(int) })
+
+	var calls atomic.Int64
+	err := pool.PreHeatFunc(ctx, 2, 100, func(context.Context) (*int, error) {
+		if calls.Add(1) == 5 {
+			cancel()
+		}
+		return new(int), nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("PreHeatFunc = %v, want context.Canceled", err)
+	}
+	if got := pool.Len(); int64(got) != calls.Load() || got >= 100 {
+		t.Fatalf("Len() = %d after %d constructions, want every built item kept", got, calls.Load())
+	}
+}
+
+func TestWithPreHeatWarnsWhenAbandoned(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var out lockedBuffer
+
+	start := time.Now()
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithLogger[*int](NewLogger(&out)),
+		WithPreHeat[*int](ctx, 4, 1000),
+	)
+	if time.Since(start) > time.Second {
+		t.Fatal("a cancelled pre-heat still blocked NewTypedPool")
+	}
+	if pool.Len() != 0 {
+		t.Fatalf("Len() = %d, want 0", pool.Len())
+	}
+	if !strings.Contains(out.String(), "pool pre-heat abandoned") {
+		t.Fatalf("no warning logged: %q", out.String())
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"runtime/pprof"
+	"sync"
+)
+
+// WithProfileName runs the constructor under a "pool" pprof label set to name,
+// so CPU and goroutine profiles can tell one pool's allocations from another's.
+// The label is added to those of the context passed to GetContext, which the
+// goroutine has again once the constructor returns; other Gets carry none.
+//
+// Heap profiles group samples by call stack only and do not carry labels, so
+// the constructor also runs below a stack frame of the name's own,
+// profileFrameN, with N counting the names in the order pools first use them
+// and wrapping after profileFrameCount. A labeled CPU or goroutine profile
+// shows which frame a name has.
+func WithProfileName[T any](name string) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.profileName = name
+	}
+}
+
+// profiledNew wraps newFn so each call runs below the name's frame, with
+// the pool's profiler label added to ctx's.
+func profiledNew[T any](name string, newFn func() T) func(context.Context) T {
+	labels := pprof.Labels("pool", name)
+	frame := profileFrameOf(name)
+
+	return func(ctx context.Context) T {
+		var v T
+		pprof.Do(ctx, labels, func(context.Context) {
+			callFrame(frame, func() { v = newFn() })
+		})
+		return v
+	}
+}
+
+// newIn runs the constructor for a Get made with ctx, so WithProfileName
+// extends ctx's labels.
+func (tp *TypedPool[T]) newIn(ctx context.Context) T {
+	if tp.labeledNew != nil {
+		return tp.labeledNew(ctx)
+	}
+	return tp.newFn()
+}
+
+// profileFrames hands out the heap profile frames, one per name.
+var profileFrames struct {
+	mu     sync.Mutex
+	byName map[string]int
+}
+
+// profileFrameCount is the number of profileFrameN functions.
+const profileFrameCount = 8
+
+// profileFrameOf returns the frame for name, assigning the next one the
+// first time name is seen.
+func profileFrameOf(name string) int {
+	profileFrames.mu.Lock()
+	defer profileFrames.mu.Unlock()
+	if i, ok := profileFrames.byName[name]; ok {
+		return i
+	}
+	if profileFrames.byName == nil {
+		profileFrames.byName = make(map[string]int)
+	}
+	i := len(profileFrames.byName) % profileFrameCount
+	profileFrames.byName[name] = i
+	return i
+}
+
+// callFrame calls f below profileFrame<frame>.
+func callFrame(frame int, f func()) {
+	switch frame {
+	case 0:
+		profileFrame0(f)
+	case 1:
+		profileFrame1(f)
+	case 2:
+		profileFrame2(f)
+	case 3:
+		profileFrame3(f)
+	case 4:
+		profileFrame4(f)
+	case 5:
+		profileFrame5(f)
+	case 6:
+		profileFrame6(f)
+	default:
+		profileFrame7(f)
+	}
+}
+
+//go:noinline
+func profileFrame0(f func()) { f() }
+
+//go:noinline
+func profileFrame1(f func()) { f() }
+
+//go:noinline
+func profileFrame2(f func()) { f() }
+
+//go:noinline
+func profileFrame3(f func()) { f() }
+
+//go:noinline
+func profileFrame4(f func()) { f() }
+
+//go:noinline
+func profileFrame5(f func()) { f() }
+
+//go:noinline
+func profileFrame6(f func()) { f() }
+
+//go:noinline
+func profileFrame7(f func()) { f() }
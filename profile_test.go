@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+	"testing"
+)
+
+func TestWithProfileNameLabelsConstructor(t *testing.T) {
+	var profile bytes.Buffer
+
+	pool := NewTypedPool(func() int {
+		pprof.Lookup("goroutine").WriteTo(&profile, 1)
+		return 1
+	}, WithProfileName[int]("scratch"))
+
+	if got := pool.Get(); got != 1 {
+		t.Fatalf("got %d, want 1", got)
+	}
+	if !strings.Contains(profile.String(), `"pool":"scratch"`) {
+		t.Fatalf("goroutine profile taken inside New has no pool label:\n%s", profile.String())
+	}
+}
+
+func TestWithProfileNameExtendsCallerLabels(t *testing.T) {
+	var inside, after bytes.Buffer
+	pool := NewTypedPool(func() int {
+		pprof.Lookup("goroutine").WriteTo(&inside, 1)
+		return 1
+	}, WithProfileName[int]("scratch"))
+
+	pprof.Do(context.Background(), pprof.Labels("request", "42"), func(ctx context.Context) {
+		if _, err := pool.GetContext(ctx); err != nil {
+			t.Fatal(err)
+		}
+		pprof.Lookup("goroutine").WriteTo(&after, 1)
+	})
+
+	if !strings.Contains(inside.String(), `"pool":"scratch", "request":"42"`) {
+		t.Fatalf("goroutine profile taken inside New lacks the caller's label:\n%s", inside.String())
+	}
+	if !strings.Contains(after.String(), `labels: {"request":"42"}`) {
+		t.Fatalf("goroutine profile taken after Get lost the caller's label:\n%s", after.String())
+	}
+}
+
+// newProfiledBuffer is the constructor both pools of
+// TestWithProfileNameSeparatesHeapProfiles share.
+func newProfiledBuffer() *[]byte {
+	b := make([]byte, 4096)
+	return &b
+}
+
+func TestWithProfileNameSeparatesHeapProfiles(t *testing.T) {
+	defer func(rate int) { runtime.MemProfileRate = rate }(runtime.MemProfileRate)
+	runtime.MemProfileRate = 1
+
+	names := []string{"heap-a", "heap-b"}
+	for _, name := range names {
+		pool := NewTypedPool(newProfiledBuffer, WithProfileName[*[]byte](name))
+		for range 16 {
+			pool.Get()
+		}
+	}
+	runtime.GC()
+	runtime.GC()
+
+	var profile bytes.Buffer
+	pprof.Lookup("allocs").WriteTo(&profile, 1)
+	frames := make(map[string]bool)
+	for _, sample := range strings.Split(profile.String(), "\n\n") {
+		if !strings.Contains(sample, "newProfiledBuffer") {
+			continue
+		}
+		for i := range profileFrameCount {
+			if strings.Contains(sample, fmt.Sprintf(".profileFrame%d+", i)) {
+				frames[fmt.Sprint(i)] = true
+			}
+		}
+	}
+	for _, name := range names {
+		if !frames[fmt.Sprint(profileFrameOf(name))] {
+			t.Fatalf("no heap sample of %q under profileFrame%d; samples under: %v", name, profileFrameOf(name), frames)
+		}
+	}
+	if profileFrameOf(names[0]) == profileFrameOf(names[1]) {
+		t.Fatal("two names share a heap profile frame")
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// Promise is an item a Get is still fetching. It resolves at once for a pool
+// that never blocks, or once WithThrottledGet or a WithResourceGuard lets the
+// Get through.
+type Promise[T any] struct {
+	done   chan struct{}
+	cancel context.CancelFunc
+	none   T // what a failed Get returns
+
+	mu        sync.Mutex
+	abandoned error // why WaitContext gave up, if it has
+	v         T
+	err       error
+}
+
+// GetPromise starts a Get on the pool's Scheduler and returns a Promise for
+// its item, so the caller can do other work meanwhile. It is the future
+// counterpart of GetContext.
+func (tp *TypedPool[T]) GetPromise() *Promise[T] {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Promise[T]{done: make(chan struct{}), cancel: cancel, none: tp.noItem()}
+	tp.cfg.schedule(func() {
+		defer cancel()
+		v, err := tp.GetContext(ctx)
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if p.abandoned != nil {
+			// Nobody will collect the item, so it goes straight back.
+			if err == nil {
+				tp.Put(v)
+			}
+			v, err = p.none, p.abandoned
+		}
+		p.v, p.err = v, err
+		close(p.done)
+	})
+	return p
+}
+
+// Wait blocks until the promise resolves and returns the item. Like Get, it
+// panics if the Get failed for any reason but ErrAborted, including a
+// WaitContext having given up on it.
+func (p *Promise[T]) Wait() T {
+	<-p.done
+	if p.err != nil && p.err != ErrAborted {
+		panic(p.err)
+	}
+	return p.v
+}
+
+// WaitContext is Wait until ctx ends. It then gives up on the item, which is
+// put back if it still arrives, and returns ctx's error, as do later calls.
+// Like GetContext, it returns other failures rather than panicking.
+func (p *Promise[T]) WaitContext(ctx context.Context) (T, error) {
+	p.mu.Lock()
+	gaveUp := p.abandoned
+	p.mu.Unlock()
+	if gaveUp != nil {
+		return p.none, gaveUp
+	}
+
+	select {
+	case <-p.done:
+		return p.v, p.err
+	case <-ctx.Done():
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	select {
+	case <-p.done:
+		return p.v, p.err
+	default:
+	}
+	if p.abandoned == nil {
+		p.abandoned = ctx.Err()
+		p.cancel()
+	}
+	return p.none, p.abandoned
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// semGuard blocks Acquire until a slot is free or ctx ends.
+type semGuard chan struct{}
+
+func (g semGuard) Acquire(ctx context.Context) error {
+	select {
+	case g <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (g semGuard) Release() { <-g }
+
+func TestGetPromise(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) }, WithFIFO[*int]())
+	v := new(int)
+	pool.Put(v)
+
+	if got := pool.GetPromise().Wait(); got != v {
+		t.Fatal("Wait did not return the idle item")
+	}
+}
+
+func TestGetPromiseWaitsForGuard(t *testing.T) {
+	guard := make(semGuard, 1)
+	pool := NewTypedPool(func() *int { return new(int) }, WithResourceGuard[*int](guard), WithFIFO[*int]())
+	held := pool.Get()
+
+	p := pool.GetPromise()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := p.WaitContext(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("WaitContext = %v while the guard is full, want DeadlineExceeded", err)
+	}
+	if _, err := p.WaitContext(context.Background()); err != context.DeadlineExceeded {
+		t.Fatalf("second WaitContext = %v, want the first one's error", err)
+	}
+
+	pool.Put(held)
+	p = pool.GetPromise()
+	if got, err := p.WaitContext(context.Background()); err != nil || got != held {
+		t.Fatalf("WaitContext = %p, %v; want the released item", got, err)
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// goneAfter is how many collections a sync.Pool store must be seen to go
+// through before an item idle in it is sure to be gone: the first count may
+// be for a cycle that ended before the item was Put, the next moves it to
+// the victim cache and the one after drops it.
+const goneAfter = 3
+
+// gcGens counts collections for the side tables keyed by item address, with
+// one WithGCNotify canary shared by every pool. It is planted by the first
+// pool that needs it and runs for the life of the process.
+var gcGens struct {
+	once sync.Once
+	g    gcNotifier
+}
+
+// gcGen returns the number of collections counted so far.
+func gcGen() int64 {
+	gcGens.once.Do(func() {
+		gcGens.g.notify = func(int) {}
+		gcGens.g.plant()
+	})
+	return gcGens.g.n.Load()
+}
+
+// idleGen is embedded in side-table entries to note when their item went
+// idle.
+type idleGen struct {
+	idle atomic.Bool
+	gen  atomic.Int64
+}
+
+// setIdle notes whether the item is idle, as of the current collection.
+func (g *idleGen) setIdle(idle bool) {
+	if idle {
+		g.gen.Store(gcGen())
+	}
+	g.idle.Store(idle)
+}
+
+// gone reports whether the item has been idle since at least collection
+// cutoff.
+func (g *idleGen) gone(cutoff int64) bool {
+	return g.idle.Load() && g.gen.Load() <= cutoff
+}
+
+// lossyStore reports whether s drops idle items over GC cycles, as sync.Pool
+// does, so their side-table entries need pruning.
+func lossyStore[T any](s idleStore[T]) bool {
+	switch s := s.(type) {
+	case *syncStore[T]:
+		return true
+	case *hashStore[T]:
+		return lossyStore(s.buckets[0])
+	case customStore[T]:
+		_, ok := s.s.(*SyncPoolStore[T])
+		return ok
+	default:
+		return false
+	}
+}
+
+// pruneGone drops the side-table entries of idle items a lossy store has
+// lost to the GC, and takes them out of the idle counters. It runs when the store misses, but at most once per
+// collection, and only removes entries idle for goneAfter collections, so
+// the items other Ps still cache keep theirs. Other stores keep what they
+// are given, and discard cleans up after the items they give back.
+func (tp *TypedPool[T]) pruneGone() {
+	if !tp.lossy {
+		return
+	}
+	gen := gcGen()
+	last := tp.prunedGen.Load()
+	if gen == last || !tp.prunedGen.CompareAndSwap(last, gen) {
+		return
+	}
+	cutoff := gen - goneAfter
+	if tp.reuse != nil {
+		tp.reuse.prune(cutoff)
+	}
+	tp.pruneVersions(cutoff)
+	tp.pruneSums(cutoff)
+	tp.pruneMetadata(cutoff)
+	tp.pruneIdleSince(cutoff)
+	tp.clampToRecent(gen)
+}
+
+// recentPuts counts what a lossy store was given in each of the last
+// goneAfter collections. Anything idle in the store was Put in one of them,
+// so their totals bound the idle counters from above once the GC has
+// cleared older items, without ever counting fewer than are really there.
+type recentPuts struct {
+	mu    sync.Mutex // held to move a slot on to a new collection
+	slots [goneAfter]recentSlot
+}
+
+type recentSlot struct {
+	gen    atomic.Int64
+	n      atomic.Int64
+	weight atomic.Int64
+	bytes  atomic.Int64
+}
+
+// add counts an item of the given weight and size going idle.
+func (r *recentPuts) add(weight, bytes int64) {
+	gen := gcGen()
+	s := &r.slots[gen%goneAfter]
+	if s.gen.Load() != gen {
+		r.mu.Lock()
+		if s.gen.Load() != gen {
+			s.n.Store(0)
+			s.weight.Store(0)
+			s.bytes.Store(0)
+			s.gen.Store(gen)
+		}
+		r.mu.Unlock()
+	}
+	s.n.Add(1)
+	s.weight.Add(weight)
+	s.bytes.Add(bytes)
+}
+
+// totals sums the slots of the goneAfter collections up to gen.
+func (r *recentPuts) totals(gen int64) (n, weight, bytes int64) {
+	for i := range r.slots {
+		s := &r.slots[i]
+		if s.gen.Load() > gen-goneAfter {
+			n += s.n.Load()
+			weight += s.weight.Load()
+			bytes += s.bytes.Load()
+		}
+	}
+	return n, weight, bytes
+}
+
+// noteIdle records v going into a lossy store.
+func (tp *TypedPool[T]) noteIdle(v T) {
+	if tp.recent != nil {
+		tp.recent.add(tp.cfg.objectLimit.weightOf(v), tp.sizeOf(v))
+	}
+}
+
+// clampToRecent lowers the idle count, WithObjectCount share, WithObjectLimit
+// weight and retained bytes to what the store can still hold as of
+// collection gen, taking out the items the GC has cleared.
+func (tp *TypedPool[T]) clampToRecent(gen int64) {
+	n, weight, bytes := tp.recent.totals(gen)
+	if excess := clampTo(&tp.inPool, n); excess > 0 {
+		if oc := tp.cfg.objectCount; oc != nil {
+			if mine := clampTo(&oc.mine, n); mine > 0 {
+				oc.counter.Add(-mine)
+			}
+		}
+	}
+	if lim := tp.cfg.objectLimit; lim != nil {
+		clampTo(&lim.total, weight)
+	}
+	tp.stats.clampRetained(bytes)
+}
+
+// clampTo lowers v to at most max and returns how much it took off.
+func clampTo(v *atomic.Int64, max int64) int64 {
+	for {
+		cur := v.Load()
+		if cur <= max {
+			return 0
+		}
+		if v.CompareAndSwap(cur, max) {
+			return cur - max
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// collect runs the GC until the shared canary has counted n more cycles.
+func collect(t *testing.T, n int64) {
+	t.Helper()
+	want := gcGen() + n
+	waitFor(t, func() bool {
+		runtime.GC()
+		return gcGen() >= want
+	})
+}
+
+func entries(m *sync.Map) int {
+	n := 0
+	m.Range(func(_, _ any) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+func TestPruneGoneWaitsForItemsToBeCollected(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithOnReuse(func(*int, int) {}),
+	)
+	v := pool.Get()
+	pool.Put(v)
+
+	collect(t, 1)
+	pool.pruneGone()
+	if n := entries(&pool.reuse.m); n != 1 {
+		t.Fatalf("after one collection, %d reuse entries, want 1", n)
+	}
+
+	collect(t, goneAfter)
+	pool.pruneGone()
+	if n := entries(&pool.reuse.m); n != 0 {
+		t.Fatalf("after %d collections, %d reuse entries, want 0", goneAfter+1, n)
+	}
+}
+
+func TestPruneGoneSkipsExactStores(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithFIFO[*int](),
+		WithOnReuse(func(*int, int) {}),
+	)
+	v := pool.Get()
+	pool.Put(v)
+
+	collect(t, goneAfter)
+	pool.pruneGone()
+	if n := entries(&pool.reuse.m); n != 1 {
+		t.Fatalf("%d reuse entries for an item still in a FIFO store, want 1", n)
+	}
+}
+
+func TestPruneGoneDropsVersionStamps(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithObjectPoolVersion[*int](1),
+	)
+	v := pool.Get()
+	pool.Put(v)
+	pool.pruneGone()
+	if n := entries(&pool.versions.m); n != 1 {
+		t.Fatalf("%d version stamps, want 1", n)
+	}
+
+	collect(t, goneAfter)
+	pool.pruneGone()
+	if n := entries(&pool.versions.m); n != 0 {
+		t.Fatalf("after %d collections, %d version stamps, want 0", goneAfter, n)
+	}
+}
+
+func TestMissKeepsCountsUntilCollected(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) }, WithMaxItems[*int](10))
+	a, b := pool.Get(), pool.Get()
+	pool.Put(a)
+	pool.Put(b)
+
+	// Take the items behind the pool's back, as other Ps' caches would
+	// hold them out of this P's reach.
+	pool.pool.get()
+	pool.pool.get()
+	pool.Get()
+	if n := pool.Len(); n != 2 {
+		t.Fatalf("after a miss with no collection, Len = %d, want 2", n)
+	}
+
+	collect(t, goneAfter)
+	pool.Get()
+	if n := pool.Len(); n != 0 {
+		t.Fatalf("after %d collections, Len = %d, want 0", goneAfter, n)
+	}
+}
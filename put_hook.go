@@ -0,0 +1,38 @@
+package main
+
+import "reflect"
+
+// WithPutHook lets fn replace each item on its way into the pool, after
+// WithReset and every check that could turn it away, such as shrinking an
+// oversized slice to a sensible capacity. The item fn returns is the one
+// pooled; returning the zero value discards the original instead. An item
+// fn replaces is left to fn, without passing through OnDiscard. fn must
+// not call back into the pool.
+func WithPutHook[T any](fn func(T) T) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.putHook = fn
+	}
+}
+
+// runPutHook passes v through the WithPutHook function and returns the item
+// to pool, or false if there is none. v's WithObjectLimit weight and
+// WithObjectCount count have been admitted; the weight is exchanged for the
+// replacement's.
+func (tp *TypedPool[T]) runPutHook(v T) (T, bool) {
+	out := tp.cfg.putHook(v)
+	if reflect.ValueOf(&out).Elem().IsZero() {
+		tp.releaseWeight(v)
+		tp.releaseCount()
+		tp.drop(v)
+		return out, false
+	}
+	if lim := tp.cfg.objectLimit; lim != nil {
+		tp.releaseWeight(v)
+		if !tp.admitWeight(lim.weightOf(out)) {
+			tp.releaseCount()
+			tp.drop(out)
+			return out, false
+		}
+	}
+	return out, true
+}
@@ -0,0 +1,34 @@
+package main
+
+import "testing"
+
+func TestPutHook(t *testing.T) {
+	discarded := 0
+	pool := NewTypedPool(func() []byte { return make([]byte, 0, 64) },
+		WithPutHook(func(b []byte) []byte {
+			switch {
+			case cap(b) > 1024:
+				return make([]byte, 0, 64)
+			case cap(b) == 0:
+				return nil
+			}
+			return b
+		}),
+		WithOnDiscard(func([]byte) { discarded++ }),
+		WithStats[[]byte](),
+		WithFIFO[[]byte](),
+	)
+
+	pool.Put(make([]byte, 0, 1<<20))
+	if got := pool.Get(); cap(got) != 64 {
+		t.Fatalf("cap = %d, want the oversized buffer swapped for a 64-byte one", cap(got))
+	}
+
+	pool.Put([]byte{})
+	if discarded != 1 {
+		t.Fatalf("OnDiscard ran %d times, want once for the item the hook refused", discarded)
+	}
+	if s := pool.Stats(); s.Discards != 1 || s.Puts != 2 {
+		t.Fatalf("Stats = %+v, want 1 discard of 2 puts", s)
+	}
+}
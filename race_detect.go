@@ -0,0 +1,61 @@
+//go:build race
+
+package main
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// WithNewRaceDetect serializes calls to the constructor in builds with the
+// race detector, and logs a warning on the pool's Logger, with the stacks
+// of both goroutines, whenever one call has to wait for another. A
+// constructor that touches shared state, such as a counter captured by the
+// closure, races exactly when calls overlap, so the warning points at the
+// callers worth a look even on runs where the detector misses the race
+// itself. Without the race detector it does nothing.
+func WithNewRaceDetect[T any]() PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.newRaceDetect = true
+	}
+}
+
+// raceDetectNew wraps newFn for WithNewRaceDetect. Every call records its
+// stack so that an overlapping one can report it; this build is for
+// debugging, so the cost does not matter.
+func (cfg *poolConfig[T]) raceDetectNew(newFn func() T) func() T {
+	if !cfg.newRaceDetect {
+		return newFn
+	}
+	var (
+		mu      sync.Mutex
+		running atomic.Pointer[string] // the stack of the call in progress
+	)
+	return func() T {
+		if !mu.TryLock() {
+			waiting := callStack()
+			var other string
+			if s := running.Load(); s != nil {
+				other = *s
+			}
+			cfg.log().Warn("pool constructor called concurrently",
+				"pool", cfg.name(),
+				"running", other,
+				"waiting", waiting,
+			)
+			mu.Lock()
+		}
+		defer mu.Unlock()
+
+		s := callStack()
+		running.Store(&s)
+		return newFn()
+	}
+}
+
+// callStack returns the current goroutine's stack.
+func callStack() string {
+	buf := make([]byte, 4096)
+	return string(buf[:runtime.Stack(buf, false)])
+}
@@ -0,0 +1,15 @@
+//go:build !race
+
+package main
+
+// WithNewRaceDetect serializes calls to the constructor and reports the
+// ones that overlap, in builds with the race detector. Without it it does
+// nothing.
+func WithNewRaceDetect[T any]() PoolOption[T] {
+	return func(*poolConfig[T]) {}
+}
+
+// raceDetectNew returns newFn unchanged outside race builds.
+func (cfg *poolConfig[T]) raceDetectNew(newFn func() T) func() T {
+	return newFn
+}
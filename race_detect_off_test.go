@@ -0,0 +1,17 @@
+//go:build !race
+
+package main
+
+import "testing"
+
+func TestNewRaceDetectIsNoOp(t *testing.T) {
+	var cfg poolConfig[*int]
+	WithNewRaceDetect[*int]()(&cfg)
+	newFn := func() *int { return new(int) }
+	if cfg.raceDetectNew(newFn)() == nil {
+		t.Fatal("raceDetectNew broke the constructor")
+	}
+	if cfg.newRaceDetect {
+		t.Fatal("WithNewRaceDetect changed the config without the race detector")
+	}
+}
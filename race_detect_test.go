@@ -0,0 +1,38 @@
+//go:build race
+
+package main
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestNewRaceDetectSerializesAndWarns(t *testing.T) {
+	var out lockedBuffer
+	entered, release := make(chan struct{}, 2), make(chan struct{})
+	built := 0 // unguarded: only safe because calls are serialized
+	pool := NewTypedPool(func() *int {
+		built++
+		entered <- struct{}{}
+		<-release
+		return new(int)
+	}, WithNewRaceDetect[*int](), WithLogger[*int](NewLogger(&out)))
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); pool.Get() }()
+	<-entered
+	go func() { defer wg.Done(); pool.Get() }()
+
+	waitFor(t, func() bool { return strings.Contains(out.String(), "pool constructor called concurrently") })
+	close(release)
+	wg.Wait()
+
+	if built != 2 {
+		t.Fatalf("built %d items, want 2", built)
+	}
+	if !strings.Contains(out.String(), "TestNewRaceDetectSerializesAndWarns") {
+		t.Fatalf("warning does not show the goroutines' stacks:\n%s", out.String())
+	}
+}
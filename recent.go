@@ -0,0 +1,82 @@
+package main
+
+import (
+	"io"
+	"sync"
+)
+
+// WithRingBuffer keeps a copy of the last n rendered lines in memory,
+// including lines below the Logger's level, for DumpRecent to write out after
+// a crash. Lines below the level are then rendered too, though never written,
+// which costs the pooled buffer and the clock read that filtering otherwise
+// skips.
+func WithRingBuffer(n int) LoggerOption {
+	return func(l *Logger) {
+		if n > 0 {
+			l.recent = &recentRing{lines: make([][]byte, n)}
+		} else {
+			l.recent = nil
+		}
+	}
+}
+
+// maxRecentLine is the largest slot capacity the ring keeps after a line is
+// overwritten, so one huge line does not stay pinned.
+const maxRecentLine = 4 << 10
+
+// recentRing is a fixed ring of line copies. Each slot keeps its backing
+// array, so once the ring has gone around, recording a line only copies
+// bytes. A single mutex guards it; it is held for a memcpy and is off the
+// write path's syscall.
+type recentRing struct {
+	mu    sync.Mutex
+	lines [][]byte
+	next  int
+	full  bool
+}
+
+// record copies line into the oldest slot. The caller's buffer goes back to
+// the pool, so it cannot be aliased.
+func (r *recentRing) record(line []byte) {
+	r.mu.Lock()
+	slot := r.lines[r.next]
+	if cap(slot) > maxRecentLine {
+		slot = nil
+	}
+	r.lines[r.next] = append(slot[:0], line...)
+	r.next++
+	if r.next == len(r.lines) {
+		r.next = 0
+		r.full = true
+	}
+	r.mu.Unlock()
+}
+
+// DumpRecent writes the lines kept by WithRingBuffer to w, oldest first.
+// It can run while other goroutines log: the lines are copied out under the
+// ring's lock, so each is whole, and w is written after the lock is released.
+// Without a ring it writes nothing.
+func (l *Logger) DumpRecent(w io.Writer) error {
+	r := l.recent
+	if r == nil {
+		return nil
+	}
+
+	b := buffPool.Get()
+	b.Reset()
+
+	r.mu.Lock()
+	if r.full {
+		for _, line := range r.lines[r.next:] {
+			b.Write(line)
+		}
+	}
+	for _, line := range r.lines[:r.next] {
+		b.Write(line)
+	}
+	r.mu.Unlock()
+
+	err := writeFull(w, b.Bytes())
+	releaseWritten(b)
+	return err
+}
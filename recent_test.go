@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRingBufferWraparound(t *testing.T) {
+	var out bytes.Buffer
+	logger := NewLogger(&out, WithTimeLayout(""), WithRingBuffer(3))
+
+	for i := range 5 {
+		logger.Info("line", "i", i)
+	}
+
+	var dump bytes.Buffer
+	if err := logger.DumpRecent(&dump); err != nil {
+		t.Fatal(err)
+	}
+	want := "INFO : line i=2\nINFO : line i=3\nINFO : line i=4\n"
+	if dump.String() != want {
+		t.Fatalf("DumpRecent =\n%q\nwant\n%q", dump.String(), want)
+	}
+}
+
+func TestRingBufferKeepsSuppressedLines(t *testing.T) {
+	var out bytes.Buffer
+	logger := NewLogger(&out, WithTimeLayout(""), WithRingBuffer(10))
+
+	logger.Debug("hidden")
+	logger.Debugf("hidden %d", 2)
+	logger.Info("shown")
+
+	if got, want := out.String(), "INFO : shown\n"; got != want {
+		t.Fatalf("writer got %q, want %q", got, want)
+	}
+	var dump bytes.Buffer
+	logger.DumpRecent(&dump)
+	if got, want := dump.String(), "DEBUG : hidden\nDEBUG : hidden 2\nINFO : shown\n"; got != want {
+		t.Fatalf("DumpRecent = %q, want %q", got, want)
+	}
+}
+
+func TestRingBufferDisabled(t *testing.T) {
+	logger := NewLogger(io.Discard)
+	logger.Info("x")
+
+	var dump bytes.Buffer
+	if err := logger.DumpRecent(&dump); err != nil || dump.Len() != 0 {
+		t.Fatalf("DumpRecent = %q, %v; want nothing", dump.String(), err)
+	}
+}
+
+func TestRingBufferConcurrentDump(t *testing.T) {
+	const (
+		writers = 8
+		lines   = 500
+	)
+	logger := NewLogger(io.Discard, WithTimeLayout(""), WithRingBuffer(100))
+	payload := strings.Repeat("x", 200)
+
+	var wg sync.WaitGroup
+	wg.Add(writers)
+	for g := range writers {
+		go func() {
+			defer wg.Done()
+			for i := range lines {
+				logger.Info(payload, "g", g, "i", i)
+			}
+		}()
+	}
+
+	check := func(dump string) {
+		t.Helper()
+		for _, line := range strings.Split(strings.TrimSuffix(dump, "\n"), "\n") {
+			var g, i int
+			if _, err := fmt.Sscanf(line, "INFO : "+payload+" g=%d i=%d", &g, &i); err != nil {
+				t.Fatalf("torn line %q: %v", line, err)
+			}
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+		}
+		var dump bytes.Buffer
+		logger.DumpRecent(&dump)
+		if dump.Len() > 0 {
+			check(dump.String())
+		}
+	}
+
+	var dump bytes.Buffer
+	logger.DumpRecent(&dump)
+	if n := strings.Count(dump.String(), "\n"); n != 100 {
+		t.Fatalf("DumpRecent kept %d lines, want 100", n)
+	}
+	check(dump.String())
+}
@@ -0,0 +1,57 @@
+package main
+
+// WithRecoveryPool gives the pool a second level: Puts refused because the
+// pool holds its WithMaxItems limit go to rp instead of being discarded, and
+// a Get that finds the pool empty takes an idle item from rp before
+// constructing one. rp's own limits decide what it keeps, and an item taken
+// from it counts as a hit in both pools. rp must not lead back to the
+// primary pool through its own WithRecoveryPool.
+func WithRecoveryPool[T any](rp *TypedPool[T]) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.recovery = rp
+	}
+}
+
+// spill Puts v into the recovery pool if the primary pool refused it for
+// being full. It reports whether v was taken.
+func (tp *TypedPool[T]) spill(v T) bool {
+	rp := tp.cfg.recovery
+	if rp == nil || !tp.full() {
+		return false
+	}
+	rp.put(v)
+	return true
+}
+
+// rescue takes an idle item holding at least hint from the recovery pool,
+// or failing that from the WithStackedPools levels in order.
+func (tp *TypedPool[T]) rescue(hint int) (T, bool) {
+	if rp := tp.cfg.recovery; rp != nil {
+		if item, ok := tp.takeFrom(rp, hint); ok {
+			return item, true
+		}
+	}
+	for _, sp := range tp.cfg.stacked {
+		if item, ok := tp.takeFrom(sp, hint); ok {
+			return item, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// takeFrom takes an idle item holding at least hint from rp, counting it as
+// a hit there.
+func (tp *TypedPool[T]) takeFrom(rp *TypedPool[T], hint int) (T, bool) {
+	item, ok := rp.takeIdle()
+	if !ok {
+		return item, false
+	}
+	if !tp.cfg.sizeHint.fits(item, hint) {
+		rp.put(item)
+		return item, false
+	}
+	rp.stats.hit()
+	rp.audit(auditGet, item)
+	return item, true
+}
@@ -0,0 +1,52 @@
+package main
+
+import "testing"
+
+func TestRecoveryPoolTakesOverflow(t *testing.T) {
+	spill := NewTypedPool(func() *int { return new(int) }, WithFIFO[*int](), WithStats[*int]())
+	news := 0
+	pool := NewTypedPool(func() *int { news++; return new(int) },
+		WithMaxItems[*int](1), WithRecoveryPool(spill), WithFIFO[*int](), WithStats[*int]())
+
+	a, b := pool.Get(), pool.Get()
+	pool.Put(a)
+	pool.Put(b)
+	if got := spill.Stats().Puts; got != 1 {
+		t.Fatalf("recovery pool Puts = %d, want 1", got)
+	}
+	if got := pool.Stats().Discards; got != 0 {
+		t.Fatalf("primary discards = %d, want 0", got)
+	}
+
+	if got := pool.Get(); got != a {
+		t.Fatal("first Get did not come from the primary pool")
+	}
+	if got := pool.Get(); got != b {
+		t.Fatal("Get on an empty primary pool did not rescue the spilled item")
+	}
+	if news != 2 {
+		t.Fatalf("constructor calls = %d, want 2", news)
+	}
+	if s := pool.Stats(); s.Hits != 2 || s.Misses != 2 {
+		t.Fatalf("primary hits, misses = %d, %d; want 2, 2", s.Hits, s.Misses)
+	}
+	if got := spill.Stats().Hits; got != 1 {
+		t.Fatalf("recovery pool hits = %d, want 1", got)
+	}
+
+	pool.Get()
+	if news != 3 {
+		t.Fatalf("constructor calls = %d after both pools ran dry, want 3", news)
+	}
+}
+
+func TestRecoveryPoolOnlyTakesCapacityOverflow(t *testing.T) {
+	spill := NewTypedPool(func() *int { return new(int) }, WithFIFO[*int](), WithStats[*int]())
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithSingletonGet[*int](), WithRecoveryPool(spill), WithFIFO[*int]())
+
+	pool.Put(pool.Get())
+	if got := spill.Stats().Puts; got != 0 {
+		t.Fatalf("recovery pool Puts = %d for a singleton discard, want 0", got)
+	}
+}
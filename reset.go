@@ -0,0 +1,28 @@
+package main
+
+// WithReset calls fn on every item the pool accepts back, before it is
+// stored, so the next Get receives it clean. Items the pool discards are
+// passed to the OnDiscard hook as they were. cmd/poolgen generates reset
+// functions for struct types, with a constructor that sets this option.
+func WithReset[T any](fn func(T)) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.reset = fn
+	}
+}
+
+// Resettable is an item that can clear itself for reuse, as bytes.Buffer
+// and strings.Builder do.
+type Resettable interface {
+	Reset()
+}
+
+// WithAutoReset calls Reset on every recycled item as Get hands it out, so
+// any pool of a Resettable type gets clean items without a reset function
+// of its own. The constraint checks at compile time that T has the method,
+// and the call is direct. Newly constructed items are not reset. Unlike
+// WithReset, idle items keep their contents until they are reused.
+func WithAutoReset[T Resettable]() PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.resetOnGet = T.Reset
+	}
+}
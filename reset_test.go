@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestResetRunsOnAcceptedPuts(t *testing.T) {
+	var discarded []string
+	pool := NewTypedPool(func() *bytes.Buffer { return new(bytes.Buffer) },
+		WithFIFO[*bytes.Buffer](),
+		WithMaxItems[*bytes.Buffer](1),
+		WithReset((*bytes.Buffer).Reset),
+		WithOnDiscard(func(b *bytes.Buffer) { discarded = append(discarded, b.String()) }),
+	)
+
+	a, b := pool.Get(), pool.Get()
+	a.WriteString("kept")
+	b.WriteString("dropped")
+	pool.Put(a)
+	pool.Put(b)
+
+	if got := pool.Get(); got != a || got.Len() != 0 {
+		t.Fatalf("Get = %q (same item %v), want the reset first buffer", got, got == a)
+	}
+	if len(discarded) != 1 || discarded[0] != "dropped" {
+		t.Fatalf("discarded = %q, want the second buffer untouched", discarded)
+	}
+}
+
+func TestAutoResetOnGet(t *testing.T) {
+	pool := NewTypedPool(func() *bytes.Buffer { return bytes.NewBufferString("new") },
+		WithFIFO[*bytes.Buffer](),
+		WithAutoReset[*bytes.Buffer](),
+	)
+
+	b := pool.Get()
+	if b.String() != "new" {
+		t.Fatalf("new item = %q, want it left as constructed", b)
+	}
+	b.WriteString(" and used")
+	pool.Put(b)
+	if got := pool.Get(); got != b || got.Len() != 0 {
+		t.Fatalf("recycled item = %q (same %v), want the reset buffer", got, got == b)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import "errors"
+
+// ErrInvalidCapacity is returned by Resize for a capacity below 1.
+var ErrInvalidCapacity = errors.New("pool: capacity must be at least 1")
+
+// Resize changes how many idle objects the pool retains. Shrinking discards
+// the oldest idle objects beyond newMax through the OnDiscard hook; growing
+// warms up the added capacity. The change is atomic with respect to
+// concurrent Gets and Puts, while the warm-up after growing is not.
+func (bp *BoundedPool[T]) Resize(newMax int) error {
+	if newMax < 1 {
+		return ErrInvalidCapacity
+	}
+
+	bp.mu.Lock()
+	old := bp.idle.capacity()
+	var freed int64
+	for bp.idle.len() > newMax {
+		it, _ := bp.idle.popFront()
+		bp.discard(it.v)
+		freed += bp.forget(it)
+	}
+	bp.idle.resize(newMax)
+	bp.mu.Unlock()
+
+	bp.release(freed)
+	if newMax > old {
+		bp.Warmup(newMax - old)
+	}
+	return nil
+}
+
+// Resize changes how many objects the pool circulates. Growing constructs
+// the new objects and wakes waiting Gets. Shrinking discards idle objects
+// through the OnDiscard hook right away and checked-out ones as they are
+// Put, until only newMax remain.
+func (fp *FixedPool[T]) Resize(newMax int) error {
+	if newMax < 1 {
+		return ErrInvalidCapacity
+	}
+
+	fp.mu.Lock()
+	var discarded []T
+	if newMax > fp.max {
+		fp.grow(newMax)
+	} else {
+		fp.max = newMax
+		fp.markOver()
+		for fp.size > newMax && fp.idle.len() > 0 {
+			it, _ := fp.idle.popFront()
+			discarded = append(discarded, it.v)
+			fp.size--
+		}
+		for fp.size > newMax {
+			v, ok := fp.free.take()
+			if !ok {
+				break
+			}
+			discarded = append(discarded, v)
+			fp.size--
+		}
+		fp.idle.resize(newMax)
+	}
+	fp.markOver()
+	fp.mu.Unlock()
+
+	for _, v := range discarded {
+		fp.discard(v)
+	}
+	return nil
+}
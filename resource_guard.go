@@ -0,0 +1,31 @@
+package main
+
+import "context"
+
+// ResourceGuard is a policy enforced at the pool's boundary, such as a rate
+// limit, an authorization check or a quota. Acquire runs before each Get
+// and may refuse it by returning an error; Release runs before each Put.
+type ResourceGuard interface {
+	Acquire(ctx context.Context) error
+	Release()
+}
+
+// WithResourceGuard has every Get acquire guard before it is served and
+// every Put release it. A refused Get returns the error from TryGet and
+// GetContext; Get, like a Get shed by WithAbortOnGet, returns the
+// WithItemSentinel value or the zero value. The pool's own fills and
+// re-pools, as in Warmup, PreHeat and HealthCheck, neither acquire nor
+// release. guard must not call back into the pool.
+func WithResourceGuard[T any](guard ResourceGuard) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.guard = guard
+	}
+}
+
+// acquire runs the WithResourceGuard Acquire for a Get.
+func (tp *TypedPool[T]) acquire(ctx context.Context) error {
+	if g := tp.cfg.guard; g != nil {
+		return g.Acquire(ctx)
+	}
+	return nil
+}
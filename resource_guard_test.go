@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// quotaGuard admits up to limit items checked out at once.
+type quotaGuard struct {
+	out, limit int
+}
+
+var errOverQuota = errors.New("over quota")
+
+func (g *quotaGuard) Acquire(context.Context) error {
+	if g.out == g.limit {
+		return errOverQuota
+	}
+	g.out++
+	return nil
+}
+
+func (g *quotaGuard) Release() { g.out-- }
+
+func TestResourceGuard(t *testing.T) {
+	guard := &quotaGuard{limit: 2}
+	pool := NewTypedPool(func() *int { return new(int) }, WithResourceGuard[*int](guard), WithFIFO[*int]())
+
+	pool.Warmup(3)
+	if guard.out != 0 {
+		t.Fatalf("Warmup moved the guard to %d", guard.out)
+	}
+
+	a, _ := pool.TryGet()
+	pool.Get()
+	if _, err := pool.TryGet(); err != errOverQuota {
+		t.Fatalf("TryGet over quota = %v, want errOverQuota", err)
+	}
+	if v := pool.Get(); v != nil {
+		t.Fatalf("Get over quota = %p, want nil", v)
+	}
+
+	pool.Put(a)
+	if _, err := pool.GetContext(context.Background()); err != nil {
+		t.Fatalf("GetContext after a Put: %v", err)
+	}
+}
+
+func TestResourceGuardReleasesFailedGet(t *testing.T) {
+	guard := &quotaGuard{limit: 1}
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithResourceGuard[*int](guard), WithConstructorBudget[*int](0))
+
+	if _, err := pool.TryGet(); err != ErrBudgetExhausted {
+		t.Fatalf("TryGet = %v, want ErrBudgetExhausted", err)
+	}
+	if guard.out != 0 {
+		t.Fatalf("guard holds %d after a failed Get, want 0", guard.out)
+	}
+}
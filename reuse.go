@@ -0,0 +1,141 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrRetire is what a WithReuseCheck function returns to have an item
+// discarded instead of reused.
+var ErrRetire = errors.New("pool: item retired")
+
+// WithOnReuse calls fn on every Get served by a recycled item, with the
+// number of times the item has now been reused: 1 the first time it comes
+// back. Counts live in a side table keyed by address, so only pointer-like
+// item types are tracked, and they are approximate for items the GC clears.
+func WithOnReuse[T any](fn func(v T, reuseCount int)) PoolOption[T] {
+	return WithReuseCheck(func(v T, reuseCount int) error {
+		fn(v, reuseCount)
+		return nil
+	})
+}
+
+// WithReuseCheck is WithOnReuse for items that wear out: when fn returns an
+// error, conventionally ErrRetire, the item is discarded, passing through
+// the OnDiscard hook, and Get moves on to the next idle item or the
+// constructor.
+func WithReuseCheck[T any](fn func(v T, reuseCount int) error) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.onReuse = fn
+	}
+}
+
+// WithItemReuseLimit retires items that have been reused maxReuse times:
+// Put discards them, through the OnDiscard hook, and a later Get constructs a
+// replacement, so no item is handed out more than maxReuse+1 times. This is
+// a fixed lifetime like an HTTP/1.1 keep-alive connection's request limit.
+// It uses the WithOnReuse counts, so only pointer-like item types are
+// retired. It panics if maxReuse is less than 1.
+func WithItemReuseLimit[T any](maxReuse int) PoolOption[T] {
+	if maxReuse < 1 {
+		panic("WithItemReuseLimit: maxReuse must be at least 1")
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.reuseLimit = maxReuse
+	}
+}
+
+// reuseEntry is one item's reuse count.
+type reuseEntry struct {
+	idleGen
+	n    atomic.Int64
+	item atomic.Pointer[any] // the idle item, under WithRecordHotObjects
+}
+
+// reuseCounts is the WithOnReuse side table.
+type reuseCounts struct {
+	m sync.Map // uintptr -> *reuseEntry
+}
+
+// constructed starts a new item at zero, replacing any entry left at its
+// address by an item the GC cleared.
+func (r *reuseCounts) constructed(id uintptr) {
+	if id != 0 {
+		r.m.Store(id, new(reuseEntry))
+	}
+}
+
+// reused counts a Get of a recycled item and returns its new count.
+func (r *reuseCounts) reused(id uintptr) int {
+	v, _ := r.m.LoadOrStore(id, new(reuseEntry))
+	e := v.(*reuseEntry)
+	e.setIdle(false)
+	e.item.Store(nil)
+	return int(e.n.Add(1))
+}
+
+// count returns the item's reuse count so far.
+func (r *reuseCounts) count(id uintptr) int {
+	v, ok := r.m.Load(id)
+	if !ok {
+		return 0
+	}
+	return int(v.(*reuseEntry).n.Load())
+}
+
+// pooled marks the item idle.
+func (r *reuseCounts) pooled(id uintptr) {
+	if id == 0 {
+		return
+	}
+	v, _ := r.m.LoadOrStore(id, new(reuseEntry))
+	v.(*reuseEntry).setIdle(true)
+}
+
+// prune drops the entries of items idle since collection cutoff.
+func (r *reuseCounts) prune(cutoff int64) {
+	r.m.Range(func(key, value any) bool {
+		if value.(*reuseEntry).gone(cutoff) {
+			r.m.Delete(key)
+		}
+		return true
+	})
+}
+
+// forget drops a discarded item.
+func (r *reuseCounts) forget(id uintptr) {
+	if id != 0 {
+		r.m.Delete(id)
+	}
+}
+
+// checkReuse runs the reuse hook on a recycled item and reports whether Get
+// may return it.
+func (tp *TypedPool[T]) checkReuse(v T) bool {
+	if tp.reuse == nil {
+		return true
+	}
+	id := uintptr(itemIdentity(v))
+	if id == 0 {
+		return true
+	}
+	n := tp.reuse.reused(id)
+	if tp.cfg.onReuse == nil {
+		return true
+	}
+	if err := tp.cfg.onReuse(v, n); err != nil {
+		tp.discard(v)
+		return false
+	}
+	return true
+}
+
+// worn reports whether v has reached its WithItemReuseLimit.
+func (tp *TypedPool[T]) worn(v T) bool {
+	if tp.cfg.reuseLimit == 0 {
+		return false
+	}
+	id := uintptr(itemIdentity(v))
+	return id != 0 && tp.reuse.count(id) >= tp.cfg.reuseLimit
+}
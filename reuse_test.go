@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"testing"
+)
+
+func TestWithOnReuseCounts(t *testing.T) {
+	type call struct {
+		v *int
+		n int
+	}
+	var calls []call
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithFIFO[*int](),
+		WithOnReuse(func(v *int, n int) { calls = append(calls, call{v, n}) }),
+	)
+
+	v := pool.Get()
+	if len(calls) != 0 {
+		t.Fatalf("hook ran for a new item: %v", calls)
+	}
+	for range 3 {
+		pool.Put(v)
+		if got := pool.Get(); got != v {
+			t.Fatal("FIFO pool did not return the pooled item")
+		}
+	}
+
+	want := []call{{v, 1}, {v, 2}, {v, 3}}
+	if len(calls) != len(want) {
+		t.Fatalf("hook calls = %v, want %v", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Fatalf("hook calls = %v, want %v", calls, want)
+		}
+	}
+}
+
+func TestWithReuseCheckRetires(t *testing.T) {
+	var discarded []*bufio.Writer
+	pool := NewTypedPool(func() *bufio.Writer { return bufio.NewWriter(io.Discard) },
+		WithFIFO[*bufio.Writer](),
+		WithReuseCheck(func(_ *bufio.Writer, n int) error {
+			if n > 2 {
+				return ErrRetire
+			}
+			return nil
+		}),
+		WithOnDiscard(func(w *bufio.Writer) { discarded = append(discarded, w) }),
+	)
+
+	w := pool.Get()
+	pool.Put(w)
+	pool.Get() // reuse 1
+	pool.Put(w)
+	pool.Get() // reuse 2
+	pool.Put(w)
+
+	fresh := pool.Get() // reuse 3 retires w
+	if fresh == w {
+		t.Fatal("Get returned the retired item")
+	}
+	if len(discarded) != 1 || discarded[0] != w {
+		t.Fatalf("discarded %v, want the retired item", discarded)
+	}
+
+	pool.Put(fresh)
+	pool.Get()
+	pool.Put(fresh)
+	if got := pool.Get(); got != fresh {
+		t.Fatal("the new item was retired early: its count was not reset")
+	}
+}
+
+func TestItemReuseLimitRetiresOnPut(t *testing.T) {
+	var discarded []*int
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithFIFO[*int](),
+		WithStats[*int](),
+		WithItemReuseLimit[*int](2),
+		WithOnDiscard(func(v *int) { discarded = append(discarded, v) }),
+	)
+
+	v := pool.Get()
+	for reuse := 1; reuse <= 2; reuse++ {
+		pool.Put(v)
+		if got := pool.Get(); got != v {
+			t.Fatalf("reuse %d: Get returned a different item", reuse)
+		}
+	}
+	pool.Put(v) // reused twice: retired here
+	if len(discarded) != 1 || discarded[0] != v {
+		t.Fatalf("discarded %v, want the worn item", discarded)
+	}
+	if pool.Len() != 0 {
+		t.Fatalf("Len = %d after retiring, want 0", pool.Len())
+	}
+
+	fresh := pool.Get()
+	if fresh == v {
+		t.Fatal("Get returned the retired item")
+	}
+	pool.Put(fresh)
+	if got := pool.Get(); got != fresh {
+		t.Fatal("the replacement was retired early: its count was not reset")
+	}
+	if s := pool.Stats(); s.Misses != 2 || s.Discards != 1 {
+		t.Fatalf("Stats = %+v, want 2 misses and 1 discard", s)
+	}
+}
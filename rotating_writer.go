@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// RotatingWriter appends to a file and rotates it by size: once a write
+// would take the file past maxSize, path is renamed to path.1, path.1 to
+// path.2 and so on, the oldest beyond keep is removed, and a fresh path is
+// started. Each Write is one record and lands whole in a single file; a
+// record larger than maxSize gets a file of its own.
+//
+// Writes are staged in a pooled buffer and reach the file once 32 KiB have
+// accumulated, before a rotation, or on Flush, Sync or Close, so a Logger
+// on top of it should be flushed or closed before exit. It is safe for
+// concurrent use.
+type RotatingWriter struct {
+	path    string
+	maxSize int64
+	keep    int
+
+	mu     sync.Mutex
+	f      *os.File
+	size   int64         // bytes in the current file, staged ones included
+	buf    *bytes.Buffer // nil while nothing is staged
+	closed bool
+}
+
+// NewRotatingWriter opens path for appending, creating it if need be, and
+// returns a RotatingWriter that keeps up to keep rotated files next to it.
+// It panics if maxSize is not positive or keep is negative.
+func NewRotatingWriter(path string, maxSize int64, keep int) (*RotatingWriter, error) {
+	if maxSize <= 0 {
+		panic("NewRotatingWriter: maxSize must be positive")
+	}
+	if keep < 0 {
+		panic("NewRotatingWriter: keep must not be negative")
+	}
+	r := &RotatingWriter{path: path, maxSize: maxSize, keep: keep}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Write stages p as one record, rotating first if it would not fit in the
+// current file. After Close it fails with os.ErrClosed.
+func (r *RotatingWriter) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return 0, os.ErrClosed
+	}
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	if r.buf == nil {
+		r.buf = buffPool.Get()
+		r.buf.Reset()
+	}
+	r.buf.Write(p)
+	r.size += int64(len(p))
+	if r.buf.Len() >= defaultFlushSize {
+		if err := r.flushLocked(); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Rotate rotates the file now, whatever its size, for callers rotating on a
+// schedule of their own. An empty file is left as it is. After Close it
+// fails with os.ErrClosed.
+func (r *RotatingWriter) Rotate() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return os.ErrClosed
+	}
+	if r.size == 0 {
+		return nil
+	}
+	return r.rotate()
+}
+
+// Flush writes out whatever is staged.
+func (r *RotatingWriter) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+	return r.flushLocked()
+}
+
+// Sync flushes the staged bytes and syncs the current file.
+func (r *RotatingWriter) Sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+	if err := r.flushLocked(); err != nil {
+		return err
+	}
+	return r.f.Sync()
+}
+
+// Close flushes the staged bytes and closes the file. Close may be called
+// again.
+func (r *RotatingWriter) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	err := r.flushLocked()
+	return errors.Join(err, r.f.Close())
+}
+
+// flushLocked writes the staged buffer to the current file and returns it
+// to the pool. The staged bytes are dropped even if the write fails.
+func (r *RotatingWriter) flushLocked() error {
+	if r.buf == nil {
+		return nil
+	}
+	err := writeFull(r.f, r.buf.Bytes())
+	releaseWritten(r.buf)
+	r.buf = nil
+	return err
+}
+
+// rotate flushes and closes the current file, shifts the rotated ones up by
+// one and starts a fresh file. If the shift fails, writing carries on in
+// path.
+func (r *RotatingWriter) rotate() error {
+	err := r.flushLocked()
+	if cerr := r.f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = r.shift()
+	}
+	if oerr := r.open(); err == nil {
+		err = oerr
+	}
+	return err
+}
+
+// shift moves path to path.1 and each path.i to path.i+1, removing the one
+// that would pass keep.
+func (r *RotatingWriter) shift() error {
+	if r.keep == 0 {
+		return os.Remove(r.path)
+	}
+	if err := removeMissing(r.rotated(r.keep)); err != nil {
+		return err
+	}
+	for i := r.keep - 1; i >= 1; i-- {
+		if err := renameMissing(r.rotated(i), r.rotated(i+1)); err != nil {
+			return err
+		}
+	}
+	return os.Rename(r.path, r.rotated(1))
+}
+
+// open opens path for appending and picks up its current size.
+func (r *RotatingWriter) open() error {
+	f, err := os.OpenFile(r.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	r.f = f
+	r.size = info.Size()
+	return nil
+}
+
+// rotated returns the name of the i-th rotated file.
+func (r *RotatingWriter) rotated(i int) string {
+	return r.path + "." + strconv.Itoa(i)
+}
+
+// removeMissing removes name, treating a missing file as removed.
+func removeMissing(name string) error {
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// renameMissing renames from to to, doing nothing if from is missing.
+func renameMissing(from, to string) error {
+	if err := os.Rename(from, to); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
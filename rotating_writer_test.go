@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// readRotated returns the contents of path and of each path.i that exists,
+// newest first.
+func readRotated(t *testing.T, path string) []string {
+	t.Helper()
+	var files []string
+	for i := 0; ; i++ {
+		name := path
+		if i > 0 {
+			name = fmt.Sprintf("%s.%d", path, i)
+		}
+		b, err := os.ReadFile(name)
+		if errors.Is(err, os.ErrNotExist) {
+			return files
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, string(b))
+	}
+}
+
+func TestRotatingWriterRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	r, err := NewRotatingWriter(path, 10, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range []string{"aaaa\n", "bbbb\n", "cccc\n", "dddd\n", "eeeeeeeeeeeeeeee\n", "ffff\n"} {
+		if _, err := r.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := readRotated(t, path)
+	// The oversized record gets a file of its own.
+	want := []string{"ffff\n", "eeeeeeeeeeeeeeee\n", "cccc\ndddd\n", "aaaa\nbbbb\n"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("files = %q, want %q", got, want)
+	}
+}
+
+func TestRotatingWriterRotateOnDemand(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	r, err := NewRotatingWriter(path, 1<<20, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	r.Write([]byte("aaaa\n"))
+	if err := r.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	r.Write([]byte("bbbb\n"))
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Rotate(); !errors.Is(err, os.ErrClosed) {
+		t.Fatalf("Rotate after Close = %v, want os.ErrClosed", err)
+	}
+
+	// Rotating the empty file did nothing.
+	got := readRotated(t, path)
+	if want := []string{"bbbb\n", "aaaa\n"}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("files = %q, want %q", got, want)
+	}
+}
+
+func TestRotatingWriterKeep(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	r, err := NewRotatingWriter(path, 5, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 6 {
+		fmt.Fprintf(r, "line%d", i)
+	}
+	r.Close()
+
+	got := readRotated(t, path)
+	want := []string{"line5", "line4", "line3"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("files = %q, want %q", got, want)
+	}
+	if _, err := os.Stat(path + ".3"); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("path.3 exists beyond keep: %v", err)
+	}
+}
+
+func TestRotatingWriterAppendsToExisting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, []byte("old\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewRotatingWriter(path, 8, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Write([]byte("new\n"))
+	r.Write([]byte("next\n"))
+	r.Close()
+
+	got := readRotated(t, path)
+	want := []string{"next\n", "old\nnew\n"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("files = %q, want %q", got, want)
+	}
+}
+
+func TestRotatingWriterConcurrentLogging(t *testing.T) {
+	const (
+		workers = 8
+		perWork = 500
+		maxSize = 4 << 10
+	)
+	path := filepath.Join(t.TempDir(), "app.log")
+	r, err := NewRotatingWriter(path, maxSize, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := NewLogger(r, WithTimeLayout(""))
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perWork {
+				logger.Info("message", "worker", w, "i", i)
+			}
+		}()
+	}
+	wg.Wait()
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Write([]byte("late\n")); !errors.Is(err, os.ErrClosed) {
+		t.Fatalf("Write after Close = %v, want os.ErrClosed", err)
+	}
+
+	files := readRotated(t, path)
+	if len(files) < 5 {
+		t.Fatalf("got %d files, want several rotations", len(files))
+	}
+	seen := make(map[string]int)
+	for i, f := range files {
+		if len(f) > maxSize {
+			t.Errorf("file %d holds %d bytes, over maxSize", i, len(f))
+		}
+		if !strings.HasSuffix(f, "\n") {
+			t.Errorf("file %d ends mid-line: %q", i, f[max(0, len(f)-20):])
+		}
+		for _, line := range bytes.Split([]byte(strings.TrimSuffix(f, "\n")), []byte("\n")) {
+			seen[string(line)]++
+		}
+	}
+	for w := range workers {
+		for i := range perWork {
+			line := fmt.Sprintf("INFO : message worker=%d i=%d", w, i)
+			if n := seen[line]; n != 1 {
+				t.Fatalf("%q appears %d times, want once", line, n)
+			}
+		}
+	}
+	if len(seen) != workers*perWork {
+		t.Fatalf("got %d distinct lines, want %d", len(seen), workers*perWork)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// sampledNewWait is how long a Get that WithSampledNew holds back waits for
+// an item to be put back before constructing one after all.
+const sampledNewWait = time.Millisecond
+
+// WithSampledNew builds the pool's items with newFn, replacing the
+// constructor given to NewTypedPool, which may then be nil, but calls it
+// for only a rate fraction of the Gets that find the pool empty. The rest
+// wait a millisecond first, by the pool's Clock, and take an item put back
+// meanwhile if there is one, for constructors expensive enough that a short
+// wait beats building an extra item. A rate of 1 constructs at once, as
+// without the option. It panics unless 0 <= rate <= 1.
+func WithSampledNew[T any](rate float64, newFn func() T) PoolOption[T] {
+	if !(rate >= 0 && rate <= 1) {
+		panic("WithSampledNew: rate must be between 0 and 1")
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.newFn = newFn
+		cfg.sampledNewRate = &rate
+	}
+}
+
+// awaitPut is the WithSampledNew wait before a construction the sample
+// skipped. It reports an item put back meanwhile, if any.
+func (tp *TypedPool[T]) awaitPut(ctx context.Context) (T, bool) {
+	if r := tp.cfg.sampledNewRate; r == nil || rand.Float64() < *r {
+		var zero T
+		return zero, false
+	}
+	select {
+	case <-ctx.Done():
+	case <-after(tp.cfg.clock, sampledNewWait):
+	}
+	return tp.takeIdle()
+}
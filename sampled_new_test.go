@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ArditZubaku/go-sync-pool/pooltest"
+)
+
+func TestSampledNewWaitsForPut(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Unix(0, 0))
+	news := 0
+	pool := NewTypedPool(nil,
+		WithSampledNew(0, func() *int { news++; return new(int) }),
+		WithPoolClock[*int](clock),
+		WithFIFO[*int](),
+	)
+
+	got := make(chan *int)
+	go func() { got <- pool.Get() }()
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	v := new(int)
+	pool.Put(v)
+	clock.Advance(time.Millisecond)
+
+	if <-got != v {
+		t.Fatal("the held-back Get did not take the item put back meanwhile")
+	}
+	if news != 0 {
+		t.Fatalf("constructor calls = %d, want 0", news)
+	}
+
+	go func() { got <- pool.Get() }()
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	clock.Advance(time.Millisecond)
+	if <-got == nil || news != 1 {
+		t.Fatalf("constructor calls = %d after a wait with nothing put back, want 1", news)
+	}
+}
+
+func TestSampledNewRateOne(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Unix(0, 0))
+	pool := NewTypedPool(nil,
+		WithSampledNew(1, func() *int { return new(int) }),
+		WithPoolClock[*int](clock),
+	)
+	if pool.Get() == nil {
+		t.Fatal("Get returned nil")
+	}
+	if n := clock.Waiters(); n != 0 {
+		t.Fatalf("Get waited on the clock %d times at rate 1", n)
+	}
+}
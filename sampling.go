@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SampleKey is the key of an argument pair that names a WithSampler call
+// site explicitly, for messages that differ between occurrences. Its value
+// is also rendered like any other pair.
+const SampleKey = "sample_key"
+
+// sampleSlots is the size of the sampler's counter table. Keys share a
+// counter when their hashes collide, which only shifts which occurrence of
+// each is kept.
+const sampleSlots = 1024
+
+// WithSampler keeps only every nth line per call site: the first, the
+// n+1th, and so on. A call site is told apart by a hash of its message, or
+// format string for the Logf family, or by the value of a SampleKey
+// argument. Counters live in a fixed table, so sampling allocates nothing.
+// Suppressed reports how many lines were dropped.
+func WithSampler(n int) LoggerOption {
+	return func(l *Logger) {
+		if n > 1 {
+			l.sampler = &sampler{n: uint64(n)}
+		} else {
+			l.sampler = nil
+		}
+	}
+}
+
+// WithDedup collapses a run of lines with the same level and message that
+// starts within window of its first line: the first is written, the rest
+// are dropped, and a single "last message repeated K times" line is written
+// at their level by the next line that ends the run, whether it is a
+// different message or the same one after the window has run out, or by
+// Flush or Close. There is no timer: a run that goes quiet keeps its summary
+// until one of those happens. The key-value arguments of dropped lines are
+// not compared and are lost; for the Logf family the format string is what
+// is compared.
+func WithDedup(window time.Duration) LoggerOption {
+	return func(l *Logger) {
+		l.dedup = &dedup{window: window}
+	}
+}
+
+// SuppressedStats counts the lines a Logger's filters dropped.
+type SuppressedStats struct {
+	Sampled uint64 // dropped by WithSampler
+	Deduped uint64 // dropped by WithDedup
+}
+
+// Suppressed returns the counts of lines dropped by WithSampler and
+// WithDedup.
+func (l *Logger) Suppressed() SuppressedStats {
+	var st SuppressedStats
+	if l.sampler != nil {
+		st.Sampled = l.sampler.dropped.Load()
+	}
+	if l.dedup != nil {
+		st.Deduped = l.dedup.dropped.Load()
+	}
+	return st
+}
+
+// sampler is the WithSampler state.
+type sampler struct {
+	n       uint64
+	counts  [sampleSlots]atomic.Uint64
+	dropped atomic.Uint64
+}
+
+// keep counts one occurrence of key and reports whether it is written.
+func (s *sampler) keep(key string) bool {
+	c := s.counts[hashString(key)%sampleSlots].Add(1)
+	if (c-1)%s.n == 0 {
+		return true
+	}
+	s.dropped.Add(1)
+	return false
+}
+
+// sampleKey returns the value of a SampleKey argument, or msg.
+func sampleKey(msg string, args []any) string {
+	for len(args) > 0 {
+		var (
+			key string
+			val any
+		)
+		key, val, args = nextKV(args)
+		if key == SampleKey {
+			if s, ok := val.(string); ok {
+				return s
+			}
+			return fmt.Sprint(val)
+		}
+	}
+	return msg
+}
+
+// hashString is 64-bit FNV-1a.
+func hashString(s string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= 1099511628211
+	}
+	return h
+}
+
+// dedup is the WithDedup state: the current run of identical lines. msg is
+// reused across runs, so only a longer message than any before allocates.
+type dedup struct {
+	window  time.Duration
+	dropped atomic.Uint64
+
+	mu      sync.Mutex
+	active  bool
+	level   Level
+	msg     []byte
+	start   time.Time
+	repeats int
+}
+
+// admit applies the Logger's filters to a line and reports whether it is
+// written. It writes any repeat summary the line ends first.
+func (l *Logger) admit(level Level, msg string, args []any) bool {
+	if l.sampler != nil && !l.sampler.keep(sampleKey(msg, args)) {
+		return false
+	}
+	if l.dedup == nil {
+		return true
+	}
+
+	d := l.dedup
+	now := l.clock.Now()
+	d.mu.Lock()
+	if d.active && d.level == level && string(d.msg) == msg && now.Sub(d.start) < d.window {
+		d.repeats++
+		d.mu.Unlock()
+		d.dropped.Add(1)
+		return false
+	}
+	summaryLevel, repeats := d.level, d.repeats
+	d.active, d.level, d.msg, d.start, d.repeats = true, level, append(d.msg[:0], msg...), now, 0
+	d.mu.Unlock()
+
+	l.writeRepeats(summaryLevel, repeats)
+	return true
+}
+
+// flushDedup writes the summary of the current run, if it dropped lines.
+func (l *Logger) flushDedup() {
+	d := l.dedup
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	level, repeats := d.level, d.repeats
+	d.active, d.repeats = false, 0
+	d.mu.Unlock()
+
+	l.writeRepeats(level, repeats)
+}
+
+// writeRepeats writes the "last message repeated K times" line for a run
+// that dropped repeats lines.
+func (l *Logger) writeRepeats(level Level, repeats int) {
+	if repeats == 0 {
+		return
+	}
+	var buf [64]byte
+	msg := append(strconv.AppendInt(append(buf[:0], "last message repeated "...), int64(repeats), 10), " times"...)
+	l.writeLine(level, bytesString(msg), nil, 0)
+}
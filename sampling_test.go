@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ArditZubaku/go-sync-pool/pooltest"
+)
+
+func TestSamplerKeepsEveryNth(t *testing.T) {
+	var out bytes.Buffer
+	logger := NewLogger(&out, WithTimeLayout(""), WithSampler(10))
+
+	for i := range 25 {
+		logger.Error("db down", "i", i)
+		logger.Info("distinct", "i", i) // a different call site, sampled apart
+	}
+
+	var kept []string
+	for line := range strings.Lines(out.String()) {
+		if strings.HasPrefix(line, "ERROR") {
+			kept = append(kept, strings.TrimSpace(line))
+		}
+	}
+	want := []string{"ERROR : db down i=0", "ERROR : db down i=10", "ERROR : db down i=20"}
+	if fmt.Sprint(kept) != fmt.Sprint(want) {
+		t.Fatalf("kept %q, want %q", kept, want)
+	}
+	if got := logger.Suppressed().Sampled; got != 44 {
+		t.Fatalf("Sampled = %d, want 44", got)
+	}
+}
+
+func TestSamplerExplicitKey(t *testing.T) {
+	var out bytes.Buffer
+	logger := NewLogger(&out, WithTimeLayout(""), WithSampler(2))
+
+	for i := range 4 {
+		logger.Warn(fmt.Sprintf("request %d failed", i), SampleKey, "req-fail")
+	}
+	if n := strings.Count(out.String(), "\n"); n != 2 {
+		t.Fatalf("wrote %d lines, want 2:\n%s", n, out.String())
+	}
+}
+
+func TestDedupStorm(t *testing.T) {
+	var out bytes.Buffer
+	clock := pooltest.NewFakeClock(time.Unix(0, 0))
+	logger := NewLogger(&out, WithTimeLayout(""), WithClock(clock), WithDedup(time.Minute))
+
+	for range 1000 {
+		logger.Error("db down", "host", "a")
+		clock.Advance(time.Millisecond)
+	}
+	if got, want := out.String(), "ERROR : db down host=a\n"; got != want {
+		t.Fatalf("during the storm got %q, want %q", got, want)
+	}
+
+	logger.Info("recovered")
+	want := "ERROR : db down host=a\nERROR : last message repeated 999 times\nINFO : recovered\n"
+	if got := out.String(); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if got := logger.Suppressed().Deduped; got != 999 {
+		t.Fatalf("Deduped = %d, want 999", got)
+	}
+}
+
+func TestDedupWindowExpires(t *testing.T) {
+	var out bytes.Buffer
+	clock := pooltest.NewFakeClock(time.Unix(0, 0))
+	logger := NewLogger(&out, WithTimeLayout(""), WithClock(clock), WithDedup(time.Second))
+
+	logger.Error("db down")
+	logger.Error("db down")
+	clock.Advance(time.Second)
+	logger.Error("db down")
+	logger.Close()
+
+	want := "ERROR : db down\nERROR : last message repeated 1 times\nERROR : db down\n"
+	if got := out.String(); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestDedupSummaryOnClose(t *testing.T) {
+	var out bytes.Buffer
+	logger := NewLogger(&out, WithTimeLayout(""), WithDedup(time.Hour))
+
+	logger.Warnf("retry %d", 1)
+	logger.Warnf("retry %d", 2)
+	logger.Close()
+
+	want := "WARN : retry 1\nWARN : last message repeated 1 times\n"
+	if got := out.String(); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestDedupNeverSuppressesDistinctMessages(t *testing.T) {
+	var out bytes.Buffer
+	logger := NewLogger(&out, WithTimeLayout(""), WithDedup(time.Hour))
+
+	for i := range 100 {
+		logger.Info(fmt.Sprintf("message %d", i))
+		logger.Warn(fmt.Sprintf("message %d", i)) // same text, other level
+	}
+	if n := strings.Count(out.String(), "\n"); n != 200 {
+		t.Fatalf("wrote %d lines, want 200", n)
+	}
+	if got := logger.Suppressed(); got != (SuppressedStats{}) {
+		t.Fatalf("Suppressed = %+v, want none", got)
+	}
+}
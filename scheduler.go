@@ -0,0 +1,40 @@
+package main
+
+// Scheduler runs background work on behalf of a pool, such as an asynchronous
+// warmup. Implementations may hand fn to a worker pool or executor instead of
+// spawning a goroutine.
+type Scheduler interface {
+	Schedule(fn func())
+}
+
+// SchedulerFunc adapts an ordinary function to the Scheduler interface.
+type SchedulerFunc func(fn func())
+
+// Schedule calls f(fn).
+func (f SchedulerFunc) Schedule(fn func()) {
+	f(fn)
+}
+
+// goScheduler is the default Scheduler: one goroutine per task.
+type goScheduler struct{}
+
+func (goScheduler) Schedule(fn func()) {
+	go fn()
+}
+
+// WithScheduler runs the pool's background work on sched instead of raw
+// goroutines.
+func WithScheduler[T any](sched Scheduler) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.scheduler = sched
+	}
+}
+
+// schedule runs fn on the configured Scheduler.
+func (cfg *poolConfig[T]) schedule(fn func()) {
+	if cfg.scheduler == nil {
+		goScheduler{}.Schedule(fn)
+		return
+	}
+	cfg.scheduler.Schedule(fn)
+}
@@ -0,0 +1,36 @@
+package main
+
+import "testing"
+
+func TestWithSchedulerRunsWarmup(t *testing.T) {
+	var queued []func()
+	sched := SchedulerFunc(func(fn func()) {
+		queued = append(queued, fn)
+	})
+
+	pool := NewBoundedPool(4, func() int { return 7 }, WithScheduler[int](sched))
+	done := pool.WarmupAsync(3)
+
+	if len(queued) != 1 {
+		t.Fatalf("scheduled %d tasks, want 1", len(queued))
+	}
+	if got := pool.Len(); got != 0 {
+		t.Fatalf("warmup ran before the scheduler did: Len() = %d", got)
+	}
+
+	queued[0]()
+	<-done
+
+	if got := pool.Len(); got != 3 {
+		t.Fatalf("Len() = %d, want 3", got)
+	}
+}
+
+func TestWarmupAsyncDefaultScheduler(t *testing.T) {
+	pool := NewBoundedPool(2, func() int { return 7 })
+	<-pool.WarmupAsync(5)
+
+	if got := pool.Len(); got != 2 {
+		t.Fatalf("Len() = %d, want 2", got)
+	}
+}
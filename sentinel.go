@@ -0,0 +1,40 @@
+package main
+
+import "reflect"
+
+// WithItemSentinel has every Get that ends without an item, because a
+// budget, guard, throttle or abort refused it or construction failed,
+// return sentinel instead of T's zero value. For interface types that zero
+// is nil; a sentinel such as a no-op implementation spares callers the nil
+// check. Errors are still reported as before. Put ignores sentinel, so it
+// never enters the pool. It panics unless sentinel can be compared with ==.
+func WithItemSentinel[T any](sentinel T) PoolOption[T] {
+	if !comparableValue(any(sentinel)) {
+		panic("WithItemSentinel: sentinel must be comparable")
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.sentinel, cfg.hasSentinel = sentinel, true
+	}
+}
+
+// noItem is what a Get that fails returns: the WithItemSentinel value, or
+// the zero value.
+func (tp *TypedPool[T]) noItem() T {
+	return tp.cfg.sentinel
+}
+
+// isSentinel reports whether v is the WithItemSentinel value. Values whose
+// dynamic type cannot be compared never are.
+func (tp *TypedPool[T]) isSentinel(v T) bool {
+	if !tp.cfg.hasSentinel {
+		return false
+	}
+	a := any(v)
+	return comparableValue(a) && a == any(tp.cfg.sentinel)
+}
+
+// comparableValue reports whether x can be compared with == without
+// panicking.
+func comparableValue(x any) bool {
+	return x == nil || reflect.ValueOf(x).Comparable()
+}
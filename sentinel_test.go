@@ -0,0 +1,57 @@
+package main
+
+import "testing"
+
+type handler interface{ Handle() string }
+
+type realHandler struct{}
+
+func (*realHandler) Handle() string { return "handled" }
+
+type noopHandler struct{}
+
+func (noopHandler) Handle() string { return "" }
+
+func TestItemSentinel(t *testing.T) {
+	pool := NewTypedPool(func() handler { return new(realHandler) },
+		WithItemSentinel[handler](noopHandler{}),
+		WithConstructorBudget[handler](1),
+		WithStats[handler](),
+		WithFIFO[handler](),
+	)
+
+	h := pool.Get()
+	if h.Handle() != "handled" {
+		t.Fatal("Get did not construct a real handler")
+	}
+	got, err := pool.TryGet()
+	if err != ErrBudgetExhausted {
+		t.Fatalf("TryGet err = %v, want ErrBudgetExhausted", err)
+	}
+	if got != (noopHandler{}) {
+		t.Fatalf("TryGet returned %#v, want the sentinel", got)
+	}
+
+	pool.Put(got)
+	pool.Put(h)
+	if s := pool.Stats(); s.Puts != 1 {
+		t.Fatalf("Puts = %d, want the sentinel Put ignored", s.Puts)
+	}
+	if again := pool.Get(); again != h {
+		t.Fatalf("Get = %#v, want the pooled handler", again)
+	}
+}
+
+// sliceHandler is a handler of a type == cannot compare.
+type sliceHandler []string
+
+func (sliceHandler) Handle() string { return "slice" }
+
+func TestItemSentinelRejectsIncomparable(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("WithItemSentinel accepted a sentinel of an incomparable type")
+		}
+	}()
+	WithItemSentinel[handler](sliceHandler{})
+}
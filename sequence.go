@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"strconv"
+	"sync/atomic"
+)
+
+// WithSequence ends every line with seq=N, numbering the Logger's lines
+// from 1, so a reader can spot dropped or reordered lines. The number is
+// taken while the line is built and each line is still a single Write, but
+// under concurrency lines can reach the writer in a different order than
+// their numbers.
+func WithSequence() LoggerOption {
+	return func(l *Logger) {
+		l.seq = new(atomic.Uint64)
+	}
+}
+
+// appendSeq writes " seq=N" with the next sequence number, if enabled.
+func (l *Logger) appendSeq(b *bytes.Buffer) {
+	if l.seq == nil {
+		return
+	}
+	b.WriteString(" seq=")
+	b.Write(strconv.AppendUint(b.AvailableBuffer(), l.seq.Add(1), 10))
+}
+
+// appendJSONSeq writes `,"seq":N` with the next sequence number, if enabled.
+func (l *Logger) appendJSONSeq(b *bytes.Buffer) {
+	if l.seq == nil {
+		return
+	}
+	b.WriteString(`,"seq":`)
+	b.Write(strconv.AppendUint(b.AvailableBuffer(), l.seq.Add(1), 10))
+}
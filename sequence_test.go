@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWithSequenceConcurrent(t *testing.T) {
+	const goroutines, perG = 16, 200
+	var out lockedBuffer
+	logger := NewLogger(&out, WithSequence())
+
+	var wg sync.WaitGroup
+	for range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range perG {
+				logger.Info("msg", "k", "v")
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[uint64]int)
+	for line := range strings.Lines(out.String()) {
+		_, num, ok := strings.Cut(line, " seq=")
+		n, err := strconv.ParseUint(strings.TrimSuffix(num, "\n"), 10, 64)
+		if !ok || err != nil {
+			t.Fatalf("torn or unnumbered line %q", line)
+		}
+		seen[n]++
+	}
+	for n := uint64(1); n <= goroutines*perG; n++ {
+		if seen[n] != 1 {
+			t.Fatalf("seq=%d appears %d times", n, seen[n])
+		}
+	}
+	if len(seen) != goroutines*perG {
+		t.Fatalf("got %d distinct numbers, want %d", len(seen), goroutines*perG)
+	}
+}
+
+func TestWithSequenceFormats(t *testing.T) {
+	var buf bytes.Buffer
+	text := NewLogger(&buf, WithTimeLayout(""), WithSequence())
+	text.Info("a", "k", 1)
+	text.Infof("b")
+	json := NewLogger(&buf, WithJSON(), WithTimeLayout(""), WithSequence())
+	json.Info("c")
+
+	want := "INFO : a k=1 seq=1\nINFO : b seq=2\n" + `{"level":"info","msg":"c","seq":1}` + "\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
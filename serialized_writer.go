@@ -0,0 +1,53 @@
+package main
+
+import (
+	"io"
+	"sync"
+)
+
+// SerializedWriter lets many goroutines share one writer: each Write holds a
+// mutex until all of p has been written, short writes included, so lines
+// from different goroutines are never interleaved byte-wise.
+type SerializedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewSerializedWriter returns a SerializedWriter for w.
+func NewSerializedWriter(w io.Writer) *SerializedWriter {
+	return &SerializedWriter{w: w}
+}
+
+// Write writes all of p to the underlying writer, retrying short writes
+// before another goroutine's Write may start.
+func (s *SerializedWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := writeFull(s.w, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush and Sync pass through to the underlying writer if it has them, so
+// Logger.Flush reaches it.
+func (s *SerializedWriter) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f, ok := s.w.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+func (s *SerializedWriter) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f, ok := s.w.(interface{ Sync() error }); ok {
+		return f.Sync()
+	}
+	return nil
+}
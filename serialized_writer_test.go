@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+)
+
+func TestSerializedWriterNeverInterleaves(t *testing.T) {
+	const (
+		writers = 8
+		lines   = 500
+	)
+	// shortWriter takes 7 bytes per call and is not safe for concurrent use,
+	// so any overlap between two Writes would tear lines or race.
+	w := &shortWriter{max: 7}
+	logger := NewLogger(NewSerializedWriter(w), WithTimeLayout(""))
+
+	var wg sync.WaitGroup
+	wg.Add(writers)
+	for g := range writers {
+		go func() {
+			defer wg.Done()
+			for i := range lines {
+				logger.Info("message", "g", g, "i", i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var want []string
+	for g := range writers {
+		for i := range lines {
+			want = append(want, fmt.Sprintf("INFO : message g=%d i=%d", g, i))
+		}
+	}
+	got := strings.Split(strings.TrimSuffix(w.String(), "\n"), "\n")
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %d lines, want %d, or some lines were interleaved", len(got), len(want))
+	}
+}
+
+func TestSerializedWriterReportsErrors(t *testing.T) {
+	w := &failingWriter{left: 3, err: syscall.EPIPE}
+	n, err := NewSerializedWriter(w).Write([]byte("too long"))
+	if n != 0 || err != syscall.EPIPE {
+		t.Fatalf("Write = %d, %v; want 0, EPIPE", n, err)
+	}
+}
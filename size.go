@@ -0,0 +1,9 @@
+package main
+
+// WithSizeFunc tells the pool how many bytes an object retains, for accounting
+// such as a PoolGroup budget.
+func WithSizeFunc[T any](fn func(T) int64) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.sizeFn = fn
+	}
+}
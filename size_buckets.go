@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// WithSizeBuckets splits the pool into size classes, like bytebufferpool.
+// buckets are ascending upper bounds such as 512, 4096 and 65536, and
+// newFns[i] builds an item for class i, holding buckets[i] units. GetSize
+// picks the smallest class that fits, and Put files an item by sizeOf under
+// the largest class it can serve, so an item is never handed out for a size
+// it cannot hold; items smaller than the first bucket are discarded. Get
+// serves the first class.
+//
+// Each class is a plain TypedPool, so the pool's other options do not apply
+// to it, and the constructor given to NewTypedPool is unused and may be nil.
+// It panics if the bounds are not ascending or there is not one constructor
+// per bucket.
+func WithSizeBuckets[T any](buckets []int, sizeOf func(T) int, newFns []func() T) PoolOption[T] {
+	if len(buckets) == 0 || len(buckets) != len(newFns) {
+		panic(fmt.Sprintf("WithSizeBuckets: got %d buckets and %d constructors", len(buckets), len(newFns)))
+	}
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i] <= buckets[i-1] {
+			panic("WithSizeBuckets: bounds must be strictly ascending")
+		}
+	}
+
+	return func(cfg *poolConfig[T]) {
+		cfg.buckets = &sizeBuckets[T]{
+			bounds: slices.Clone(buckets),
+			sizeOf: sizeOf,
+			newFns: slices.Clone(newFns),
+		}
+	}
+}
+
+// sizeBuckets holds the WithSizeBuckets settings.
+type sizeBuckets[T any] struct {
+	bounds []int
+	sizeOf func(T) int
+	newFns []func() T
+}
+
+// newClasses builds one TypedPool per size class.
+func (sb *sizeBuckets[T]) newClasses() []*TypedPool[T] {
+	classes := make([]*TypedPool[T], len(sb.newFns))
+	for i, newFn := range sb.newFns {
+		classes[i] = NewTypedPool(newFn)
+	}
+	return classes
+}
+
+// GetSize returns an item from the smallest WithSizeBuckets class holding at
+// least size. A size above the largest bucket gets an item of the largest
+// class, which the caller has to grow. Without buckets it is Get.
+func (tp *TypedPool[T]) GetSize(size int) T {
+	if tp.classes == nil {
+		return tp.Get()
+	}
+	if tp.aborted() {
+		return tp.noItem()
+	}
+	tp.wait(context.Background())
+	if err := tp.acquire(context.Background()); err != nil {
+		return tp.noItem()
+	}
+	i, _ := slices.BinarySearch(tp.cfg.buckets.bounds, size)
+	return tp.classes[min(i, len(tp.classes)-1)].Get()
+}
+
+// putSized files v under the largest class whose bound it reaches.
+func (tp *TypedPool[T]) putSized(v T) {
+	if tp.denied(v) {
+		return
+	}
+	sb := tp.cfg.buckets
+	size := sb.sizeOf(v)
+	i, found := slices.BinarySearch(sb.bounds, size)
+	if !found {
+		i--
+	}
+	if i < 0 {
+		tp.discard(v)
+		return
+	}
+	if tp.cfg.reset != nil {
+		tp.cfg.reset(v)
+	}
+	tp.classes[i].Put(v)
+}
@@ -0,0 +1,69 @@
+package main
+
+import "testing"
+
+func newBucketedPool() *TypedPool[[]byte] {
+	return NewTypedPool(nil, WithSizeBuckets(
+		[]int{512, 4096, 65536},
+		func(b []byte) int { return cap(b) },
+		[]func() []byte{
+			func() []byte { return make([]byte, 0, 512) },
+			func() []byte { return make([]byte, 0, 4096) },
+			func() []byte { return make([]byte, 0, 65536) },
+		},
+	))
+}
+
+func TestSizeBucketsGetPicksSmallestFit(t *testing.T) {
+	pool := newBucketedPool()
+
+	for _, tc := range []struct{ size, cap int }{
+		{0, 512}, {512, 512}, {513, 4096}, {4096, 4096}, {10000, 65536}, {1 << 20, 65536},
+	} {
+		if got := cap(pool.GetSize(tc.size)); got != tc.cap {
+			t.Errorf("GetSize(%d) has cap %d, want %d", tc.size, got, tc.cap)
+		}
+	}
+	if got := cap(pool.Get()); got != 512 {
+		t.Errorf("Get() has cap %d, want 512", got)
+	}
+}
+
+func TestSizeBucketsPutRoutesToFittingClass(t *testing.T) {
+	pool := newBucketedPool()
+
+	grown := make([]byte, 0, 5000) // serves up to 4096, not 65536
+	pool.Put(grown)
+	if got := pool.classes[1].Len(); got != 1 {
+		t.Fatalf("4096 class holds %d items, want 1", got)
+	}
+	for i, c := range []*TypedPool[[]byte]{pool.classes[0], pool.classes[2]} {
+		if c.Len() != 0 {
+			t.Errorf("class %d holds %d items, want 0", i, c.Len())
+		}
+	}
+
+	pool.Put(make([]byte, 0, 100)) // below every bucket
+	for i, c := range pool.classes {
+		if i != 1 && c.Len() != 0 {
+			t.Errorf("undersized item went to class %d", i)
+		}
+	}
+}
+
+func TestSizeBucketsValidation(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"mismatch":   func() { WithSizeBuckets([]int{1, 2}, func(int) int { return 0 }, []func() int{nil}) },
+		"unsorted":   func() { WithSizeBuckets([]int{2, 1}, func(int) int { return 0 }, []func() int{nil, nil}) },
+		"duplicates": func() { WithSizeBuckets([]int{1, 1}, func(int) int { return 0 }, []func() int{nil, nil}) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("no panic")
+				}
+			}()
+			fn()
+		})
+	}
+}
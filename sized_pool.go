@@ -0,0 +1,42 @@
+package main
+
+import "context"
+
+// SizedPool is a TypedPool whose Get takes a size hint, for buffer-like items
+// where the caller sometimes knows it needs more than the default size.
+type SizedPool[T any] struct {
+	*TypedPool[T]
+}
+
+// sizeHint holds the NewSizedPool constructor and size function.
+type sizeHint[T any] struct {
+	newFn  func(sizeHint int) T
+	sizeOf func(T) int
+}
+
+// NewSizedPool creates a SizedPool. newFn builds an item with room for at
+// least sizeHint units, and must build the pool's default item for a hint of
+// 0; sizeOf reports what an item holds, such as the capacity of a buffer.
+func NewSizedPool[T any](newFn func(sizeHint int) T, sizeOf func(T) int, opts ...PoolOption[T]) *SizedPool[T] {
+	opts = append(opts, func(cfg *poolConfig[T]) {
+		cfg.sizeHint = &sizeHint[T]{newFn: newFn, sizeOf: sizeOf}
+	})
+	return &SizedPool[T]{NewTypedPool(func() T { return newFn(0) }, opts...)}
+}
+
+// Get returns an item holding at least sizeHint. An idle item that is too
+// small goes back to the pool and a miss passes the hint to the
+// constructor. A hint of 0 or less is the plain TypedPool Get. Under
+// WithSizeBuckets the hint picks the class, as GetSize does.
+func (sp *SizedPool[T]) Get(sizeHint int) T {
+	if sp.classes != nil {
+		return sp.GetSize(sizeHint)
+	}
+	v, _, err := sp.get(context.Background(), sizeHint)
+	return sp.orNoItem(v, err)
+}
+
+// fits reports whether v holds at least hint units.
+func (sh *sizeHint[T]) fits(v T, hint int) bool {
+	return sh == nil || hint <= 0 || sh.sizeOf(v) >= hint
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func newSizedBufferPool(hints *[]int) *SizedPool[*bytes.Buffer] {
+	return NewSizedPool(func(sizeHint int) *bytes.Buffer {
+		*hints = append(*hints, sizeHint)
+		return bytes.NewBuffer(make([]byte, 0, max(sizeHint, 1<<10)))
+	}, func(b *bytes.Buffer) int { return b.Cap() }, WithFIFO[*bytes.Buffer](), WithStats[*bytes.Buffer]())
+}
+
+func TestSizedPoolPassesHintOnMiss(t *testing.T) {
+	var hints []int
+	pool := newSizedBufferPool(&hints)
+
+	if b := pool.Get(64 << 10); b.Cap() < 64<<10 {
+		t.Fatalf("Get(64KiB) cap = %d", b.Cap())
+	}
+	if len(hints) != 1 || hints[0] != 64<<10 {
+		t.Fatalf("constructor hints = %v, want [65536]", hints)
+	}
+}
+
+func TestSizedPoolChecksHitsFit(t *testing.T) {
+	var hints []int
+	pool := newSizedBufferPool(&hints)
+
+	small := pool.Get(0)
+	pool.Put(small)
+	big := pool.Get(8 << 10)
+	if big == small || big.Cap() < 8<<10 {
+		t.Fatalf("Get(8KiB) returned the 1KiB buffer (cap %d)", big.Cap())
+	}
+	if got := pool.Get(512); got != small {
+		t.Fatal("the too-small buffer did not go back to the pool")
+	}
+
+	pool.Put(big)
+	if got := pool.Get(4 << 10); got != big {
+		t.Fatal("Get(4KiB) did not reuse the 8KiB buffer")
+	}
+	if s := pool.Stats(); s.Hits != 2 || s.Misses != 2 {
+		t.Fatalf("hits, misses = %d, %d; want 2, 2", s.Hits, s.Misses)
+	}
+}
+
+func TestSizedPoolZeroHint(t *testing.T) {
+	var hints []int
+	pool := newSizedBufferPool(&hints)
+
+	b := pool.Get(0)
+	if b.Cap() != 1<<10 {
+		t.Fatalf("Get(0) cap = %d, want the default 1024", b.Cap())
+	}
+	pool.Put(b)
+	if got := pool.Get(0); got != b {
+		t.Fatal("Get(0) did not reuse the idle buffer")
+	}
+	if got := pool.TypedPool.Get(); got.Cap() != 1<<10 {
+		t.Fatalf("TypedPool.Get cap = %d", got.Cap())
+	}
+	if len(hints) != 2 || hints[0] != 0 || hints[1] != 0 {
+		t.Fatalf("constructor hints = %v, want [0 0]", hints)
+	}
+}
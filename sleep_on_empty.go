@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"time"
+)
+
+// defaultEmptyRetries is how many times TryGet sleeps under
+// WithSleepOnEmpty unless WithEmptyRetries says otherwise.
+const defaultEmptyRetries = 3
+
+// ErrPoolEmpty is returned by FixedPool.TryGet when every object stayed
+// checked out.
+var ErrPoolEmpty = errors.New("pool: no idle object")
+
+// WithSleepOnEmpty makes FixedPool.TryGet sleep d and look again when every
+// object is checked out, up to WithEmptyRetries times (3 by default), before
+// giving up with ErrPoolEmpty. It suits tight loops that can put off their
+// work more easily than they can block in Get.
+func WithSleepOnEmpty[T any](d time.Duration) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.sleepOnEmpty = d
+	}
+}
+
+// WithEmptyRetries sets how many times TryGet sleeps under
+// WithSleepOnEmpty. It panics if n is negative.
+func WithEmptyRetries[T any](n int) PoolOption[T] {
+	if n < 0 {
+		panic("WithEmptyRetries: n must not be negative")
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.emptyRetries = &n
+	}
+}
+
+// TryGet returns an idle object like Get, but never waits on a Put: if every
+// object is checked out it returns ErrPoolEmpty, after the retries set up by
+// WithSleepOnEmpty, if any.
+func (fp *FixedPool[T]) TryGet() (T, error) {
+	retries := 0
+	if fp.cfg.sleepOnEmpty > 0 {
+		retries = defaultEmptyRetries
+		if fp.cfg.emptyRetries != nil {
+			retries = *fp.cfg.emptyRetries
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		fp.mu.Lock()
+		v, ok := fp.takeLocked()
+		fp.mu.Unlock()
+		if ok {
+			fp.stats.hit()
+			return v, nil
+		}
+
+		if attempt == retries {
+			var zero T
+			return zero, ErrPoolEmpty
+		}
+		time.Sleep(fp.cfg.sleepOnEmpty)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFixedPoolTryGet(t *testing.T) {
+	pool := NewFixedPool(1, func() int { return 1 })
+
+	v, err := pool.TryGet()
+	if err != nil || v != 1 {
+		t.Fatalf("TryGet() = %d, %v, want 1, nil", v, err)
+	}
+	if _, err := pool.TryGet(); !errors.Is(err, ErrPoolEmpty) {
+		t.Fatalf("TryGet on an empty pool = %v, want ErrPoolEmpty", err)
+	}
+}
+
+func TestSleepOnEmptyRetries(t *testing.T) {
+	pool := NewFixedPool(1, func() int { return 1 },
+		WithSleepOnEmpty[int](5*time.Millisecond), WithEmptyRetries[int](4))
+	v := pool.Get()
+
+	start := time.Now()
+	if _, err := pool.TryGet(); !errors.Is(err, ErrPoolEmpty) {
+		t.Fatalf("TryGet = %v, want ErrPoolEmpty", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Fatalf("TryGet gave up after %v, want 4 sleeps of 5ms", elapsed)
+	}
+
+	time.AfterFunc(10*time.Millisecond, func() { pool.Put(v) })
+	if got, err := pool.TryGet(); err != nil || got != 1 {
+		t.Fatalf("TryGet() = %d, %v, want the object Put during the retries", got, err)
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// slogTimeLayout is the timestamp layout of slog.TextHandler.
+const slogTimeLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// slogHandler renders slog records in slog.TextHandler's key=value format
+// into pooled buffers.
+type slogHandler struct {
+	w         io.Writer
+	level     slog.Leveler
+	addSource bool
+
+	// attrs holds the WithAttrs attributes, already rendered with the group
+	// prefix that was open when they were added.
+	attrs []byte
+	// group is the dotted prefix of the open groups, such as "a.b.".
+	group string
+}
+
+// NewSlogHandler returns a slog.Handler that writes each record to w with a
+// single Write (retried if short), rendered through the shared buffer pool in the same format as
+// slog.TextHandler. Only opts.Level and opts.AddSource are honored, and the
+// source is reported as file:line with the file's base name like WithCaller.
+// A nil opts logs at slog.LevelInfo and above.
+func NewSlogHandler(w io.Writer, opts *slog.HandlerOptions) slog.Handler {
+	h := &slogHandler{w: w, level: slog.LevelInfo}
+	if opts != nil {
+		if opts.Level != nil {
+			h.level = opts.Level
+		}
+		h.addSource = opts.AddSource
+	}
+	return h
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *slogHandler) Handle(_ context.Context, r slog.Record) error {
+	b := buffPool.Get()
+	b.Reset()
+	defer releaseWritten(b)
+
+	if !r.Time.IsZero() {
+		b.WriteString("time=")
+		b.Write(r.Time.AppendFormat(b.AvailableBuffer(), slogTimeLayout))
+		b.WriteByte(' ')
+	}
+	b.WriteString("level=")
+	b.WriteString(r.Level.String())
+	if h.addSource && r.PC != 0 {
+		b.WriteString(" source=")
+		appendCallerFrame(b, r.PC)
+	}
+	b.WriteString(" msg=")
+	appendText(b, r.Message)
+	b.Write(h.attrs)
+	r.Attrs(func(a slog.Attr) bool {
+		appendSlogAttr(b, h.group, a)
+		return true
+	})
+	b.WriteByte('\n')
+
+	return writeFull(h.w, b.Bytes())
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	b := buffPool.Get()
+	b.Reset()
+	for _, a := range attrs {
+		appendSlogAttr(b, h.group, a)
+	}
+
+	h2 := *h
+	// Clip so that sibling handlers never append into the same array.
+	h2.attrs = append(slices.Clip(h.attrs), b.Bytes()...)
+	buffPool.Put(b)
+	return &h2
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.group = h.group + name + "."
+	return &h2
+}
+
+// appendSlogAttr renders a as " key=value", qualifying the key with group.
+// Empty attributes and empty groups are skipped; groups with an empty key are
+// inlined.
+func appendSlogAttr(b *bytes.Buffer, group string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			group += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			appendSlogAttr(b, group, ga)
+		}
+		return
+	}
+
+	b.WriteByte(' ')
+	if group == "" {
+		appendText(b, a.Key)
+	} else {
+		appendText(b, group+a.Key)
+	}
+	b.WriteByte('=')
+	appendSlogValue(b, a.Value)
+}
+
+func appendSlogValue(b *bytes.Buffer, v slog.Value) {
+	switch v.Kind() {
+	case slog.KindString:
+		appendText(b, v.String())
+	case slog.KindInt64:
+		appendValue(b, v.Int64())
+	case slog.KindUint64:
+		appendValue(b, v.Uint64())
+	case slog.KindFloat64:
+		appendValue(b, v.Float64())
+	case slog.KindBool:
+		appendValue(b, v.Bool())
+	case slog.KindDuration:
+		appendValue(b, v.Duration())
+	case slog.KindTime:
+		b.Write(v.Time().AppendFormat(b.AvailableBuffer(), time.RFC3339Nano))
+	default:
+		appendValue(b, v.Any())
+	}
+}
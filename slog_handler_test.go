@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/slogtest"
+)
+
+func TestSlogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewSlogHandler(&buf, nil)
+
+	results := func() []map[string]any {
+		var ms []map[string]any
+		for line := range strings.Lines(buf.String()) {
+			m, err := parseTextLine(strings.TrimSuffix(line, "\n"))
+			if err != nil {
+				t.Fatalf("%q: %v", line, err)
+			}
+			ms = append(ms, m)
+		}
+		return ms
+	}
+	if err := slogtest.TestHandler(h, results); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSlogHandlerMatchesTextHandler(t *testing.T) {
+	var got, want bytes.Buffer
+	opts := &slog.HandlerOptions{Level: slog.LevelDebug}
+	log := func(l *slog.Logger) {
+		l = l.With("conn", 7).WithGroup("req")
+		l.Debug("start", "path", "/a b", "n", uint64(3), "ok", true)
+		l.Warn("slow", slog.Group("db", "rows", 12, "took", 1500000), "err", io.EOF)
+	}
+	log(slog.New(NewSlogHandler(&got, opts)))
+	log(slog.New(slog.NewTextHandler(&want, opts)))
+
+	// Drop the timestamps, which differ between the two runs.
+	strip := func(s string) string {
+		var out []string
+		for line := range strings.Lines(s) {
+			_, rest, _ := strings.Cut(line, " ")
+			out = append(out, rest)
+		}
+		return strings.Join(out, "")
+	}
+	if g, w := strip(got.String()), strip(want.String()); g != w {
+		t.Fatalf("got:\n%s\nwant:\n%s", g, w)
+	}
+}
+
+func TestSlogHandlerLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := slog.New(NewSlogHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	l.Info("dropped")
+	l.Error("kept")
+
+	if s := buf.String(); strings.Contains(s, "dropped") || !strings.Contains(s, "msg=kept") {
+		t.Fatalf("got %q", s)
+	}
+}
+
+// parseTextLine parses one key=value line into nested maps, splitting
+// dotted keys into groups.
+func parseTextLine(line string) (map[string]any, error) {
+	m := map[string]any{}
+	for line != "" {
+		key, rest, err := parseTextToken(line, '=')
+		if err != nil {
+			return nil, err
+		}
+		val, rest, err := parseTextToken(rest[1:], ' ')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimPrefix(rest, " ")
+
+		parts := strings.Split(key, ".")
+		g := m
+		for _, p := range parts[:len(parts)-1] {
+			sub, ok := g[p].(map[string]any)
+			if !ok {
+				sub = map[string]any{}
+				g[p] = sub
+			}
+			g = sub
+		}
+		g[parts[len(parts)-1]] = val
+	}
+	return m, nil
+}
+
+// parseTextToken reads a possibly quoted token ending at stop.
+func parseTextToken(s string, stop byte) (tok, rest string, err error) {
+	if strings.HasPrefix(s, `"`) {
+		q, err := strconv.QuotedPrefix(s)
+		if err != nil {
+			return "", "", err
+		}
+		tok, err = strconv.Unquote(q)
+		return tok, s[len(q):], err
+	}
+	if i := strings.IndexByte(s, stop); i >= 0 {
+		return s[:i], s[i:], nil
+	}
+	return s, "", nil
+}
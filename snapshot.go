@@ -0,0 +1,56 @@
+package main
+
+import "time"
+
+// PoolSnapshot describes one pool at a point in time.
+type PoolSnapshot struct {
+	Name  string // telemetry prefix or profile name, if any
+	Idle  int    // idle items; an estimate for TypedPool
+	Stats Stats
+}
+
+// WithMetricsSnapshot hands sink the pool's Snapshot every interval, from a
+// background goroutine that Close stops, so metrics reach a time-series
+// backend without the caller polling. AllocTracker is the counterpart for
+// flushing several pools' telemetry at once. It panics if interval is not
+// positive.
+func WithMetricsSnapshot[T any](interval time.Duration, sink func(PoolSnapshot)) PoolOption[T] {
+	if interval <= 0 {
+		panic("WithMetricsSnapshot: interval must be positive")
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.snapshotEvery = interval
+		cfg.snapshotSink = sink
+	}
+}
+
+// name returns the label used for the pool in snapshots.
+func (cfg *poolConfig[T]) name() string {
+	if cfg.telemetryPrefix != "" {
+		return cfg.telemetryPrefix
+	}
+	return cfg.profileName
+}
+
+// Len returns the estimated number of idle items. The GC may have cleared
+// some of them without the pool noticing yet.
+func (tp *TypedPool[T]) Len() int {
+	return int(tp.inPool.Load())
+}
+
+// Snapshot returns the pool's current state.
+func (tp *TypedPool[T]) Snapshot() PoolSnapshot {
+	return PoolSnapshot{Name: tp.cfg.name(), Idle: tp.Len(), Stats: tp.Stats()}
+}
+
+// startSnapshots schedules the WithMetricsSnapshot task.
+func (tp *TypedPool[T]) startSnapshots() {
+	tp.bg.every(tp.cfg.schedule, tp.cfg.clock, tp.cfg.jitter, tp.cfg.snapshotEvery, func() {
+		tp.cfg.snapshotSink(tp.Snapshot())
+	})
+}
+
+// Snapshot returns the pool's current state.
+func (bp *BoundedPool[T]) Snapshot() PoolSnapshot {
+	return PoolSnapshot{Name: bp.cfg.name(), Idle: bp.Len(), Stats: bp.Stats()}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"os"
+	"time"
+)
+
+// WithSnapshotExport appends the pool's Snapshot to the file at path every
+// interval, one JSON record per line, for capacity planning after the fact.
+// Records carry a "ts" timestamp in Unix nanoseconds from the pool's Clock.
+// A background goroutine on the pool's Scheduler writes them until Close,
+// which closes the file. The file is opened for appending by NewTypedPool;
+// if that or a write fails, the pool logs a warning and carries on. It
+// panics if interval is not positive.
+func WithSnapshotExport[T any](path string, interval time.Duration) PoolOption[T] {
+	if interval <= 0 {
+		panic("WithSnapshotExport: interval must be positive")
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.snapshotExport = &snapshotExport{path: path, interval: interval}
+	}
+}
+
+// WithSnapshotRotation rotates the WithSnapshotExport file by size through a
+// RotatingWriter, keeping up to keep rotated files of at most maxSize bytes.
+// Without WithSnapshotExport it does nothing.
+func WithSnapshotRotation[T any](maxSize int64, keep int) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		r := cfg.rotation()
+		r.maxSize, r.keep = maxSize, max(r.keep, keep)
+	}
+}
+
+// WithSnapshotDailyRotation rotates the WithSnapshotExport file before the
+// first record of each new day, by the pool's Clock in the zone of its
+// times, keeping up to keep rotated files. The first day is the one the
+// pool starts on. With WithSnapshotRotation a file also rotates by size,
+// and the larger keep applies. Without WithSnapshotExport it does nothing.
+func WithSnapshotDailyRotation[T any](keep int) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		r := cfg.rotation()
+		r.daily, r.keep = true, max(r.keep, keep)
+	}
+}
+
+// rotation returns the snapshot rotation settings, creating them if need
+// be.
+func (cfg *poolConfig[T]) rotation() *snapshotRotation {
+	if cfg.snapshotRotation == nil {
+		cfg.snapshotRotation = new(snapshotRotation)
+	}
+	return cfg.snapshotRotation
+}
+
+// snapshotExport holds the WithSnapshotExport settings.
+type snapshotExport struct {
+	path     string
+	interval time.Duration
+}
+
+// snapshotRotation holds the WithSnapshotRotation and
+// WithSnapshotDailyRotation settings.
+type snapshotRotation struct {
+	maxSize int64 // 0 for daily rotation alone
+	keep    int
+	daily   bool
+}
+
+// snapshotRecord is one line of a WithSnapshotExport file.
+type snapshotRecord struct {
+	Time  int64  `json:"ts"` // Unix nanoseconds
+	Name  string `json:"name,omitempty"`
+	Idle  int    `json:"idle"`
+	Stats Stats  `json:"stats"`
+}
+
+// open opens the export file for appending, rotated if rot is set.
+func (se *snapshotExport) open(rot *snapshotRotation) (io.WriteCloser, error) {
+	if rot != nil {
+		maxSize := rot.maxSize
+		if maxSize == 0 && rot.daily {
+			maxSize = math.MaxInt64
+		}
+		return NewRotatingWriter(se.path, maxSize, rot.keep)
+	}
+	return os.OpenFile(se.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+}
+
+// startSnapshotExport opens the WithSnapshotExport file and starts writing
+// to it.
+func (tp *TypedPool[T]) startSnapshotExport() {
+	se := tp.cfg.snapshotExport
+	w, err := se.open(tp.cfg.snapshotRotation)
+	if err != nil {
+		tp.cfg.log().Warn("pool snapshot export disabled", "pool", tp.cfg.name(), "err", err)
+		return
+	}
+	daily := tp.cfg.snapshotRotation != nil && tp.cfg.snapshotRotation.daily
+	tp.bg.run(tp.cfg.schedule, func(stop <-chan struct{}) {
+		defer w.Close()
+		last := tp.cfg.now()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-after(tp.cfg.clock, se.interval):
+				now := tp.cfg.now()
+				if daily && !sameDay(now, last) {
+					if err := w.(*RotatingWriter).Rotate(); err != nil {
+						tp.cfg.log().Warn("pool snapshot rotation failed", "pool", tp.cfg.name(), "err", err)
+					}
+				}
+				last = now
+				if err := tp.exportSnapshot(w, now); err != nil {
+					tp.cfg.log().Warn("pool snapshot export failed", "pool", tp.cfg.name(), "err", err)
+				}
+			}
+		}
+	})
+}
+
+// sameDay reports whether a and b fall on the same calendar day.
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// exportSnapshot writes one record, taken at now, to w.
+func (tp *TypedPool[T]) exportSnapshot(w io.Writer, now time.Time) error {
+	s := tp.Snapshot()
+	line, err := json.Marshal(snapshotRecord{
+		Time:  now.UnixNano(),
+		Name:  s.Name,
+		Idle:  s.Idle,
+		Stats: s.Stats,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	if rw, ok := w.(*RotatingWriter); ok {
+		return rw.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ArditZubaku/go-sync-pool/pooltest"
+)
+
+func TestSnapshotExport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pool.ndjson")
+	if err := os.WriteFile(path, []byte("{\"earlier\":true}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	clock := pooltest.NewFakeClock(time.Unix(100, 0))
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithSnapshotExport[*int](path, time.Second),
+		WithPoolClock[*int](clock),
+		WithTelemetryPrefix[*int]("jobs"),
+		WithStats[*int](),
+	)
+	pool.Put(pool.Get())
+
+	for range 2 {
+		waitFor(t, func() bool { return clock.Waiters() == 1 })
+		clock.Advance(time.Second)
+	}
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	pool.Close()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var lines []string
+	for sc := bufio.NewScanner(f); sc.Scan(); {
+		lines = append(lines, sc.Text())
+	}
+	if len(lines) != 3 {
+		t.Fatalf("file has %d lines, want the earlier one and 2 records:\n%q", len(lines), lines)
+	}
+	for i, line := range lines[1:] {
+		var rec snapshotRecord
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("record %d: %v", i, err)
+		}
+		want := time.Unix(101+int64(i), 0).UnixNano()
+		if rec.Time != want || rec.Name != "jobs" || rec.Stats.Gets != 1 || rec.Stats.Puts != 1 {
+			t.Errorf("record %d = %+v, want ts %d for jobs with 1 get and 1 put", i, rec, want)
+		}
+	}
+}
+
+func TestSnapshotExportRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pool.ndjson")
+	clock := pooltest.NewFakeClock(time.Unix(0, 0))
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithSnapshotRotation[*int](1, 1), // every record starts a new file
+		WithSnapshotExport[*int](path, time.Second),
+		WithPoolClock[*int](clock),
+	)
+	for range 2 {
+		waitFor(t, func() bool { return clock.Waiters() == 1 })
+		clock.Advance(time.Second)
+	}
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	pool.Close()
+
+	for _, name := range []string{path, path + ".1"} {
+		if data, err := os.ReadFile(name); err != nil || len(data) == 0 {
+			t.Errorf("%s: %q, %v; want one record", filepath.Base(name), data, err)
+		}
+	}
+}
+
+func TestSnapshotExportRotatesDaily(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pool.ndjson")
+	clock := pooltest.NewFakeClock(time.Date(2024, 3, 9, 23, 59, 58, 0, time.UTC))
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithSnapshotDailyRotation[*int](7),
+		WithSnapshotExport[*int](path, time.Second),
+		WithPoolClock[*int](clock),
+	)
+	// Records at 23:59:59 and, in a new file, 00:00:00 and 00:00:01.
+	for range 3 {
+		waitFor(t, func() bool { return clock.Waiters() == 1 })
+		clock.Advance(time.Second)
+	}
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	pool.Close()
+
+	files := readRotated(t, path)
+	if len(files) != 2 || strings.Count(files[0], "\n") != 2 || strings.Count(files[1], "\n") != 1 {
+		t.Fatalf("files = %q, want today's 2 records and yesterday's 1", files)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ArditZubaku/go-sync-pool/pooltest"
+)
+
+func TestMetricsSnapshotDeliversUntilClose(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Unix(0, 0))
+	snaps := make(chan PoolSnapshot, 64)
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithPoolClock[*int](clock),
+		WithStats[*int](),
+		WithTelemetryPrefix[*int]("jobs"),
+		WithMetricsSnapshot[*int](time.Second, func(s PoolSnapshot) { snaps <- s }),
+	)
+	pool.Put(pool.Get())
+
+	for i := range 2 {
+		waitFor(t, func() bool { return clock.Waiters() == 1 })
+		clock.Advance(time.Second)
+		waitFor(t, func() bool { return len(snaps) == 1 })
+		if s := <-snaps; s.Name != "jobs" || s.Stats.Gets != 1 || s.Stats.Puts != 1 {
+			t.Fatalf("snapshot %d = %+v, want jobs with 1 get and 1 put", i, s)
+		}
+	}
+
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	pool.Close()
+	clock.Advance(time.Second)
+	if n := len(snaps); n != 0 {
+		t.Fatalf("%d snapshots delivered after Close", n)
+	}
+}
+
+func TestMetricsSnapshotKeepsSchedule(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Unix(0, 0))
+	snaps := make(chan PoolSnapshot, 64)
+	slow := true
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithPoolClock[*int](clock),
+		WithMetricsSnapshot[*int](time.Second, func(s PoolSnapshot) {
+			if slow {
+				slow = false
+				clock.Advance(400 * time.Millisecond)
+			}
+			snaps <- s
+		}),
+	)
+	defer pool.Close()
+
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	clock.Advance(time.Second)
+	<-snaps
+
+	// The slow first snapshot took 400ms of the second's interval.
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	clock.Advance(600 * time.Millisecond)
+	select {
+	case <-snaps:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the second snapshot slipped behind the schedule")
+	}
+}
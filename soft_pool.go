@@ -0,0 +1,114 @@
+//go:build go1.24
+
+package main
+
+import (
+	"sync"
+	"weak"
+)
+
+// SoftPool keeps up to a fixed number of idle objects strongly and the rest
+// behind weak pointers, so they stay reusable until the GC actually needs
+// their memory. It sits between BoundedPool, which pins everything it holds,
+// and TypedPool, which may lose everything at any GC.
+type SoftPool[T any] struct {
+	mu     sync.Mutex
+	strong []*T
+	weak   []weak.Pointer[T]
+	newFn  func() *T
+	cfg    poolConfig[*T]
+	stats  *poolStats
+}
+
+var _ Pool[*int] = (*SoftPool[int])(nil)
+
+// NewSoftPool creates a SoftPool that strongly retains up to strong idle
+// objects.
+func NewSoftPool[T any](strong int, newFn func() *T, opts ...PoolOption[*T]) *SoftPool[T] {
+	sp := &SoftPool[T]{
+		strong: make([]*T, 0, strong),
+		newFn:  newFn,
+	}
+	for _, opt := range opts {
+		opt(&sp.cfg)
+	}
+	sp.stats = newPoolStats(sp.cfg.stats)
+
+	return sp
+}
+
+// Get returns a strongly held object, then any weakly held object the GC has
+// not reclaimed yet, and otherwise a new one. It never returns nil unless the
+// constructor does.
+func (sp *SoftPool[T]) Get() *T {
+	p, _ := sp.GetInfo()
+	return p
+}
+
+// GetInfo is Get, also reporting whether the object was constructed for
+// this call.
+func (sp *SoftPool[T]) GetInfo() (*T, Origin) {
+	sp.mu.Lock()
+	if n := len(sp.strong); n > 0 {
+		p := sp.strong[n-1]
+		sp.strong[n-1] = nil
+		sp.strong = sp.strong[:n-1]
+		sp.mu.Unlock()
+		sp.stats.hit()
+		return p, OriginReused
+	}
+
+	for n := len(sp.weak); n > 0; n-- {
+		w := sp.weak[n-1]
+		sp.weak = sp.weak[:n-1]
+		if p := w.Value(); p != nil {
+			sp.mu.Unlock()
+			sp.stats.hit()
+			return p, OriginReused
+		}
+	}
+	sp.mu.Unlock()
+
+	sp.stats.miss()
+	return sp.newFn(), OriginNew
+}
+
+// Put returns p to the pool. Nil pointers are ignored.
+func (sp *SoftPool[T]) Put(p *T) {
+	if p == nil {
+		return
+	}
+	sp.stats.put()
+
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	if len(sp.strong) < cap(sp.strong) {
+		sp.strong = append(sp.strong, p)
+		return
+	}
+
+	if len(sp.weak) == cap(sp.weak) {
+		sp.compact()
+	}
+	sp.weak = append(sp.weak, weak.Make(p))
+}
+
+// Stats returns the pool's counters. They are all zero unless the pool was
+// created with WithStats.
+func (sp *SoftPool[T]) Stats() Stats {
+	return sp.stats.snapshot()
+}
+
+// compact drops weak pointers whose objects have been collected, before the
+// weak list grows. sp.mu must be held.
+func (sp *SoftPool[T]) compact() {
+	live := sp.weak[:0]
+	for _, w := range sp.weak {
+		if w.Value() != nil {
+			live = append(live, w)
+		}
+	}
+	clear(sp.weak[len(live):])
+	sp.weak = live
+}
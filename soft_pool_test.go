@@ -0,0 +1,61 @@
+//go:build go1.24
+
+package main
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+)
+
+type softObject struct {
+	buf [4096]byte
+}
+
+func TestSoftPoolInvariants(t *testing.T) {
+	pool := NewSoftPool(2, func() *softObject { return new(softObject) }, WithStats[*softObject]())
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 500 {
+				objs := make([]*softObject, 1+i%5)
+				for j := range objs {
+					if objs[j] = pool.Get(); objs[j] == nil {
+						t.Error("Get returned nil")
+						return
+					}
+				}
+				for _, o := range objs {
+					pool.Put(o)
+				}
+				if i%100 == 0 {
+					runtime.GC()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	s := pool.Stats()
+	if s.Hits+s.Misses != s.Gets {
+		t.Fatalf("hits (%d) + misses (%d) != gets (%d)", s.Hits, s.Misses, s.Gets)
+	}
+	if s.Puts != s.Gets {
+		t.Fatalf("puts (%d) != gets (%d)", s.Puts, s.Gets)
+	}
+}
+
+func TestSoftPoolKeepsStrongCore(t *testing.T) {
+	pool := NewSoftPool(1, func() *softObject { return new(softObject) })
+
+	o := pool.Get()
+	pool.Put(o)
+	runtime.GC()
+
+	if got := pool.Get(); got != o {
+		t.Fatal("strongly retained object was not reused after GC")
+	}
+}
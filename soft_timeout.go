@@ -0,0 +1,25 @@
+package main
+
+import "time"
+
+// WithSoftTimeout calls onExpiry with every item that has been checked out
+// for longer than d, once per checkout. onExpiry may log, cancel work tied
+// to the item, or Put it back. Where WithDeadlockDetector only warns, this
+// hands each overdue item to the caller. Like the detector, it tracks only
+// pointer-like item types and stops on Close.
+func WithSoftTimeout[T any](d time.Duration, onExpiry func(T)) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.softTimeout = d
+		cfg.onExpiry = onExpiry
+	}
+}
+
+// startSoftTimeout begins the periodic scan for expired borrows.
+func (tp *TypedPool[T]) startSoftTimeout() {
+	d := tp.cfg.softTimeout
+	tp.bg.every(tp.cfg.schedule, tp.cfg.clock, tp.cfg.jitter, d/2, func() {
+		tp.checkouts.overdue(tp.cfg.now(), d, checkSoftTimeout, func(_ uintptr, co *checkout, _ time.Duration) {
+			tp.cfg.onExpiry(co.item.(T))
+		})
+	})
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithSoftTimeout(t *testing.T) {
+	type borrow struct{ id int }
+	expired := make(chan *borrow, 4)
+	var pool *TypedPool[*borrow]
+	pool = NewTypedPool(func() *borrow { return new(borrow) },
+		WithFIFO[*borrow](),
+		WithSoftTimeout(10*time.Millisecond, func(b *borrow) {
+			expired <- b
+			pool.Put(b) // force the item back
+		}),
+	)
+	defer pool.Close()
+
+	held := pool.Get()
+	held.id = 1
+
+	select {
+	case b := <-expired:
+		if b != held {
+			t.Fatalf("onExpiry got %p, want the held item %p", b, held)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("onExpiry was not called for the overdue item")
+	}
+
+	pool.Close()
+	if len(expired) != 0 {
+		t.Fatalf("onExpiry ran %d more times", len(expired))
+	}
+	if got := pool.Get(); got != held {
+		t.Fatal("the force-returned item was not pooled")
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+// WithSpillover sends Puts refused because the pool holds its WithMaxItems
+// limit to overflow instead of discarding them, after any WithRecoveryPool
+// has had its turn. The send never blocks: if overflow is full the item is
+// discarded as usual. A worker draining overflow can, say, serialize items
+// to a persistent queue and Put them back on the next start. Items are sent
+// as they were Put, without WithReset, OnDiscard or Destroy, and count as
+// discards in Stats.
+func WithSpillover[T any](overflow chan<- T) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.overflow = overflow
+	}
+}
+
+// overflowTo hands v to the WithSpillover channel if the pool refused it
+// for being full and the channel has room. It reports whether v was taken.
+func (tp *TypedPool[T]) overflowTo(v T) bool {
+	if tp.cfg.overflow == nil || !tp.full() {
+		return false
+	}
+	select {
+	case tp.cfg.overflow <- v:
+	default:
+		return false
+	}
+	tp.stats.discard()
+	tp.forget(v)
+	return true
+}
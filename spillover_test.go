@@ -0,0 +1,33 @@
+package main
+
+import "testing"
+
+func TestSpillover(t *testing.T) {
+	overflow := make(chan *int, 1)
+	discarded := 0
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithMaxItems[*int](1),
+		WithSpillover[*int](overflow),
+		WithOnDiscard(func(*int) { discarded++ }),
+		WithStats[*int](),
+		WithFIFO[*int](),
+	)
+
+	a, b, c := pool.Get(), pool.Get(), pool.Get()
+	pool.Put(a)
+	pool.Put(b) // full: spills over
+	pool.Put(c) // full, and so is the channel: discarded
+
+	if got := <-overflow; got != b {
+		t.Fatal("the channel did not get the first refused item")
+	}
+	if discarded != 1 {
+		t.Fatalf("OnDiscard ran %d times, want once for the item the channel had no room for", discarded)
+	}
+	if s := pool.Stats(); s.Discards != 2 {
+		t.Fatalf("Discards = %d, want 2", s.Discards)
+	}
+	if got := pool.Get(); got != a {
+		t.Fatal("Get did not return the pooled item")
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"io"
+	"math/rand/v2"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// stackSampleDepth is the number of caller frames a WithStackSampler sample
+// records.
+const stackSampleDepth = 4
+
+// WithStackSampler writes the call stack of a random fraction rate of Gets
+// to out, to show which code paths lean on the pool hardest. Each sample is
+// one line holding the innermost four frames outside the pool, as
+// "function file:line", joined by " <- ". WithGetMiddleware functions are
+// the caller's code and count among them:
+//
+//	example.com/app.render /src/app/render.go:41 <- example.com/app.handle /src/app/http.go:102 <- ...
+//
+// Unlike a CPU profile it only costs anything on the sampled calls: a stack
+// walk and a write, serialized so concurrent samples never interleave. It
+// panics unless rate is between 0 and 1.
+func WithStackSampler[T any](rate float64, out io.Writer) PoolOption[T] {
+	if rate < 0 || rate > 1 {
+		panic("WithStackSampler: rate must be between 0 and 1")
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.stackSampler = &stackSampler{rate: rate, out: out}
+	}
+}
+
+// stackSampler holds the WithStackSampler settings.
+type stackSampler struct {
+	rate float64
+	mu   sync.Mutex
+	out  io.Writer
+}
+
+// stackSamplePoolFrames bounds the pool frames between a Get's caller and
+// sample without WithGetMiddleware: getItem, getRecycled, getFrom, get, the
+// exported method and spares for wrappers such as SizedPool's. stackSampleMiddlewareFrames is what each
+// middleware adds, its own frame and the pool's closure around it, with one
+// to spare for the pool's frames that start the chain.
+const (
+	stackSamplePoolFrames       = 8
+	stackSampleMiddlewareFrames = 3
+)
+
+// sample writes the caller's stack with probability rate. It must be called
+// from the pool's getItem; middlewares is the number of WithGetMiddleware
+// functions the Get runs through.
+func (s *stackSampler) sample(middlewares int) {
+	if s == nil || rand.Float64() >= s.rate {
+		return
+	}
+	pcs := make([]uintptr, stackSampleDepth+stackSamplePoolFrames+middlewares*stackSampleMiddlewareFrames)
+	// Skip runtime.Callers and sample.
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+	var b strings.Builder
+	n := 0
+	for n < stackSampleDepth {
+		frame, more := frames.Next()
+		// The pool's own frames can sit anywhere below the caller, between
+		// middlewares as well as at the top, so each one is left out.
+		if isPoolMethod(frame.Function) {
+			if !more {
+				break
+			}
+			continue
+		}
+		if n > 0 {
+			b.WriteString(" <- ")
+		}
+		b.WriteString(frame.Function)
+		b.WriteByte(' ')
+		b.WriteString(frame.File)
+		b.WriteByte(':')
+		b.WriteString(strconv.Itoa(frame.Line))
+		n++
+		if !more {
+			break
+		}
+	}
+	b.WriteByte('\n')
+
+	s.mu.Lock()
+	io.WriteString(s.out, b.String())
+	s.mu.Unlock()
+}
+
+// isPoolMethod reports whether fn names a TypedPool or SizedPool method or
+// a closure inside one.
+func isPoolMethod(fn string) bool {
+	return strings.Contains(fn, ".(*TypedPool[") || strings.Contains(fn, ".(*SizedPool[")
+}
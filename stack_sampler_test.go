@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+//go:noinline
+func sampledCaller(pool *TypedPool[*int]) *int { return pool.Get() }
+
+func TestStackSampler(t *testing.T) {
+	var out bytes.Buffer
+	pool := NewTypedPool(func() *int { return new(int) }, WithStackSampler[*int](1, &out))
+
+	sampledCaller(pool)
+	pool.TryGet()
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d samples, want 2:\n%s", len(lines), out.String())
+	}
+	if !strings.HasSuffix(strings.Fields(lines[0])[0], ".sampledCaller") ||
+		!strings.Contains(lines[0], " <- github.com/ArditZubaku/go-sync-pool.TestStackSampler ") {
+		t.Errorf("first sample = %q, want sampledCaller then TestStackSampler", lines[0])
+	}
+	if !strings.HasSuffix(strings.Fields(lines[1])[0], ".TestStackSampler") {
+		t.Errorf("second sample = %q, want TestStackSampler first", lines[1])
+	}
+	for _, line := range lines {
+		if n := strings.Count(line, " <- ") + 1; n > stackSampleDepth {
+			t.Errorf("sample has %d frames, want at most %d: %s", n, stackSampleDepth, line)
+		}
+		if strings.Contains(line, "TypedPool") {
+			t.Errorf("sample includes pool frames: %s", line)
+		}
+	}
+
+	out.Reset()
+	never := NewTypedPool(func() *int { return new(int) }, WithStackSampler[*int](0, &out))
+	never.Get()
+	if out.Len() != 0 {
+		t.Fatalf("rate 0 sampled: %s", out.String())
+	}
+}
+
+func TestStackSamplerSkipsPoolFramesBetweenMiddlewares(t *testing.T) {
+	var out bytes.Buffer
+	pass := func(next func() *int) *int { return next() }
+	opts := []PoolOption[*int]{
+		WithStackSampler[*int](1, &out),
+		WithObjectRecycle(func(v *int) *int { return v }),
+	}
+	for range 2 {
+		opts = append(opts, WithGetMiddleware(pass))
+	}
+	pool := NewTypedPool(func() *int { return new(int) }, opts...)
+
+	sampledCaller(pool)
+
+	line := strings.TrimSuffix(out.String(), "\n")
+	if strings.Contains(line, "TypedPool") {
+		t.Errorf("sample includes pool frames: %s", line)
+	}
+	if !strings.Contains(line, ".sampledCaller ") {
+		t.Errorf("sample = %q, want the two middlewares then sampledCaller", line)
+	}
+}
@@ -0,0 +1,19 @@
+package main
+
+import "slices"
+
+// WithStackedPools chains the pool in front of pools, say a local pool ahead
+// of regional and global ones with their own limits and GC pressure. A Get
+// that finds the pool empty takes an idle item from the first of pools that
+// has one, after any WithRecoveryPool, and constructs one only once every
+// level is empty. Puts always return to this pool, the primary; the other
+// levels are only filled by their own Puts. None of pools may lead back to
+// the primary pool.
+func WithStackedPools[T any](pools []*TypedPool[T]) PoolOption[T] {
+	if slices.Contains(pools, nil) {
+		panic("WithStackedPools: pools must not be nil")
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.stacked = slices.Clone(pools)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import "testing"
+
+func TestStackedPools(t *testing.T) {
+	regional := NewTypedPool(func() *int { return new(int) }, WithFIFO[*int](), WithStats[*int]())
+	global := NewTypedPool(func() *int { return new(int) }, WithFIFO[*int](), WithStats[*int]())
+	news := 0
+	local := NewTypedPool(func() *int { news++; return new(int) },
+		WithStackedPools([]*TypedPool[*int]{regional, global}), WithFIFO[*int](), WithStats[*int]())
+
+	r, g := new(int), new(int)
+	regional.Put(r)
+	global.Put(g)
+
+	if got := local.Get(); got != r {
+		t.Fatal("first Get did not come from the regional pool")
+	}
+	if got := local.Get(); got != g {
+		t.Fatal("second Get did not fall through to the global pool")
+	}
+	if local.Get(); news != 1 {
+		t.Fatalf("constructor calls = %d once every level was empty, want 1", news)
+	}
+	if regional.Stats().Hits != 1 || global.Stats().Hits != 1 {
+		t.Fatalf("level hits = %d, %d; want 1, 1", regional.Stats().Hits, global.Stats().Hits)
+	}
+
+	local.Put(r)
+	if local.Len() != 1 || regional.Len() != 0 {
+		t.Fatalf("Len local, regional = %d, %d after Put; want 1, 0", local.Len(), regional.Len())
+	}
+}
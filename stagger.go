@@ -0,0 +1,24 @@
+package main
+
+import "time"
+
+// WithStagger waits d, by the pool's Clock, between items in the pool's bulk operations: Warmup,
+// Drain and HealthCheck on a TypedPool, and Warmup and the
+// WithItemTTLSweeper sweep on a BoundedPool. Spreading the work out keeps
+// them from monopolizing a CPU or the pool's lock, at the cost of taking
+// longer, which improves tail latency for concurrent Gets and Puts. A
+// BoundedPool's Drain and HealthCheck hold its lock throughout, so they are
+// not staggered.
+func WithStagger[T any](d time.Duration) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.staggerDelay = d
+	}
+}
+
+// staggerBefore waits for the WithStagger delay before item i of a bulk
+// operation, unless it is the first.
+func (cfg *poolConfig[T]) staggerBefore(i int) {
+	if cfg.staggerDelay > 0 && i > 0 {
+		<-after(cfg.clock, cfg.staggerDelay)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ArditZubaku/go-sync-pool/pooltest"
+)
+
+func TestStaggerSpacesBulkOperations(t *testing.T) {
+	const d = 5 * time.Millisecond
+	clock := pooltest.NewFakeClock(time.Unix(0, 0))
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithStagger[*int](d),
+		WithPoolClock[*int](clock),
+		WithFIFO[*int](),
+	)
+
+	// Each bulk operation waits on the clock before its second and third
+	// items.
+	for _, op := range []struct {
+		name string
+		run  func()
+	}{
+		{"Warmup(3)", func() { pool.Warmup(3) }},
+		{"Drain", func() { pool.Drain() }},
+	} {
+		done := make(chan struct{})
+		go func() {
+			op.run()
+			close(done)
+		}()
+		for range 2 {
+			waitFor(t, func() bool { return clock.Waiters() == 1 })
+			select {
+			case <-done:
+				t.Fatalf("%s finished without waiting out the stagger", op.name)
+			default:
+			}
+			clock.Advance(d)
+		}
+		<-done
+	}
+	if n := pool.Len(); n != 0 {
+		t.Fatalf("Len after Drain = %d, want 0", n)
+	}
+}
+
+func TestStaggeredSweepDiscardsAllExpired(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Unix(0, 0))
+	var discarded atomic.Int64
+	pool := NewBoundedPool(4, func() *int { return new(int) },
+		WithIdleTTL[*int](time.Second),
+		WithItemTTLSweeper[*int](2*time.Second),
+		WithStagger[*int](time.Millisecond),
+		WithPoolClock[*int](clock),
+		WithOnDiscard(func(*int) { discarded.Add(1) }),
+	)
+	defer pool.Close()
+
+	pool.Put(new(int))
+	pool.Put(new(int))
+	pool.Put(new(int))
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	clock.Advance(2 * time.Second)
+	// The sweep waits out the stagger before its second and third objects.
+	waitFor(t, func() bool {
+		clock.Advance(time.Millisecond)
+		return discarded.Load() == 3
+	})
+	if n := pool.Len(); n != 0 {
+		t.Fatalf("Len after the sweep = %d, want 0", n)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import "slices"
+
+// WithStaticFields renders kv, alternating keys and values like the
+// arguments of Info, once when the Logger is built, and copies the result
+// into every line after the message, ahead of the call's own arguments. It
+// suits fields such as the hostname, pid and service name that never
+// change. Given more than once, the fields accumulate.
+func WithStaticFields(kv ...any) LoggerOption {
+	return func(l *Logger) {
+		l.staticKVs = append(l.staticKVs, kv...)
+	}
+}
+
+// With returns a child Logger whose lines carry kv after the parent's
+// static fields. The child is rendered into the same pool and shares the
+// parent's writers, level and async queues, so Close on either closes both.
+func (l *Logger) With(kv ...any) *Logger {
+	child := *l
+	child.static = append(slices.Clip(l.static), l.renderStatic(kv)...)
+	return &child
+}
+
+// renderStatic renders kv in the Logger's format, each pair preceded by its
+// separator: " key=value" in text and ,"key":value in JSON.
+func (l *Logger) renderStatic(kv []any) []byte {
+	if len(kv) == 0 {
+		return nil
+	}
+
+	b := buffPool.Get()
+	b.Reset()
+	if l.json {
+		appendJSONKVs(b, kv)
+	} else {
+		appendKVs(b, kv)
+	}
+	static := slices.Clone(b.Bytes())
+	buffPool.Put(b)
+	return static
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ArditZubaku/go-sync-pool/pooltest"
+)
+
+func TestStaticFieldsMatchPerCallFields(t *testing.T) {
+	static := []any{"host", "web-1", "pid", 4242, "service", "checkout api"}
+	clock := pooltest.NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	for _, mode := range []struct {
+		name string
+		opts []LoggerOption
+	}{
+		{"text", nil},
+		{"json", []LoggerOption{WithJSON()}},
+	} {
+		t.Run(mode.name, func(t *testing.T) {
+			base := append([]LoggerOption{WithClock(clock), WithUTC()}, mode.opts...)
+			var cached, reference bytes.Buffer
+			logger := NewLogger(&cached, append(base, WithStaticFields(static...))...)
+			plain := NewLogger(&reference, base...)
+
+			logger.Info("no args")
+			plain.Info("no args", static...)
+			logger.Warn("with args", "user", 7, "ok", true)
+			plain.Warn("with args", append(static, "user", 7, "ok", true)...)
+
+			if cached.String() != reference.String() {
+				t.Fatalf("cached fields:\n%s\nreference:\n%s", cached.String(), reference.String())
+			}
+		})
+	}
+}
+
+func TestWithExtendsStaticFields(t *testing.T) {
+	for _, mode := range []struct {
+		name string
+		opts []LoggerOption
+		want string
+	}{
+		{"text", []LoggerOption{WithTimeLayout("")},
+			"INFO : parent svc=api\nINFO : child svc=api req=9 k=1\nINFO : sibling svc=api req=10\n"},
+		{"json", []LoggerOption{WithTimeLayout(""), WithJSON()},
+			`{"level":"info","msg":"parent","svc":"api"}` + "\n" +
+				`{"level":"info","msg":"child","svc":"api","req":9,"k":1}` + "\n" +
+				`{"level":"info","msg":"sibling","svc":"api","req":10}` + "\n"},
+	} {
+		t.Run(mode.name, func(t *testing.T) {
+			var out bytes.Buffer
+			parent := NewLogger(&out, append(mode.opts, WithStaticFields("svc", "api"))...)
+			child := parent.With("req", 9)
+			sibling := parent.With("req", 10) // must not overwrite the child's fields
+
+			parent.Info("parent")
+			child.Info("child", "k", 1)
+			sibling.Info("sibling")
+
+			if out.String() != mode.want {
+				t.Fatalf("got\n%s\nwant\n%s", out.String(), mode.want)
+			}
+		})
+	}
+}
+
+func TestWithSharesLevel(t *testing.T) {
+	var out bytes.Buffer
+	parent := NewLogger(&out, WithTimeLayout(""))
+	child := parent.With("k", 1)
+
+	parent.SetLevel(LevelError)
+	child.Info("hidden")
+	if out.Len() != 0 {
+		t.Fatalf("child ignored the parent's level: %q", out.String())
+	}
+}
+
+func TestStaticFieldsLogf(t *testing.T) {
+	var out bytes.Buffer
+	logger := NewLogger(&out, WithTimeLayout(""), WithStaticFields("svc", "api"))
+	logger.Infof("n=%d", 3)
+
+	if got, want := out.String(), "INFO : n=3 svc=api\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"math/rand/v2"
+	"runtime"
+	"sync/atomic"
+)
+
+// Stats is a point-in-time view of a pool's counters.
+type Stats struct {
+	Gets     int64 `json:"gets"`     // Get calls
+	Hits     int64 `json:"hits"`     // Gets served by a pooled item
+	Misses   int64 `json:"misses"`   // Gets that called the constructor
+	Puts     int64 `json:"puts"`     // Put calls
+	Discards int64 `json:"discards"` // Puts the pool refused to keep
+	Prefills int64 `json:"prefills"` // items built by the WithMinIdle refiller
+
+	// RetainedBytes is the size of the idle items, as reported by the
+	// WithSizeFunc function; 0 without one. For TypedPool it is an estimate,
+	// since the GC may clear items without the pool noticing until its next
+	// miss.
+	RetainedBytes int64 `json:"retained_bytes"`
+
+	// PeakConcurrency is the most items checked out at once since the pool
+	// was created or ResetStats was called; 0 without
+	// WithConcurrencyProfile.
+	PeakConcurrency int64 `json:"peak_concurrency"`
+
+	// BarrierDiscards counts the Puts turned away by WithWriteBarrier since
+	// the pool was created or ResetStats was called. Like PeakConcurrency it
+	// does not need WithStats.
+	BarrierDiscards int64 `json:"barrier_discards"`
+
+	// BorrowDepthHistogram counts Gets by how many items were already
+	// checked out, in the buckets described at WithBorrowCapTrace; all zero
+	// without it.
+	BorrowDepthHistogram [8]uint64 `json:"borrow_depth_histogram"`
+
+	// GetLatencyHistogram counts Gets by how long they took: bucket i for
+	// the i-th WithLatencyHistogram bound, and the last bucket for Gets
+	// beyond every bound; all zero without it.
+	GetLatencyHistogram [16]uint64 `json:"get_latency_histogram"`
+}
+
+// poolStats holds the live counters behind Stats. A nil *poolStats records
+// nothing, so pools without WithStats pay only a nil check.
+//
+// The counters are split into GOMAXPROCS-many shards, each on cache lines of
+// its own, and summed on read, so Gets and Puts on different Ps mostly
+// update different lines rather than all sharing one. Updates pick a shard
+// at random, since Go exposes no per-P index. BenchmarkStatsParallel
+// compares the cost with and without stats.
+type poolStats struct {
+	shards []statShard
+	mask   uint32
+}
+
+// statShard is one shard of poolStats, padded so that neighbouring shards
+// never share a cache line, nor the adjacent line some CPUs prefetch with it.
+// Gets is not counted: it is Hits plus Misses.
+type statShard struct {
+	hits     atomic.Int64
+	misses   atomic.Int64
+	puts     atomic.Int64
+	discards atomic.Int64
+	prefills atomic.Int64
+	retained atomic.Int64
+	_        [128 - 6*8]byte
+}
+
+// WithStats enables the counters reported by Stats.
+func WithStats[T any]() PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.stats = true
+	}
+}
+
+func newPoolStats(enabled bool) *poolStats {
+	if !enabled {
+		return nil
+	}
+	n := 1
+	for n < runtime.GOMAXPROCS(0) {
+		n <<= 1
+	}
+	return &poolStats{shards: make([]statShard, n), mask: uint32(n - 1)}
+}
+
+// shard returns the shard for the calling goroutine to update.
+func (s *poolStats) shard() *statShard {
+	return &s.shards[rand.Uint32()&s.mask]
+}
+
+func (s *poolStats) hit() {
+	if s != nil {
+		s.shard().hits.Add(1)
+	}
+}
+
+func (s *poolStats) miss() {
+	if s != nil {
+		s.shard().misses.Add(1)
+	}
+}
+
+func (s *poolStats) put() {
+	if s != nil {
+		s.shard().puts.Add(1)
+	}
+}
+
+func (s *poolStats) discard() {
+	if s != nil {
+		sh := s.shard()
+		sh.puts.Add(1)
+		sh.discards.Add(1)
+	}
+}
+
+func (s *poolStats) prefill() {
+	if s != nil {
+		s.shard().prefills.Add(1)
+	}
+}
+
+// addRetained adjusts the retained-bytes gauge by delta.
+func (s *poolStats) addRetained(delta int64) {
+	if s != nil {
+		s.shard().retained.Add(delta)
+	}
+}
+
+// clampRetained lowers the retained-bytes gauge to at most max.
+func (s *poolStats) clampRetained(max int64) {
+	if s == nil {
+		return
+	}
+	var total int64
+	for i := range s.shards {
+		total += s.shards[i].retained.Load()
+	}
+	if total > max {
+		s.shard().retained.Add(max - total)
+	}
+}
+
+// reset zeroes the event counters. The retained-bytes gauge is kept.
+func (s *poolStats) reset() {
+	if s != nil {
+		for i := range s.shards {
+			sh := &s.shards[i]
+			sh.hits.Store(0)
+			sh.misses.Store(0)
+			sh.puts.Store(0)
+			sh.discards.Store(0)
+			sh.prefills.Store(0)
+		}
+	}
+}
+
+// snapshot sums the shards. Like the counters themselves, the sum is not
+// taken atomically, so it may mix updates made while it runs.
+func (s *poolStats) snapshot() Stats {
+	if s == nil {
+		return Stats{}
+	}
+	var out Stats
+	for i := range s.shards {
+		sh := &s.shards[i]
+		out.Hits += sh.hits.Load()
+		out.Misses += sh.misses.Load()
+		out.Puts += sh.puts.Load()
+		out.Discards += sh.discards.Load()
+		out.Prefills += sh.prefills.Load()
+		out.RetainedBytes += sh.retained.Load()
+	}
+	out.Gets = out.Hits + out.Misses
+	return out
+}
+
+// PoolCounters implements pooltest.Reporter.
+func (tp *TypedPool[T]) PoolCounters() (hits, misses, retainedBytes int64) {
+	s := tp.Stats()
+	return s.Hits, s.Misses, s.RetainedBytes
+}
+
+// PoolCounters implements pooltest.Reporter.
+func (bp *BoundedPool[T]) PoolCounters() (hits, misses, retainedBytes int64) {
+	s := bp.Stats()
+	return s.Hits, s.Misses, s.RetainedBytes
+}
+
+// Stats returns the pool's counters. They are all zero unless the pool was
+// created with WithStats.
+func (tp *TypedPool[T]) Stats() Stats {
+	s := tp.stats.snapshot()
+	s.PeakConcurrency = tp.conc.peak()
+	s.BarrierDiscards = tp.barrierDiscards.Load()
+	s.BorrowDepthHistogram = tp.conc.histogram()
+	s.GetLatencyHistogram = tp.latency.snapshot()
+	return s
+}
+
+// ResetStats zeroes the counters and restarts the WithConcurrencyProfile
+// peak from the items checked out now. RetainedBytes is a gauge and is not
+// reset.
+func (tp *TypedPool[T]) ResetStats() {
+	tp.stats.reset()
+	tp.window.reset()
+	tp.conc.reset()
+	tp.latency.reset()
+	tp.barrierDiscards.Store(0)
+}
+
+// Stats returns the pool's counters. They are all zero unless the pool was
+// created with WithStats.
+func (bp *BoundedPool[T]) Stats() Stats {
+	s := bp.stats.snapshot()
+	if bp.stats != nil {
+		s.RetainedBytes = bp.RetainedBytes()
+	}
+	return s
+}
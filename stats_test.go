@@ -0,0 +1,72 @@
+package main
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// BenchmarkStatsParallel measures Get and Put from every P with and without
+// the sharded stats counters. Run it with -cpu 1,N on a machine with N cores;
+// with fewer cores than Ps the parallel runs only interleave.
+func BenchmarkStatsParallel(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		opts []PoolOption[*int]
+	}{
+		{"nostats", nil},
+		{"stats", []PoolOption[*int]{WithStats[*int]()}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			pool := NewTypedPool(func() *int { return new(int) }, bc.opts...)
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					pool.Put(pool.Get())
+				}
+			})
+		})
+	}
+}
+
+func TestStatsShardsSumToReference(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(8))
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithStats[*int](), WithMaxItems[*int](4))
+	if n := len(pool.stats.shards); n < 8 {
+		t.Fatalf("got %d shards with GOMAXPROCS=8", n)
+	}
+
+	var gets, puts atomic.Int64
+	var wg sync.WaitGroup
+	wg.Add(16)
+	for g := range 16 {
+		go func() {
+			defer wg.Done()
+			var held []*int
+			for i := range 2000 {
+				held = append(held, pool.Get())
+				gets.Add(1)
+				if (i+g)%3 != 0 {
+					pool.Put(held[len(held)-1])
+					held = held[:len(held)-1]
+					puts.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	s := pool.Stats()
+	if s.Gets != gets.Load() || s.Hits+s.Misses != s.Gets {
+		t.Fatalf("Gets = %d (hits %d + misses %d), want %d", s.Gets, s.Hits, s.Misses, gets.Load())
+	}
+	if s.Puts != puts.Load() {
+		t.Fatalf("Puts = %d, want %d", s.Puts, puts.Load())
+	}
+
+	pool.ResetStats()
+	if s := pool.Stats(); s.Gets != 0 || s.Puts != 0 {
+		t.Fatalf("Stats after ResetStats = %+v", s)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// Writer returns an io.Writer that logs what is written to it at level, so
+// the standard library's log package can be pointed at the Logger with
+// log.SetOutput. It takes each Write ending in a newline as one message,
+// without that newline; a message split across several Writes is held in a
+// pooled buffer until the newline arrives. The returned writer is safe for
+// concurrent use.
+func (l *Logger) Writer(level Level) io.Writer {
+	return &levelWriter{l: l, level: level}
+}
+
+// levelWriter is the io.Writer returned by Logger.Writer.
+type levelWriter struct {
+	l     *Logger
+	level Level
+
+	mu      sync.Mutex
+	pending *bytes.Buffer // the start of a message, nil between messages
+}
+
+func (w *levelWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(p) == 0 || p[len(p)-1] != '\n' {
+		if w.pending == nil {
+			w.pending = buffPool.Get()
+			w.pending.Reset()
+		}
+		w.pending.Write(p)
+		return len(p), nil
+	}
+
+	msg := p[:len(p)-1]
+	if w.pending != nil {
+		w.pending.Write(msg)
+		msg = w.pending.Bytes()
+	}
+
+	var err error
+	if w.l.renders(w.level) {
+		err = w.l.write(w.level, bytesString(msg), nil, 0)
+	}
+	if w.pending != nil {
+		buffPool.Put(w.pending)
+		w.pending = nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
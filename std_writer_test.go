@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	stdlog "log"
+	"testing"
+)
+
+func TestLoggerWriterStdlibLog(t *testing.T) {
+	var out bytes.Buffer
+	logger := NewLogger(&out, WithTimeLayout(""))
+	before := buffersInFlight()
+
+	std := stdlog.New(logger.Writer(LevelWarn), "", 0)
+	std.Printf("disk %d%% full", 91)
+	std.Print("two\nlines")
+
+	want := "WARN : disk 91% full\nWARN : two\\nlines\n"
+	if out.String() != want {
+		t.Fatalf("got %q, want %q", out.String(), want)
+	}
+	if n := buffersInFlight() - before; n != 0 {
+		t.Fatalf("%d buffers leaked", n)
+	}
+}
+
+func TestLoggerWriterSplitMessage(t *testing.T) {
+	var out bytes.Buffer
+	logger := NewLogger(&out, WithTimeLayout(""))
+	before := buffersInFlight()
+	w := logger.Writer(LevelInfo)
+
+	w.Write([]byte("partial "))
+	w.Write([]byte("message"))
+	if out.Len() != 0 {
+		t.Fatalf("wrote %q before the newline", out.String())
+	}
+	w.Write([]byte(" done\n"))
+
+	if got, want := out.String(), "INFO : partial message done\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if n := buffersInFlight() - before; n != 0 {
+		t.Fatalf("%d buffers leaked", n)
+	}
+}
+
+func TestLoggerWriterBelowLevel(t *testing.T) {
+	var out bytes.Buffer
+	logger := NewLogger(&out, WithTimeLayout(""))
+	before := buffersInFlight()
+
+	n, err := logger.Writer(LevelDebug).Write([]byte("quiet\n"))
+	if n != 6 || err != nil {
+		t.Fatalf("Write = %d, %v; want 6, nil", n, err)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("wrote %q below the level", out.String())
+	}
+	if n := buffersInFlight() - before; n != 0 {
+		t.Fatalf("%d buffers leaked", n)
+	}
+}
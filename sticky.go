@@ -0,0 +1,165 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// maxStickyItems caps the items WithStickyPool parks, so owners that never
+// come back, such as finished requests, cannot grow the table without
+// bound.
+const maxStickyItems = 1024
+
+// WithStickyPool returns items to the caller that put them back: Put parks
+// the item under the owner ID that id returns, a goroutine or request ID
+// say, and the owner's next Get takes it back before looking in the shared
+// store, so it finds its own, cache-warm item. Each owner parks one item;
+// further Puts, those from owner 0, and those past 1024 parked items in
+// all go to the shared store. Parked items do not count toward WithMaxItems,
+// WithObjectLimit or Len, and stay until their owner's next Get or Drain.
+// Owners that park nothing for a few garbage collections, such as finished
+// goroutines, which never come back, have their items moved to the shared
+// store on a later miss, or when the table is full. Parking happens on the
+// calling goroutine, ahead of any WithAsyncPut queue.
+func WithStickyPool[T any](id func() uint64) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.stickyID = id
+	}
+}
+
+// stickyItems is the WithStickyPool table.
+type stickyItems struct {
+	byOwner  sync.Map // uint64 -> *localSlot[T]
+	n        atomic.Int64
+	sweptGen atomic.Int64
+}
+
+// park stores v under the caller's owner ID and reports whether it did, in
+// which case it stands in for the shared store's Put.
+func (tp *TypedPool[T]) park(v T) bool {
+	if tp.sticky == nil {
+		return false
+	}
+	owner := tp.cfg.stickyID()
+	if owner == 0 {
+		return false
+	}
+	if tp.sticky.n.Add(1) > maxStickyItems {
+		tp.sticky.n.Add(-1)
+		tp.sweepParked()
+		return false
+	}
+	if !tp.parkUnder(owner, v) {
+		tp.sticky.n.Add(-1)
+		return false
+	}
+
+	tp.stats.put()
+	tp.audit(auditPut, v)
+	if tp.reuse != nil {
+		tp.reuse.pooled(uintptr(itemIdentity(v)))
+		tp.recordHot(v)
+	}
+	tp.markVersioned(v, true)
+	return true
+}
+
+// parkUnder stores v in owner's slot and reports whether there was room.
+// It only resets v once it has room, so an item that goes on to the shared
+// store is not reset twice.
+func (tp *TypedPool[T]) parkUnder(owner uint64, v T) bool {
+	for {
+		slot, ok := tp.sticky.byOwner.Load(owner)
+		if !ok {
+			slot, _ = tp.sticky.byOwner.LoadOrStore(owner, new(localSlot[T]))
+		}
+		ls := slot.(*localSlot[T])
+		ls.mu.Lock()
+		if ls.dead {
+			// unpark emptied and removed it; start a new one.
+			ls.mu.Unlock()
+			continue
+		}
+		room := len(ls.items) < max(tp.cfg.localCache, 1)
+		if room {
+			// Reset before the item becomes visible to its owner's Get.
+			if tp.cfg.reset != nil {
+				tp.cfg.reset(v)
+			}
+			tp.recordSum(v)
+			tp.markIdle(v, true)
+			ls.items = append(ls.items, v)
+			ls.gen = gcGen()
+		}
+		ls.mu.Unlock()
+		return room
+	}
+}
+
+// unpark takes the item parked by the caller, if any; under WithLocalCache,
+// the one parked last.
+func (tp *TypedPool[T]) unpark() (T, bool) {
+	var zero T
+	if tp.sticky == nil {
+		return zero, false
+	}
+	owner := tp.cfg.stickyID()
+	slot, ok := tp.sticky.byOwner.Load(owner)
+	if !ok {
+		return zero, false
+	}
+	ls := slot.(*localSlot[T])
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	if len(ls.items) == 0 {
+		return zero, false
+	}
+	v := ls.items[len(ls.items)-1]
+	ls.items[len(ls.items)-1] = zero
+	ls.items = ls.items[:len(ls.items)-1]
+	if len(ls.items) == 0 {
+		// Owners, such as goroutines, may never come back, so an empty slot
+		// does not linger.
+		ls.dead = true
+		tp.sticky.byOwner.Delete(owner)
+	}
+	tp.sticky.n.Add(-1)
+	return v, true
+}
+
+// sweepParked moves the items of owners that have parked nothing for
+// goneAfter collections to the shared store, where other Gets and Drain
+// reach them. It runs at most once per collection.
+func (tp *TypedPool[T]) sweepParked() {
+	if tp.sticky == nil {
+		return
+	}
+	gen := gcGen()
+	last := tp.sticky.sweptGen.Load()
+	if gen == last || !tp.sticky.sweptGen.CompareAndSwap(last, gen) {
+		return
+	}
+	for _, v := range tp.unparkAll(gen - goneAfter) {
+		tp.restore(v)
+	}
+}
+
+// unparkAll takes the items of every owner that last parked one at or
+// before collection cutoff.
+func (tp *TypedPool[T]) unparkAll(cutoff int64) []T {
+	var all []T
+	tp.sticky.byOwner.Range(func(owner, slot any) bool {
+		ls := slot.(*localSlot[T])
+		ls.mu.Lock()
+		if !ls.dead && ls.gen <= cutoff {
+			all = append(all, ls.items...)
+			tp.sticky.n.Add(-int64(len(ls.items)))
+			ls.items = nil
+			ls.dead = true
+			tp.sticky.byOwner.Delete(owner)
+		}
+		ls.mu.Unlock()
+		return true
+	})
+	return all
+}
@@ -0,0 +1,116 @@
+package main
+
+import "testing"
+
+func TestStickyPoolReturnsToOwner(t *testing.T) {
+	var caller uint64
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithFIFO[*int](),
+		WithStats[*int](),
+		WithStickyPool[*int](func() uint64 { return caller }),
+	)
+
+	caller = 1
+	a := pool.Get()
+	caller = 2
+	b := pool.Get()
+
+	caller = 1
+	pool.Put(a)
+	caller = 2
+	pool.Put(b)
+	if pool.Len() != 0 {
+		t.Fatalf("Len = %d, want parked items kept out of the shared store", pool.Len())
+	}
+
+	caller = 1
+	if got := pool.Get(); got != a {
+		t.Fatal("owner 1 did not get its own item back")
+	}
+	caller = 3
+	if got := pool.Get(); got == b {
+		t.Fatal("owner 3 took owner 2's parked item")
+	}
+	caller = 2
+	if got := pool.Get(); got != b {
+		t.Fatal("owner 2 did not get its own item back")
+	}
+	if s := pool.Stats(); s.Hits != 2 || s.Misses != 3 || s.Puts != 2 {
+		t.Fatalf("Stats = %+v, want 2 hits, 3 misses, 2 puts", s)
+	}
+}
+
+func TestStickyPoolSharesOverflow(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithFIFO[*int](),
+		WithStickyPool[*int](func() uint64 { return 7 }),
+	)
+
+	a, b := pool.Get(), pool.Get()
+	pool.Put(a) // parked
+	pool.Put(b) // owner 7 already has one: shared
+	if pool.Len() != 1 {
+		t.Fatalf("Len = %d, want the second item in the shared store", pool.Len())
+	}
+	if got := pool.Get(); got != a {
+		t.Fatal("Get did not prefer the parked item")
+	}
+	if got := pool.Get(); got != b {
+		t.Fatal("Get did not fall back to the shared store")
+	}
+}
+
+func TestStickyPoolResetsOverflowOnce(t *testing.T) {
+	resets := make(map[*int]int)
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithFIFO[*int](),
+		WithReset(func(v *int) { resets[v]++ }),
+		WithStickyPool[*int](func() uint64 { return 7 }),
+	)
+
+	a, b := pool.Get(), pool.Get()
+	pool.Put(a) // parked
+	pool.Put(b) // owner 7 already has one: shared
+	if resets[a] != 1 || resets[b] != 1 {
+		t.Fatalf("resets = %d and %d, want each item reset once", resets[a], resets[b])
+	}
+}
+
+func TestStickyPoolDrainIncludesParkedItems(t *testing.T) {
+	var discarded int
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithFIFO[*int](),
+		WithOnDiscard(func(*int) { discarded++ }),
+		WithStickyPool[*int](func() uint64 { return 7 }),
+	)
+	pool.Put(new(int)) // parked
+	pool.Put(new(int)) // shared
+	if n := pool.Drain(); n != 2 || discarded != 2 {
+		t.Fatalf("Drain = %d, discarded %d; want both items", n, discarded)
+	}
+	if pool.sticky.n.Load() != 0 {
+		t.Fatal("Drain left an item parked")
+	}
+}
+
+func TestStickyPoolEvictsAbandonedOwners(t *testing.T) {
+	var owner uint64
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithFIFO[*int](),
+		WithStickyPool[*int](func() uint64 { return owner }),
+	)
+	for owner = 1; owner <= maxStickyItems; owner++ {
+		pool.Put(new(int)) // owners that never come back
+	}
+
+	collect(t, goneAfter)
+	pool.Put(new(int)) // the table is full: shared, and the table is swept
+	if got, want := pool.Len(), maxStickyItems+1; got != want {
+		t.Fatalf("Len() = %d, want the %d abandoned items in the shared store", got, want)
+	}
+	a := new(int)
+	pool.Put(a)
+	if got := pool.Get(); got != a {
+		t.Fatal("a new owner could not park after the sweep")
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import "strings"
+
+// StringPool recycles strings.Builders, the string counterpart of a
+// bytes.Buffer pool:
+//
+//	s := strPool.Format(func(sb *strings.Builder) {
+//		sb.WriteString("user ")
+//		sb.WriteString(name)
+//	})
+//
+// Builders are reset with WithAutoReset as they are handed out. A Builder's
+// String shares its memory, so Reset lets go of the buffer instead of
+// overwriting it, and strings built earlier stay intact; what the pool
+// saves is the Builder itself, not the bytes of each result.
+type StringPool struct {
+	pool *TypedPool[*strings.Builder]
+}
+
+// NewStringPool creates a StringPool; opts configure the underlying
+// TypedPool.
+func NewStringPool(opts ...PoolOption[*strings.Builder]) *StringPool {
+	opts = append([]PoolOption[*strings.Builder]{WithAutoReset[*strings.Builder]()}, opts...)
+	return &StringPool{pool: NewTypedPool(func() *strings.Builder { return new(strings.Builder) }, opts...)}
+}
+
+// GetBuilder returns an empty Builder from the pool.
+func (sp *StringPool) GetBuilder() *strings.Builder {
+	return sp.pool.Get()
+}
+
+// PutBuilder returns sb to the pool. Strings it built stay valid, but sb
+// itself must not be used afterwards.
+func (sp *StringPool) PutBuilder(sb *strings.Builder) {
+	sp.pool.Put(sb)
+}
+
+// Format runs fn on a pooled Builder and returns what it wrote.
+func (sp *StringPool) Format(fn func(sb *strings.Builder)) string {
+	sb := sp.pool.Get()
+	fn(sb)
+	s := sb.String()
+	sp.pool.Put(sb)
+	return s
+}
+
+// Stats returns the underlying pool's counters.
+func (sp *StringPool) Stats() Stats {
+	return sp.pool.Stats()
+}
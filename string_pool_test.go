@@ -0,0 +1,42 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStringPoolFormat(t *testing.T) {
+	sp := NewStringPool(WithFIFO[*strings.Builder](), WithStats[*strings.Builder]())
+
+	first := sp.Format(func(sb *strings.Builder) { sb.WriteString("hello") })
+	second := sp.Format(func(sb *strings.Builder) {
+		if sb.Len() != 0 {
+			t.Errorf("Format got a builder holding %q", sb.String())
+		}
+		sb.WriteString("world")
+	})
+	if first != "hello" || second != "world" {
+		t.Fatalf("Format returned %q and %q, want hello and world", first, second)
+	}
+	if s := sp.Stats(); s.Misses != 1 || s.Hits != 1 {
+		t.Fatalf("Stats = %+v, want the builder reused", s)
+	}
+}
+
+func TestStringPoolBuilders(t *testing.T) {
+	sp := NewStringPool(WithFIFO[*strings.Builder]())
+
+	sb := sp.GetBuilder()
+	sb.WriteString("kept")
+	kept := sb.String()
+	sp.PutBuilder(sb)
+
+	again := sp.GetBuilder()
+	if again != sb || again.Len() != 0 {
+		t.Fatalf("GetBuilder = %p holding %q, want the reset builder %p", again, again.String(), sb)
+	}
+	again.WriteString("overwritten")
+	if kept != "kept" {
+		t.Fatalf("an earlier string changed to %q", kept)
+	}
+}
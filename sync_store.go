@@ -0,0 +1,87 @@
+package main
+
+import (
+	"reflect"
+	"sync"
+)
+
+// WithItemPool sets the sync.Pool a TypedPool recycles its item envelopes
+// through. Items that are not pointer-shaped, such as slices or structs,
+// are stored in sync.Pool inside a small envelope so that Put does not box
+// them into a fresh interface; envelopes are recycled, so a Get/Put cycle
+// does not allocate. By default each pool has its own envelope pool. Pools
+// of the same item type may share one, which must have New unset.
+func WithItemPool[T any](ip *sync.Pool) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.itemPool = ip
+	}
+}
+
+// envelope carries a non-pointer item through sync.Pool.
+type envelope[T any] struct {
+	v T
+}
+
+// syncStore is the default idleStore, backed by sync.Pool. New is left
+// unset so a miss is visible as nil; put never stores nil.
+type syncStore[T any] struct {
+	pool sync.Pool
+
+	// envelopes is nil when T is pointer-shaped and goes into sync.Pool as
+	// is; otherwise it recycles the *envelope[T] values.
+	envelopes *sync.Pool
+}
+
+func newSyncStore[T any](envelopes *sync.Pool) *syncStore[T] {
+	s := new(syncStore[T])
+	if !pointerShaped[T]() {
+		if envelopes == nil {
+			envelopes = new(sync.Pool)
+		}
+		s.envelopes = envelopes
+	}
+	return s
+}
+
+// pointerShaped reports whether a T fits in an interface without
+// allocating.
+func pointerShaped[T any]() bool {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Interface:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *syncStore[T]) get() (T, bool) {
+	x := s.pool.Get()
+	if x == nil {
+		var zero T
+		return zero, false
+	}
+	if s.envelopes == nil {
+		return x.(T), true
+	}
+
+	e := x.(*envelope[T])
+	v := e.v
+	var zero T
+	e.v = zero
+	s.envelopes.Put(e)
+	return v, true
+}
+
+func (s *syncStore[T]) put(v T) {
+	if s.envelopes == nil {
+		s.pool.Put(v)
+		return
+	}
+
+	e, ok := s.envelopes.Get().(*envelope[T])
+	if !ok {
+		e = new(envelope[T])
+	}
+	e.v = v
+	s.pool.Put(e)
+}
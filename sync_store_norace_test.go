@@ -0,0 +1,73 @@
+//go:build !race
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+// The race detector makes sync.Pool drop items at random, so these only run
+// without it.
+
+func TestTypedPoolSliceCycleDoesNotAllocate(t *testing.T) {
+	pool := NewTypedPool(func() []byte { return make([]byte, 1024) })
+	pool.Put(pool.Get())
+
+	if n := testing.AllocsPerRun(100, func() { pool.Put(pool.Get()) }); n != 0 {
+		t.Fatalf("Get/Put allocated %v times per cycle, want 0", n)
+	}
+}
+
+func TestErrorPoolCycleDoesNotAllocate(t *testing.T) {
+	ep := NewErrorPool()
+	ep.NewError("warm", nil).Put()
+
+	cause := errors.New("cause")
+	if n := testing.AllocsPerRun(100, func() { ep.NewError("wrap", cause).Put() }); n != 0 {
+		t.Fatalf("NewError/Put allocated %v times per cycle, want 0", n)
+	}
+}
+
+func TestBatchAllocations(t *testing.T) {
+	logger := NewLogger(&bytes.Buffer{}, WithTimeLayout(""))
+
+	// Warm the pool so the counted batches find a buffer in it.
+	warm := logger.Batch()
+	warm.Info("warm up")
+	warm.Flush()
+
+	allocs := testing.AllocsPerRun(10, func() {
+		batch := logger.Batch()
+		for range 20 {
+			batch.Info("request step", "ok", true)
+		}
+		batch.Flush()
+	})
+	// Debug builds also allocate the cleanup that watches for abandoned
+	// batches.
+	if !debugBuild && allocs > 1 {
+		t.Fatalf("%v allocations per batch, want at most the Batch itself", allocs)
+	}
+}
+
+func TestEventAllocations(t *testing.T) {
+	if debugBuild {
+		t.Skip("debug builds do not recycle Events")
+	}
+	logger := NewLogger(&bytes.Buffer{}, WithTimeLayout(""))
+	allocs := testing.AllocsPerRun(100, func() {
+		logger.InfoEvent().
+			Str("method", "GET").
+			Int("status", 200).
+			Dur("elapsed", 1500*time.Millisecond).
+			Bool("cached", false).
+			Float64("ratio", 0.5).
+			Msg("request done")
+	})
+	if allocs != 0 {
+		t.Fatalf("%v allocations per event, want 0", allocs)
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestWithItemPoolShared(t *testing.T) {
+	envelopes := new(sync.Pool)
+	a := NewTypedPool(func() []byte { return make([]byte, 1) }, WithItemPool[[]byte](envelopes))
+	b := NewTypedPool(func() []byte { return make([]byte, 2) }, WithItemPool[[]byte](envelopes))
+	// A pool of another type sharing the envelopes must not break them.
+	c := NewTypedPool(func() [2]int { return [2]int{} }, WithItemPool[[2]int](envelopes))
+
+	for range 100 {
+		x, y, z := a.Get(), b.Get(), c.Get()
+		if len(x) != 1 || len(y) != 2 {
+			t.Fatalf("got lengths %d and %d, want 1 and 2", len(x), len(y))
+		}
+		a.Put(x)
+		b.Put(y)
+		c.Put(z)
+	}
+}
+
+func TestPointerShaped(t *testing.T) {
+	if !pointerShaped[*int]() || !pointerShaped[map[int]int]() || !pointerShaped[any]() {
+		t.Fatal("pointer-shaped types reported as needing an envelope")
+	}
+	if pointerShaped[[]byte]() || pointerShaped[int]() || pointerShaped[struct{ p *int }]() {
+		t.Fatal("non-pointer types reported as pointer-shaped")
+	}
+}
+
+func BenchmarkTypedPoolSlice(b *testing.B) {
+	pool := NewTypedPool(func() []byte { return make([]byte, 1024) })
+	b.ReportAllocs()
+	for b.Loop() {
+		pool.Put(pool.Get())
+	}
+}
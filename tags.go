@@ -0,0 +1,83 @@
+package main
+
+import (
+	"maps"
+	"sync"
+)
+
+// WithObjectTagger calls tag with each item and an empty map before Get
+// returns it; tag can record diagnostics such as created_at or request_id
+// in the map, which TagsFor reports until the item is Put. The maps are
+// recycled through a pool of their own. Like WithDeadlockDetector it tracks
+// only pointer-like item types.
+func WithObjectTagger[T any](tag func(T, map[string]string)) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.tagger = tag
+	}
+}
+
+// tagMapPool recycles the WithObjectTagger maps.
+var tagMapPool = NewTypedPool(func() map[string]string {
+	return make(map[string]string)
+})
+
+// itemTags holds the tags of the checked-out items, by identity.
+type itemTags struct {
+	mu   sync.Mutex
+	byID map[uint64]map[string]string
+}
+
+// TagsFor returns a copy of the tags WithObjectTagger recorded for v when
+// it was last handed out, or nil if v is not checked out or the pool has no
+// tagger.
+func (tp *TypedPool[T]) TagsFor(v T) map[string]string {
+	if tp.tags == nil {
+		return nil
+	}
+	id := itemIdentity(v)
+	tp.tags.mu.Lock()
+	defer tp.tags.mu.Unlock()
+
+	return maps.Clone(tp.tags.byID[id])
+}
+
+// tagGet records the tags of an item about to be returned by Get.
+func (tp *TypedPool[T]) tagGet(v T) {
+	if tp.tags == nil {
+		return
+	}
+	id := itemIdentity(v)
+	if id == 0 {
+		return
+	}
+	m := tagMapPool.Get()
+	tp.cfg.tagger(v, m)
+
+	tp.tags.mu.Lock()
+	old := tp.tags.byID[id]
+	tp.tags.byID[id] = m
+	tp.tags.mu.Unlock()
+	releaseTags(old)
+}
+
+// untag forgets the tags of an item being Put.
+func (tp *TypedPool[T]) untag(v T) {
+	if tp.tags == nil {
+		return
+	}
+	id := itemIdentity(v)
+	tp.tags.mu.Lock()
+	m := tp.tags.byID[id]
+	delete(tp.tags.byID, id)
+	tp.tags.mu.Unlock()
+	releaseTags(m)
+}
+
+// releaseTags clears m and returns it to tagMapPool.
+func releaseTags(m map[string]string) {
+	if m == nil {
+		return
+	}
+	clear(m)
+	tagMapPool.Put(m)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestObjectTagger(t *testing.T) {
+	requests := 0
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithObjectTagger(func(v *int, tags map[string]string) {
+			if len(tags) != 0 {
+				t.Errorf("tagger got a used map: %v", tags)
+			}
+			requests++
+			tags["request_id"] = strconv.Itoa(requests)
+		}),
+		WithFIFO[*int](),
+	)
+
+	a, b := pool.Get(), pool.Get()
+	if got := pool.TagsFor(a)["request_id"]; got != "1" {
+		t.Fatalf("TagsFor(a) request_id = %q, want 1", got)
+	}
+	if got := pool.TagsFor(b)["request_id"]; got != "2" {
+		t.Fatalf("TagsFor(b) request_id = %q, want 2", got)
+	}
+
+	pool.Put(a)
+	if tags := pool.TagsFor(a); tags != nil {
+		t.Fatalf("TagsFor after Put = %v, want nil", tags)
+	}
+	if again := pool.Get(); again != a || pool.TagsFor(again)["request_id"] != "3" {
+		t.Fatalf("re-Get tags = %v, want request_id 3", pool.TagsFor(again))
+	}
+}
+
+func TestTagsForCopies(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithObjectTagger(func(_ *int, tags map[string]string) { tags["k"] = "v" }))
+
+	v := pool.Get()
+	pool.TagsFor(v)["k"] = "changed"
+	if got := pool.TagsFor(v)["k"]; got != "v" {
+		t.Fatalf("tag = %q after editing the copy, want v", got)
+	}
+	if tags := NewTypedPool(func() *int { return new(int) }).TagsFor(v); tags != nil {
+		t.Fatalf("TagsFor without a tagger = %v, want nil", tags)
+	}
+}
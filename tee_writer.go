@@ -0,0 +1,80 @@
+package main
+
+import (
+	"io"
+	"sync/atomic"
+)
+
+// TeeWriter copies each Write to a primary and a secondary writer. Unlike
+// io.MultiWriter, a failing secondary, such as a best-effort network sink,
+// is only counted: the Write still reports the primary's result. Each record
+// is staged once in a pooled buffer and handed to both writers whole, with
+// short writes retried. It is safe for concurrent use if both writers are.
+type TeeWriter struct {
+	primary   io.Writer
+	secondary atomic.Pointer[teeBranch]
+
+	primaryErrors   atomic.Int64
+	secondaryErrors atomic.Int64
+}
+
+// teeBranch boxes the secondary writer so it can be swapped atomically.
+type teeBranch struct {
+	w io.Writer
+}
+
+// TeeStats counts the failed writes to each side of a TeeWriter.
+type TeeStats struct {
+	PrimaryErrors   int64 `json:"primary_errors"`
+	SecondaryErrors int64 `json:"secondary_errors"`
+}
+
+// NewTeeWriter returns a TeeWriter writing to primary and, if it is not
+// nil, to secondary.
+func NewTeeWriter(primary, secondary io.Writer) *TeeWriter {
+	t := &TeeWriter{primary: primary}
+	t.SetSecondary(secondary)
+	return t
+}
+
+// SetSecondary replaces the secondary writer; nil turns it off. Writes
+// already under way finish with the writer they started with.
+func (t *TeeWriter) SetSecondary(w io.Writer) {
+	if w == nil {
+		t.secondary.Store(nil)
+		return
+	}
+	t.secondary.Store(&teeBranch{w: w})
+}
+
+// Write passes p to both writers and returns the primary's error, if any.
+// The secondary is written even when the primary fails.
+func (t *TeeWriter) Write(p []byte) (int, error) {
+	b := buffPool.Get()
+	b.Reset()
+	b.Write(p)
+
+	err := writeFull(t.primary, b.Bytes())
+	if err != nil {
+		t.primaryErrors.Add(1)
+	}
+	if s := t.secondary.Load(); s != nil {
+		if writeFull(s.w, b.Bytes()) != nil {
+			t.secondaryErrors.Add(1)
+		}
+	}
+	releaseWritten(b)
+
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Stats returns the error counts of both writers.
+func (t *TeeWriter) Stats() TeeStats {
+	return TeeStats{
+		PrimaryErrors:   t.primaryErrors.Load(),
+		SecondaryErrors: t.secondaryErrors.Load(),
+	}
+}
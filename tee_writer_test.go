@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestTeeWriterSecondaryFailure(t *testing.T) {
+	var primary bytes.Buffer
+	tee := NewTeeWriter(&primary, &failingWriter{err: errors.New("udp down")})
+
+	for range 3 {
+		if n, err := tee.Write([]byte("line\n")); n != 5 || err != nil {
+			t.Fatalf("Write = %d, %v, want 5, nil", n, err)
+		}
+	}
+	if got := primary.String(); got != "line\nline\nline\n" {
+		t.Fatalf("primary got %q", got)
+	}
+	if s := tee.Stats(); s != (TeeStats{SecondaryErrors: 3}) {
+		t.Fatalf("Stats = %+v, want 3 secondary errors", s)
+	}
+}
+
+func TestTeeWriterPrimaryFailure(t *testing.T) {
+	errDisk := errors.New("disk full")
+	var secondary bytes.Buffer
+	tee := NewTeeWriter(&failingWriter{err: errDisk}, &secondary)
+
+	if _, err := tee.Write([]byte("line\n")); !errors.Is(err, errDisk) {
+		t.Fatalf("Write error = %v, want %v", err, errDisk)
+	}
+	if got := secondary.String(); got != "line\n" {
+		t.Fatalf("secondary got %q, want the line anyway", got)
+	}
+	if s := tee.Stats(); s != (TeeStats{PrimaryErrors: 1}) {
+		t.Fatalf("Stats = %+v, want 1 primary error", s)
+	}
+}
+
+func TestTeeWriterShortWritesAndSwap(t *testing.T) {
+	primary, first := &shortWriter{max: 1}, &shortWriter{max: 2}
+	var second bytes.Buffer
+	tee := NewTeeWriter(primary, first)
+
+	tee.Write([]byte("one\n"))
+	tee.SetSecondary(&second)
+	tee.Write([]byte("two\n"))
+	tee.SetSecondary(nil)
+	tee.Write([]byte("three\n"))
+
+	if got := primary.String(); got != "one\ntwo\nthree\n" {
+		t.Fatalf("primary got %q", got)
+	}
+	if first.String() != "one\n" || second.String() != "two\n" {
+		t.Fatalf("secondaries got %q and %q, want one line each", first.String(), second.String())
+	}
+}
+
+func TestTeeWriterReturnsBuffers(t *testing.T) {
+	tee := NewTeeWriter(&bytes.Buffer{}, &failingWriter{err: errors.New("down")})
+	logger := NewLogger(tee)
+
+	before := buffersInFlight()
+	for range 10 {
+		logger.Info("through the tee")
+	}
+	if n := buffersInFlight() - before; n != 0 {
+		t.Fatalf("%d buffers still checked out", n)
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"expvar"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// WithTelemetryPrefix names the pool in every exported metric: expvar keys
+// become prefix.gets, Prometheus names prefix_pool_gets_total, and slog output
+// carries pool.prefix. It implies WithStats.
+func WithTelemetryPrefix[T any](prefix string) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.stats = true
+		cfg.telemetryPrefix = prefix
+	}
+}
+
+// statField is one exported counter.
+type statField struct {
+	name  string
+	value int64
+	gauge bool
+}
+
+func (s Stats) fields() []statField {
+	return []statField{
+		{"gets", s.Gets, false},
+		{"hits", s.Hits, false},
+		{"misses", s.Misses, false},
+		{"puts", s.Puts, false},
+		{"discards", s.Discards, false},
+		{"prefills", s.Prefills, false},
+		{"retained_bytes", s.RetainedBytes, true},
+	}
+}
+
+// publishExpvar registers one expvar.Func per counter. Like expvar.Publish,
+// it panics if a name is already taken.
+func publishExpvar(prefix string, stats func() Stats) {
+	if prefix == "" {
+		prefix = "pool"
+	}
+
+	for i, f := range stats().fields() {
+		expvar.Publish(prefix+"."+f.name, expvar.Func(func() any {
+			return stats().fields()[i].value
+		}))
+	}
+}
+
+// writePrometheus writes s in the Prometheus text exposition format.
+func writePrometheus(w io.Writer, prefix string, s Stats) error {
+	name := "pool_"
+	if prefix != "" {
+		name = prefix + "_pool_"
+	}
+
+	for _, f := range s.fields() {
+		metric, kind := name+f.name+"_total", "counter"
+		if f.gauge {
+			metric, kind = name+f.name, "gauge"
+		}
+		if _, err := fmt.Fprintf(w, "# TYPE %s %s\n%s %d\n", metric, kind, metric, f.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// statsLogValue renders s as a slog group tagged with the prefix.
+func statsLogValue(prefix string, s Stats) slog.Value {
+	fields := s.fields()
+	attrs := make([]slog.Attr, 0, len(fields)+1)
+	if prefix != "" {
+		attrs = append(attrs, slog.String("prefix", prefix))
+	}
+	for _, f := range fields {
+		attrs = append(attrs, slog.Int64(f.name, f.value))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// PublishExpvar exports the pool's counters through expvar.
+func (tp *TypedPool[T]) PublishExpvar() {
+	publishExpvar(tp.cfg.telemetryPrefix, tp.Stats)
+}
+
+// WritePrometheus writes the pool's counters in the Prometheus text format.
+func (tp *TypedPool[T]) WritePrometheus(w io.Writer) error {
+	return writePrometheus(w, tp.cfg.telemetryPrefix, tp.Stats())
+}
+
+// LogValue implements slog.LogValuer, so slog.Any("pool", p) logs the
+// pool's counters as pool.gets, pool.hits, and so on.
+func (tp *TypedPool[T]) LogValue() slog.Value {
+	return statsLogValue(tp.cfg.telemetryPrefix, tp.Stats())
+}
+
+// PublishExpvar exports the pool's counters through expvar.
+func (bp *BoundedPool[T]) PublishExpvar() {
+	publishExpvar(bp.cfg.telemetryPrefix, bp.Stats)
+}
+
+// WritePrometheus writes the pool's counters in the Prometheus text format.
+func (bp *BoundedPool[T]) WritePrometheus(w io.Writer) error {
+	return writePrometheus(w, bp.cfg.telemetryPrefix, bp.Stats())
+}
+
+// LogValue implements slog.LogValuer.
+func (bp *BoundedPool[T]) LogValue() slog.Value {
+	return statsLogValue(bp.cfg.telemetryPrefix, bp.Stats())
+}
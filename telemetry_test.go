@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"expvar"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func newTelemetryPool(prefix string) *BoundedPool[int] {
+	pool := NewBoundedPool(1, func() int { return 0 }, WithTelemetryPrefix[int](prefix))
+	pool.Get()
+	pool.Put(1)
+	pool.Put(2)
+	return pool
+}
+
+func TestTelemetryPrefixExpvar(t *testing.T) {
+	newTelemetryPool("telemetry_expvar").PublishExpvar()
+
+	for key, want := range map[string]string{
+		"telemetry_expvar.gets":     "1",
+		"telemetry_expvar.misses":   "1",
+		"telemetry_expvar.puts":     "2",
+		"telemetry_expvar.discards": "1",
+	} {
+		v := expvar.Get(key)
+		if v == nil {
+			t.Fatalf("expvar %q not published", key)
+		}
+		if got := v.String(); got != want {
+			t.Errorf("expvar %q = %s, want %s", key, got, want)
+		}
+	}
+}
+
+func TestTelemetryPrefixPrometheus(t *testing.T) {
+	var buf bytes.Buffer
+	if err := newTelemetryPool("api").WritePrometheus(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"# TYPE api_pool_gets_total counter\napi_pool_gets_total 1\n",
+		"api_pool_discards_total 1\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestTelemetryPrefixSlog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	logger.Info("stats", "pool", newTelemetryPool("api"))
+
+	want := "level=INFO msg=stats pool.prefix=api pool.gets=1 pool.hits=0 pool.misses=1 pool.puts=2 pool.discards=1 pool.prefills=0 pool.retained_bytes=0\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("got  %q\nwant %q", got, want)
+	}
+}
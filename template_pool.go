@@ -0,0 +1,42 @@
+package main
+
+import "sync/atomic"
+
+// TemplatePool is a TypedPool whose misses are served by cloning a prototype
+// instead of calling a constructor.
+type TemplatePool[T any] struct {
+	*TypedPool[T]
+	prototype atomic.Pointer[T]
+}
+
+// NewTemplatePool creates a TemplatePool that clones prototype on every miss.
+// A nil clone falls back to CopyClone, which is only safe for plain value types.
+func NewTemplatePool[T any](prototype T, clone func(T) T) *TemplatePool[T] {
+	if clone == nil {
+		clone = CopyClone[T]
+	}
+
+	tp := &TemplatePool[T]{}
+	tp.prototype.Store(&prototype)
+	tp.TypedPool = NewTypedPool(func() T {
+		return clone(*tp.prototype.Load())
+	})
+
+	return tp
+}
+
+// Prototype returns the template currently used for misses.
+func (tp *TemplatePool[T]) Prototype() T {
+	return *tp.prototype.Load()
+}
+
+// SetPrototype atomically replaces the template used for future misses.
+// Objects already sitting in the pool are not affected.
+func (tp *TemplatePool[T]) SetPrototype(prototype T) {
+	tp.prototype.Store(&prototype)
+}
+
+// CopyClone is the default clone function: a plain value copy.
+func CopyClone[T any](v T) T {
+	return v
+}
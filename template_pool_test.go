@@ -0,0 +1,57 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+type header struct {
+	Name   string
+	Fields []string
+}
+
+func cloneHeader(h *header) *header {
+	return &header{Name: h.Name, Fields: slices.Clone(h.Fields)}
+}
+
+func TestTemplatePoolClonesDoNotShareSlices(t *testing.T) {
+	pool := NewTemplatePool(&header{Name: "proto", Fields: []string{"a", "b"}}, cloneHeader)
+
+	first := pool.Get()
+	second := pool.Get()
+	first.Fields[0] = "changed"
+
+	if second.Fields[0] != "a" {
+		t.Fatalf("clones share Fields: got %q, want %q", second.Fields[0], "a")
+	}
+	if got := pool.Prototype().Fields[0]; got != "a" {
+		t.Fatalf("prototype mutated through clone: got %q, want %q", got, "a")
+	}
+}
+
+func TestTemplatePoolSetPrototype(t *testing.T) {
+	pool := NewTemplatePool(&header{Name: "v1"}, cloneHeader)
+
+	if got := pool.Get().Name; got != "v1" {
+		t.Fatalf("got %q, want %q", got, "v1")
+	}
+
+	pool.SetPrototype(&header{Name: "v2"})
+
+	if got := pool.Get().Name; got != "v2" {
+		t.Fatalf("after SetPrototype got %q, want %q", got, "v2")
+	}
+}
+
+func TestTemplatePoolDefaultClone(t *testing.T) {
+	type point struct{ X, Y int }
+
+	pool := NewTemplatePool(point{X: 1, Y: 2}, nil)
+
+	p := pool.Get()
+	p.X = 10
+
+	if got := pool.Get(); got != (point{X: 1, Y: 2}) {
+		t.Fatalf("got %+v, want %+v", got, point{X: 1, Y: 2})
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// WithThrottledGet rate-limits the pool's consumers to rps Gets per second,
+// whether the Get is served from the pool or by the constructor, for pools
+// fronting a resource that must not be hit faster. A token bucket holding
+// one second's worth of tokens, and at least one, absorbs bursts; beyond
+// that each Get waits for its token on the pool's Clock. GetContext gives up
+// the wait when its context ends. It panics if rps is not positive.
+func WithThrottledGet[T any](rps float64) PoolOption[T] {
+	if rps <= 0 {
+		panic("WithThrottledGet: rps must be positive")
+	}
+	return func(cfg *poolConfig[T]) {
+		cfg.throttle = newThrottle(rps)
+	}
+}
+
+// throttle is a token bucket kept as a single theoretical arrival time, the
+// GCRA form, so taking a token is one compare-and-swap.
+type throttle struct {
+	interval int64 // nanoseconds per token
+	burst    int64 // nanoseconds of tokens the bucket holds
+	tat      atomic.Int64
+}
+
+func newThrottle(rps float64) *throttle {
+	interval := max(int64(float64(time.Second)/rps), 1)
+	return &throttle{interval: interval, burst: max(int64(time.Second), interval)}
+}
+
+// reserve takes the next token at now and returns how long the caller must
+// wait before using it.
+func (t *throttle) reserve(now int64) time.Duration {
+	for {
+		old := t.tat.Load()
+		next := max(old, now) + t.interval
+		if t.tat.CompareAndSwap(old, next) {
+			return time.Duration(max(next-t.burst-now, 0))
+		}
+	}
+}
+
+// cancel hands back a reserved token that will not be used.
+func (t *throttle) cancel() {
+	t.tat.Add(-t.interval)
+}
+
+// GetContext is Get for pools built WithThrottledGet: it waits for a token
+// only until ctx ends, returning ctx's error then. Like TryGet, it returns
+// ErrBudgetExhausted or ErrAborted where Get returns no item.
+func (tp *TypedPool[T]) GetContext(ctx context.Context) (T, error) {
+	if tp.classes != nil {
+		if err := tp.wait(ctx); err != nil {
+			return tp.noItem(), err
+		}
+		if err := tp.acquire(ctx); err != nil {
+			return tp.noItem(), err
+		}
+		return tp.classes[0].Get(), nil
+	}
+	v, _, err := tp.get(ctx, 0)
+	return v, err
+}
+
+// wait blocks until the caller may take a WithThrottledGet token, or ctx
+// ends.
+func (tp *TypedPool[T]) wait(ctx context.Context) error {
+	t := tp.cfg.throttle
+	if t == nil {
+		return nil
+	}
+	d := t.reserve(tp.cfg.now().UnixNano())
+	if d == 0 {
+		return nil
+	}
+	select {
+	case <-after(tp.cfg.clock, d):
+		return nil
+	case <-ctx.Done():
+		t.cancel()
+		return ctx.Err()
+	}
+}
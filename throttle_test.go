@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ArditZubaku/go-sync-pool/pooltest"
+)
+
+func TestThrottledGetWaitsForTokens(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Unix(0, 0))
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithPoolClock[*int](clock),
+		WithThrottledGet[*int](2),
+	)
+
+	// The bucket starts with a second's worth of tokens.
+	pool.Get()
+	pool.Get()
+
+	got := make(chan *int)
+	go func() { got <- pool.Get() }()
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	select {
+	case <-got:
+		t.Fatal("third Get within the burst did not wait")
+	default:
+	}
+	clock.Advance(500 * time.Millisecond)
+	if v := <-got; v == nil {
+		t.Fatal("Get returned nil")
+	}
+}
+
+func TestThrottledGetContextGivesUp(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Unix(0, 0))
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithPoolClock[*int](clock),
+		WithThrottledGet[*int](1),
+	)
+	pool.Get()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error)
+	go func() {
+		_, err := pool.GetContext(ctx)
+		errs <- err
+	}()
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	cancel()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Fatalf("GetContext = %v, want context.Canceled", err)
+	}
+
+	// The abandoned token was handed back: one second on, the next Get
+	// passes without waiting, as the clock does not move again.
+	clock.Advance(time.Second)
+	if _, err := pool.GetContext(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
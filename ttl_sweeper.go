@@ -0,0 +1,79 @@
+package main
+
+import "time"
+
+// WithItemTTLSweeper discards a pool's expired idle objects every
+// sweepInterval, on the pool's Scheduler and Clock, until Close.
+// WithIdleTTL on a BoundedPool and WithItemExpiry on a TypedPool alone only
+// expire objects when the pool is used, so an idle pool would hold on to
+// them, and to their memory, indefinitely. Expired objects pass through the
+// OnDiscard hook, and a BoundedPool returns them to the group budget.
+// A TypedPool sweeps as WithIdleCapLimit scans. NewBoundedPool panics unless
+// WithIdleTTL is also set, and NewTypedPool unless WithItemExpiry is.
+func WithItemTTLSweeper[T any](sweepInterval time.Duration) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.sweepInterval = sweepInterval
+	}
+}
+
+// sweep discards the expired idle objects. Under WithStagger it takes the
+// lock for one object at a time.
+func (bp *BoundedPool[T]) sweep() {
+	if bp.cfg.staggerDelay <= 0 {
+		bp.mu.Lock()
+		freed := bp.evictExpired()
+		bp.mu.Unlock()
+
+		bp.release(freed)
+		return
+	}
+
+	for i := 0; ; i++ {
+		bp.cfg.staggerBefore(i)
+		bp.mu.Lock()
+		freed, ok := bp.evictFirstExpired()
+		bp.mu.Unlock()
+
+		bp.release(freed)
+		if !ok {
+			return
+		}
+	}
+}
+
+// startSweeper runs sweep every WithItemTTLSweeper interval.
+func (bp *BoundedPool[T]) startSweeper() {
+	bp.bg.run(bp.cfg.schedule, func(stop <-chan struct{}) {
+		for {
+			select {
+			case <-stop:
+				return
+			case <-after(bp.cfg.clock, bp.cfg.sweepInterval):
+				bp.sweep()
+			}
+		}
+	})
+}
+
+// sweepExpired discards the idle items whose WithItemExpiry deadline has
+// passed and returns how many there were.
+func (tp *TypedPool[T]) sweepExpired() int {
+	now := tp.cfg.now()
+	return tp.evictWhere(func(item T) bool {
+		return !now.Before(tp.cfg.expiresAt(item))
+	})
+}
+
+// startExpirySweeper runs sweepExpired every WithItemTTLSweeper interval.
+func (tp *TypedPool[T]) startExpirySweeper() {
+	tp.bg.run(tp.cfg.schedule, func(stop <-chan struct{}) {
+		for {
+			select {
+			case <-stop:
+				return
+			case <-after(tp.cfg.clock, tp.cfg.sweepInterval):
+				tp.sweepExpired()
+			}
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ArditZubaku/go-sync-pool/pooltest"
+)
+
+func TestItemTTLSweeperDiscardsWithoutTraffic(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Unix(0, 0))
+	var discarded atomic.Int64
+	pool := NewBoundedPool(4, func() *int { return new(int) },
+		WithIdleTTL[*int](10*time.Second),
+		WithItemTTLSweeper[*int](time.Second),
+		WithPoolClock[*int](clock),
+		WithOnDiscard(func(*int) { discarded.Add(1) }),
+	)
+	defer pool.Close()
+
+	pool.Put(new(int))
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	clock.Advance(6 * time.Second) // nothing has expired yet
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	pool.Put(new(int))
+	if n := discarded.Load(); n != 0 {
+		t.Fatalf("discarded %d before anything expired", n)
+	}
+
+	clock.Advance(5 * time.Second) // the first object is 11s old
+	waitFor(t, func() bool { return discarded.Load() == 1 })
+	if n := pool.Len(); n != 1 {
+		t.Fatalf("Len after the sweep = %d, want the unexpired object only", n)
+	}
+}
+
+func TestItemTTLSweeperRequiresIdleTTL(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("NewBoundedPool did not panic without WithIdleTTL")
+		}
+	}()
+	NewBoundedPool(4, func() *int { return new(int) }, WithItemTTLSweeper[*int](time.Second))
+}
+
+func TestItemTTLSweeperTypedPool(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Unix(0, 0))
+	var discarded atomic.Int64
+	pool := NewTypedPool(func() *lease { return &lease{until: clock.Now().Add(10 * time.Second)} },
+		WithItemExpiry[*lease](),
+		WithItemTTLSweeper[*lease](time.Second),
+		WithPoolClock[*lease](clock),
+		WithOnDiscard(func(*lease) { discarded.Add(1) }),
+		WithFIFO[*lease](),
+	)
+	defer pool.Close()
+
+	old := pool.Get()
+	pool.Put(old)
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	clock.Advance(6 * time.Second) // nothing has expired yet
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	young := &lease{until: clock.Now().Add(10 * time.Second)}
+	pool.Put(young)
+	if n := discarded.Load(); n != 0 {
+		t.Fatalf("discarded %d before anything expired", n)
+	}
+
+	clock.Advance(5 * time.Second) // old expired a second ago
+	waitFor(t, func() bool { return discarded.Load() == 1 })
+	waitFor(t, func() bool { return clock.Waiters() == 1 })
+	if got := pool.Get(); got != young {
+		t.Fatal("the sweep did not keep the unexpired lease")
+	}
+}
+
+func TestItemTTLSweeperRequiresItemExpiry(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("NewTypedPool did not panic without WithItemExpiry")
+		}
+	}()
+	NewTypedPool(func() *int { return new(int) }, WithItemTTLSweeper[*int](time.Second))
+}
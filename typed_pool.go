@@ -1,29 +1,635 @@
 package main
 
-import "sync"
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
 
-// TypedPool wraps sync.Pool with a generic type
+// TypedPool wraps sync.Pool with a generic type.
+// The order in which idle items are returned is unspecified unless the pool
+// is built WithFIFO.
 type TypedPool[T any] struct {
-	pool sync.Pool
+	pool   idleStore[T]
+	newFn  func() T
+	cfg    poolConfig[T]
+	inPool atomic.Int64
+
+	// labeledNew is newFn taking the Get's context, under WithProfileName.
+	labeledNew func(context.Context) T
+
+	barrierDiscards atomic.Int64
+	maxItems        atomic.Int64
+	debugOff        atomic.Bool
+	stats           *poolStats
+	conc            *concurrencyProfile
+	bg              *background
+
+	checkouts *checkouts
+	puts      chan T
+	classes   []*TypedPool[T]
+	reuse     *reuseCounts
+	shards    []*TypedPool[T]
+	drain     *drainWaiter
+	tags      *itemTags
+	budget    *atomic.Int64
+	window    *statsWindow
+	versions  *itemVersions
+	memo      *memo
+	once      *singleton[T]
+	latency   *latencyHistogram
+	metadata  *itemMetadata
+	events    *eventRing
+	idleSince *idleSince
+	sticky    *stickyItems
+	ready     <-chan struct{}
+	batch     *putBatch[T]
+	fast      *fastPath
+
+	lossy     bool // the store drops items over GC; see pruneGone
+	prunedGen atomic.Int64
+	recent    *recentPuts // nil unless lossy
+
+	detach     []func()
+	detachOnce sync.Once
 }
 
 // NewTypedPool creates a new TypedPool using the provided constructor.
-func NewTypedPool[T any](newFn func() T) *TypedPool[T] {
-	return &TypedPool[T]{
-		pool: sync.Pool{
-			New: func() any {
-				return newFn()
-			},
-		},
+func NewTypedPool[T any](newFn func() T, opts ...PoolOption[T]) *TypedPool[T] {
+	var cfg poolConfig[T]
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	cfg.validateTyped()
+
+	if cfg.newFn != nil {
+		newFn = cfg.newFn
+	}
+	if cfg.condNew != nil {
+		newFn = cfg.condNew.wrap(newFn)
+	}
+	if cfg.onOOM != nil {
+		newFn = oomGuardedNew(newFn, cfg.onOOM)
+	}
+	if cfg.ctorObserver != nil {
+		newFn = observedNew(cfg.ctorObserver, newFn)
+	}
+	newFn = cfg.raceDetectNew(newFn)
+
+	tp := &TypedPool[T]{
+		pool:    newSyncStore[T](cfg.itemPool),
+		newFn:   newFn,
+		cfg:     cfg,
+		stats:   newPoolStats(cfg.stats),
+		latency: newLatencyHistogram(cfg.latencyBounds),
+		conc:    newConcurrencyProfile(cfg.concurrency || cfg.drainTimeout > 0 || cfg.autoTune != nil, cfg.borrowTrace),
+		bg:      newBackground(),
+	}
+	if cfg.profileName != "" {
+		tp.labeledNew = profiledNew(cfg.profileName, newFn)
+		tp.newFn = func() T { return tp.labeledNew(context.Background()) }
+	}
+	if cfg.store != nil {
+		tp.pool = customStore[T]{cfg.store}
+	}
+	if cfg.ordering == FIFO {
+		tp.pool = new(fifoStore[T])
+	}
+	if cfg.putHashFn != nil {
+		tp.pool = newHashStore(&cfg)
+	}
+	tp.lossy = lossyStore(tp.pool) || cfg.fastPath != nil
+	if tp.lossy {
+		tp.recent = new(recentPuts)
+	}
+	if cfg.onReuse != nil || cfg.reuseLimit > 0 || cfg.hotObjects > 0 {
+		tp.reuse = new(reuseCounts)
+	}
+	if cfg.buckets != nil {
+		tp.classes = cfg.buckets.newClasses()
+	}
+	if cfg.shards > 0 {
+		tp.shards = tp.newShards()
+	}
+	if cfg.drainTimeout > 0 {
+		tp.drain = newDrainWaiter()
+	}
+	if cfg.tagger != nil {
+		tp.tags = &itemTags{byID: make(map[uint64]map[string]string)}
+	}
+	if cfg.memoKey != nil {
+		tp.memo = new(memo)
+	}
+	if cfg.eventLog > 0 {
+		tp.events = newEventRing(cfg.eventLog)
+	}
+	if cfg.metadataFn != nil || cfg.monotonicID {
+		tp.metadata = new(itemMetadata)
+	}
+	if cfg.newOnce {
+		tp.once = new(singleton[T])
+	}
+	if cfg.stickyID != nil {
+		tp.sticky = new(stickyItems)
+	}
+	if cfg.version != nil {
+		tp.versions = new(itemVersions)
+		tp.versions.current.Store(int64(*cfg.version))
+	}
+	if cfg.windowStats {
+		tp.window = newStatsWindow(cfg.now())
+	}
+	if cfg.ctorBudget != nil {
+		tp.budget = new(atomic.Int64)
+		tp.budget.Store(*cfg.ctorBudget)
+	}
+	tp.maxItems.Store(int64(cfg.maxItems))
+	if cfg.autoTune != nil {
+		tp.startAutoTune()
+	}
+	if cfg.group != nil {
+		cfg.group.register(tp)
+	}
+	if cfg.tracker != nil {
+		tp.startTracker()
+	}
+	if cfg.snapshotEvery > 0 {
+		tp.startSnapshots()
+	}
+	if cfg.snapshotExport != nil {
+		tp.startSnapshotExport()
+	}
+	if cfg.deadlockTimeout > 0 || cfg.checkoutsShared() {
+		tp.checkouts = new(checkouts)
+	}
+	if cfg.deadlockTimeout > 0 {
+		tp.startDeadlockDetector()
+	}
+	if cfg.debugMode && cfg.debugExpiry > 0 {
+		tp.startDebugExpiry()
+	}
+	if cfg.onGC != nil {
+		tp.startGCNotify()
+	}
+	if cfg.softTimeout > 0 {
+		tp.startSoftTimeout()
+	}
+	if cfg.shutdownSignals != nil {
+		tp.startDrainOnShutdown()
+	}
+	if cfg.prewarmTrigger != nil {
+		tp.startAdaptivePrewarm()
+	}
+	if cfg.idleCap > 0 {
+		tp.idleSince = new(idleSince)
+		tp.startIdleCap()
+	}
+	if cfg.sweepInterval > 0 {
+		tp.startExpirySweeper()
+	}
+	if cfg.fastPath != nil {
+		tp.fast = &fastPath{pool: cfg.fastPath}
+	}
+	if cfg.batchSize > 0 {
+		tp.startBatchFlush()
+	}
+	if cfg.ctorWorkers > 0 {
+		tp.startCtorWorkers()
+	}
+	if cfg.asyncPutSize > 0 {
+		tp.puts = make(chan T, cfg.asyncPutSize)
+		for range max(cfg.asyncPutWorkers, 1) {
+			tp.bg.run(cfg.schedule, tp.drainPuts)
+		}
+	}
+	if cfg.preHeat != nil {
+		tp.runPreHeat()
+	}
+	if cfg.parallelInit != nil {
+		tp.runParallelInit()
+	}
+	if cfg.coldStart > 0 {
+		tp.ready = tp.WarmupAsync(cfg.coldStart)
+	}
+	for _, attach := range cfg.attach {
+		if detach := attach(tp); detach != nil {
+			tp.detach = append(tp.detach, detach)
+		}
+	}
+
+	return tp
+}
+
+// validateTyped panics on the option combinations a TypedPool rejects. It
+// runs before NewTypedPool starts any goroutine or registers the pool
+// anywhere, so a rejected pool leaves nothing behind.
+func (cfg *poolConfig[T]) validateTyped() {
+	if cfg.store != nil && cfg.ordering == FIFO {
+		panic("NewTypedPool: WithCustomStore cannot be combined with WithFIFO")
+	}
+	if cfg.putHashFn != nil && cfg.store != nil {
+		panic("NewTypedPool: WithHashBucketPut cannot be combined with WithCustomStore")
+	}
+	if cfg.minIdle > 0 || cfg.refillInterval > 0 {
+		panic("NewTypedPool: WithMinIdle and WithRefillInterval are only supported by BoundedPool")
+	}
+	if cfg.sweepInterval > 0 && cfg.expiresAt == nil {
+		panic("NewTypedPool: WithItemTTLSweeper requires WithItemExpiry")
+	}
+	if cfg.fastPath != nil && (cfg.objectLimit != nil || cfg.objectCount != nil) {
+		panic("NewTypedPool: WithFastPath cannot be combined with WithObjectLimit or WithObjectCount")
 	}
 }
 
 // Get retrieves an item from the pool (properly typed).
 func (tp *TypedPool[T]) Get() T {
-	return tp.pool.Get().(T)
+	if tp.classes != nil {
+		return tp.GetSize(0)
+	}
+	v, _, err := tp.get(context.Background(), 0)
+	return tp.orNoItem(v, err)
+}
+
+// orNoItem is what a Get that cannot return an error hands out for get's
+// result: a Get shed by WithAbortOnGet, refused by a WithResourceGuard or
+// missing with the WithConstructorBudget spent comes back as v, which get
+// has set to the WithItemSentinel value or the zero value. A WithInitCheck
+// failure means the constructor is broken, and panics.
+func (tp *TypedPool[T]) orNoItem(v T, err error) T {
+	if errors.Is(err, ErrInitCheck) {
+		panic(err)
+	}
+	return v
+}
+
+// get serves Get, GetInfo, TryGet, GetContext and SizedPool.Get; hint is
+// the SizedPool size hint, or 0. It only fails once a WithConstructorBudget
+// runs out, when WithAbortOnGet sheds the call, when ctx ends while
+// WithThrottledGet holds it back, or when a WithResourceGuard refuses it.
+func (tp *TypedPool[T]) get(ctx context.Context, hint int) (T, Origin, error) {
+	return tp.getFrom(ctx, hint, nil)
+}
+
+// getFrom is get, first trying the WithHashBucketPut bucket from if it is
+// not nil.
+func (tp *TypedPool[T]) getFrom(ctx context.Context, hint int, from idleStore[T]) (T, Origin, error) {
+	if tp.cfg.getMiddleware != nil {
+		return tp.getThrough(func() (T, Origin, error) { return tp.getRecycled(ctx, hint, from) })
+	}
+	return tp.getRecycled(ctx, hint, from)
+}
+
+// getRecycled is getFrom short of WithGetMiddleware.
+func (tp *TypedPool[T]) getRecycled(ctx context.Context, hint int, from idleStore[T]) (T, Origin, error) {
+	v, origin, err := tp.getItem(ctx, hint, from)
+	if err != nil || tp.cfg.recycle == nil {
+		return v, origin, err
+	}
+	return tp.recycled(v), origin, nil
+}
+
+// getItem is getRecycled short of WithObjectRecycle.
+func (tp *TypedPool[T]) getItem(ctx context.Context, hint int, from idleStore[T]) (T, Origin, error) {
+	if tp.latency != nil {
+		defer tp.latency.observe(time.Now())
+	}
+	tp.cfg.stackSampler.sample(len(tp.cfg.getMiddleware))
+	if tp.aborted() {
+		return tp.noItem(), OriginNew, ErrAborted
+	}
+	if err := tp.wait(ctx); err != nil {
+		return tp.noItem(), OriginNew, err
+	}
+	if err := tp.acquire(ctx); err != nil {
+		return tp.noItem(), OriginNew, err
+	}
+	tp.tickWindow()
+	if tp.once != nil {
+		v, origin := tp.onceGet()
+		return v, origin, nil
+	}
+	if tp.memo != nil {
+		if v, origin, ok := tp.memoGet(); ok {
+			return v, origin, nil
+		}
+	}
+	tp.conc.borrow()
+	var served int64
+	if p := tp.cfg.poison; p != nil {
+		served = p.countGet()
+	}
+
+	if tp.chaos() {
+		return tp.fresh(ctx, served, hint)
+	}
+	if item, ok := tp.takeFast(); ok {
+		return tp.serve(item), OriginReused, nil
+	}
+	var (
+		item T
+		ok   bool
+	)
+	if from != nil {
+		item, ok = tp.takeIdleFrom(from)
+	}
+	if !ok {
+		item, ok = tp.takeIdle()
+	}
+	if ok {
+		if !tp.cfg.sizeHint.fits(item, hint) {
+			tp.put(item)
+			return tp.fresh(ctx, served, hint)
+		}
+		return tp.serve(item), OriginReused, nil
+	}
+
+	// A sync.Pool miss only says this P found nothing: other Ps may still
+	// hold items and Puts may be racing in, so the counters are only lowered
+	// by what the GC can have cleared. Exact stores never lose items.
+	tp.pruneGone()
+	tp.sweepParked()
+	if item, ok := tp.rescue(hint); ok {
+		return tp.serve(item), OriginReused, nil
+	}
+	if item, ok := tp.awaitPut(ctx); ok {
+		if !tp.cfg.sizeHint.fits(item, hint) {
+			tp.put(item)
+			return tp.fresh(ctx, served, hint)
+		}
+		return tp.serve(item), OriginReused, nil
+	}
+	return tp.fresh(ctx, served, hint)
+}
+
+// takeIdle removes an idle item from the store, skipping any WithItemExpiry
+// or WithReuseCheck rejects. It reports false once the store is empty. The
+// caller's WithStickyPool item, if any, comes first.
+func (tp *TypedPool[T]) takeIdle() (T, bool) {
+	if item, ok := tp.unpark(); ok && tp.checkExpiry(item) && tp.checkReuse(item) && tp.checkVersion(item) && tp.checkSum(item) {
+		return item, true
+	}
+	return tp.takeIdleFrom(tp.pool)
+}
+
+// takeIdleFrom is takeIdle without the WithStickyPool item, taking from
+// store, which is the pool's store or one of its WithHashBucketPut buckets.
+func (tp *TypedPool[T]) takeIdleFrom(store idleStore[T]) (T, bool) {
+	for {
+		item, ok := store.get()
+		if !ok {
+			return item, false
+		}
+		tp.inPool.Add(-1)
+		tp.releaseCount()
+		tp.releaseWeight(item)
+		tp.retain(-tp.sizeOf(item))
+		if tp.checkExpiry(item) && tp.checkReuse(item) && tp.checkVersion(item) && tp.checkSum(item) {
+			return item, true
+		}
+	}
+}
+
+// serve hands out an idle item.
+func (tp *TypedPool[T]) serve(item T) T {
+	if tp.cfg.resetOnGet != nil {
+		tp.cfg.resetOnGet(item)
+	}
+	tp.stats.hit()
+	tp.bindPool(item)
+	tp.markIdle(item, false)
+	tp.unstampIdle(item)
+	tp.audit(auditGet, item)
+	tp.trackGet(item)
+	tp.tagGet(item)
+	return item
+}
+
+// fresh constructs the item for a Get made with ctx that found nothing
+// suitable idle.
+func (tp *TypedPool[T]) fresh(ctx context.Context, served int64, hint int) (T, Origin, error) {
+	if !tp.spendBudget() {
+		tp.abandon()
+		return tp.noItem(), OriginNew, ErrBudgetExhausted
+	}
+	tp.stats.miss()
+	item, err := tp.constructChecked(ctx, served, hint)
+	if err != nil {
+		tp.abandon()
+		return tp.noItem(), OriginNew, err
+	}
+	tp.stamp(item)
+	tp.bindPool(item)
+	tp.recordMetadata(item)
+	if tp.reuse != nil {
+		tp.reuse.constructed(uintptr(itemIdentity(item)))
+	}
+	tp.audit(auditNew, item)
+	tp.audit(auditGet, item)
+	tp.trackGet(item)
+	tp.tagGet(item)
+	return item, OriginNew, nil
+}
+
+// abandon undoes the checkout of a Get that ends without an item.
+func (tp *TypedPool[T]) abandon() {
+	if tp.conc.release() == 0 && tp.drain != nil {
+		tp.drain.signal()
+	}
+	if g := tp.cfg.guard; g != nil {
+		g.Release()
+	}
+}
+
+// construct serves a miss of a Get made with ctx. served is the number of
+// Gets before this one, as counted for WithPoisonPill, and hint the
+// SizedPool size hint.
+func (tp *TypedPool[T]) construct(ctx context.Context, served int64, hint int) T {
+	if p := tp.cfg.poison; p != nil {
+		if pill, ok := p.take(served); ok {
+			return pill
+		}
+	}
+	if hint > 0 && tp.cfg.sizeHint != nil {
+		return tp.cfg.sizeHint.newFn(hint)
+	}
+	if tp.cfg.ctorTimeout > 0 {
+		return tp.newWithTimeout(ctx)
+	}
+	return tp.newIn(ctx)
 }
 
 // Put returns an item back to the pool.
 func (tp *TypedPool[T]) Put(v T) {
-	tp.pool.Put(v)
+	if tp.isSentinel(v) {
+		return
+	}
+	if g := tp.cfg.guard; g != nil {
+		g.Release()
+	}
+	tp.putBack(v)
+}
+
+// putBack is Put without the WithResourceGuard Release, for items the pool
+// pools itself.
+func (tp *TypedPool[T]) putBack(v T) {
+	if tp.once != nil || tp.memoized(v) {
+		return
+	}
+	tp.tickWindow()
+	tp.cfg.mirror.shadow(v)
+	if tp.classes != nil {
+		tp.putSized(v)
+		return
+	}
+	if tp.dropForced(v, tp.trackPut(v)) {
+		return
+	}
+	if tp.conc.release() == 0 && tp.drain != nil {
+		tp.drain.signal()
+	}
+	if tp.cfg.onSlowPut != nil {
+		defer tp.checkPutLatency(time.Now())
+	}
+	tp.untag(v)
+	if tp.denied(v) {
+		return
+	}
+	if tp.stale(v) {
+		tp.stats.discard()
+		tp.markVersioned(v, false)
+		tp.discard(v)
+		return
+	}
+	if tp.worn(v) {
+		tp.drop(v)
+		return
+	}
+	if tp.park(v) {
+		return
+	}
+	if tp.puts != nil && tp.enqueuePut(v) {
+		return
+	}
+	if tp.batch != nil && tp.batchPut(v) {
+		return
+	}
+	if tp.putFast(v) {
+		return
+	}
+	tp.put(v)
+}
+
+// put pools v unless an item limit rejects it.
+func (tp *TypedPool[T]) put(v T) {
+	if v, ok := tp.admitPut(v); ok {
+		tp.pool.put(v)
+	}
+}
+
+// admitPut is put short of the store itself: it accounts for v and returns
+// the item to store, or reports false if v was dropped or went elsewhere.
+func (tp *TypedPool[T]) admitPut(v T) (T, bool) {
+	if tp.chaos() {
+		tp.drop(v)
+		return v, false
+	}
+	if !tp.admit(v) || !tp.admitWeight(tp.cfg.objectLimit.weightOf(v)) {
+		if !tp.spill(v) && !tp.overflowTo(v) {
+			tp.drop(v)
+		}
+		return v, false
+	}
+	if !tp.admitCount() {
+		tp.releaseWeight(v)
+		tp.drop(v)
+		return v, false
+	}
+
+	v, ok := tp.prepare(v)
+	if !ok {
+		return v, false
+	}
+	tp.retain(tp.sizeOf(v))
+	tp.inPool.Add(1)
+	tp.noteIdle(v)
+	tp.pooled(v)
+	return v, true
+}
+
+// prepare runs WithReset and the WithPutHook on an admitted v and returns
+// the item to pool, or false if the hook dropped it.
+func (tp *TypedPool[T]) prepare(v T) (T, bool) {
+	if tp.cfg.reset != nil {
+		tp.cfg.reset(v)
+	}
+	if tp.cfg.putHook != nil {
+		return tp.runPutHook(v)
+	}
+	return v, true
+}
+
+// pooled records that v went idle, in the stats, the audit log and the side
+// tables.
+func (tp *TypedPool[T]) pooled(v T) {
+	tp.stats.put()
+	tp.audit(auditPut, v)
+	if tp.reuse != nil {
+		tp.reuse.pooled(uintptr(itemIdentity(v)))
+		tp.recordHot(v)
+	}
+	tp.markVersioned(v, true)
+	tp.recordSum(v)
+	tp.markIdle(v, true)
+	tp.stampIdle(v)
+}
+
+// drop discards v on Put, counting it.
+func (tp *TypedPool[T]) drop(v T) {
+	tp.stats.discard()
+	tp.discard(v)
+}
+
+// sizeOf returns the WithSizeFunc size of v, or 0 without a size function.
+func (tp *TypedPool[T]) sizeOf(v T) int64 {
+	if tp.cfg.sizeFn == nil {
+		return 0
+	}
+	return tp.cfg.sizeFn(v)
+}
+
+// retain adjusts the retained-bytes estimate by delta.
+func (tp *TypedPool[T]) retain(delta int64) {
+	if delta != 0 {
+		tp.stats.addRetained(delta)
+	}
+}
+
+// forget drops v from the side tables, as it leaves the pool for good.
+func (tp *TypedPool[T]) forget(v T) {
+	if tp.reuse != nil {
+		tp.reuse.forget(uintptr(itemIdentity(v)))
+	}
+	tp.markVersioned(v, false)
+	tp.forgetSum(v)
+	tp.forgetMetadata(v)
+	tp.unstampIdle(v)
+}
+
+// discard hands v to the OnDiscard hook and the WithObjectFactory Destroy
+// method, if any, or to the WithDestructorPool.
+func (tp *TypedPool[T]) discard(v T) {
+	tp.audit(auditDiscard, v)
+	tp.forget(v)
+	if dp := tp.cfg.destructor; dp != nil {
+		dp.Put(v)
+		return
+	}
+	if tp.cfg.onDiscard != nil {
+		tp.cfg.onDiscard(v)
+	}
+	if f := tp.cfg.factory; f != nil {
+		f.Destroy(v)
+	}
 }
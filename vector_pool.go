@@ -0,0 +1,114 @@
+package main
+
+import (
+	"io"
+	"net"
+)
+
+// VectorPool hands out Vectors for assembling messages as net.Buffers, so a
+// message can be written with a single writev. Both the Vectors and their
+// fixed-size chunks are pooled.
+type VectorPool struct {
+	chunkSize int
+	chunks    *TypedPool[*[]byte]
+	vectors   *TypedPool[*Vector]
+}
+
+// Vector is a message assembled from pooled chunks. It is not safe for
+// concurrent use.
+type Vector struct {
+	pool    *VectorPool
+	bufs    net.Buffers
+	chunks  []*[]byte
+	scratch net.Buffers
+	pending net.Buffers
+}
+
+// NewVectorPool creates a VectorPool whose chunks hold chunkSize bytes.
+func NewVectorPool(chunkSize int) *VectorPool {
+	vp := &VectorPool{chunkSize: chunkSize}
+	vp.chunks = NewTypedPool(func() *[]byte {
+		b := make([]byte, 0, chunkSize)
+		return &b
+	})
+	vp.vectors = NewTypedPool(func() *Vector {
+		return &Vector{pool: vp}
+	})
+	return vp
+}
+
+// Get returns an empty Vector.
+func (vp *VectorPool) Get() *Vector {
+	return vp.vectors.Get()
+}
+
+// Append reserves n bytes at the end of the message and returns them for the
+// caller to fill. Appends that fit are packed into the current chunk; larger
+// than a chunk, they get a direct allocation that is not pooled.
+func (v *Vector) Append(n int) []byte {
+	if n > v.pool.chunkSize {
+		b := make([]byte, n)
+		v.bufs = append(v.bufs, b)
+		return b
+	}
+
+	if k := len(v.chunks); k > 0 {
+		chunk := *v.chunks[k-1]
+		if last := len(v.bufs) - 1; cap(chunk)-len(chunk) >= n && sameTail(v.bufs[last], chunk) {
+			start := len(chunk)
+			chunk = chunk[:start+n]
+			*v.chunks[k-1] = chunk
+			v.bufs[last] = v.bufs[last][:len(v.bufs[last])+n]
+			return chunk[start : start+n]
+		}
+	}
+
+	c := v.pool.chunks.Get()
+	*c = (*c)[:n]
+	v.chunks = append(v.chunks, c)
+	v.bufs = append(v.bufs, *c)
+	return *c
+}
+
+// sameTail reports whether buf ends where chunk ends, i.e. buf is the most
+// recent region carved from chunk and can be extended in place.
+func sameTail(buf, chunk []byte) bool {
+	return len(buf) > 0 && len(chunk) > 0 && &buf[len(buf)-1] == &chunk[len(chunk)-1]
+}
+
+// Len returns the number of bytes in the message.
+func (v *Vector) Len() int {
+	n := 0
+	for _, b := range v.bufs {
+		n += len(b)
+	}
+	return n
+}
+
+// WriteTo writes the message to w. When w is a net.Conn that supports it,
+// the whole message goes out in a single writev. The Vector keeps its
+// contents and can be written again.
+func (v *Vector) WriteTo(w io.Writer) (int64, error) {
+	// net.Buffers.WriteTo consumes its receiver, so hand it a copy of the
+	// slice headers in pending and keep scratch intact for the next call.
+	v.scratch = append(v.scratch[:0], v.bufs...)
+	v.pending = v.scratch
+	n, err := v.pending.WriteTo(w)
+	clear(v.scratch)
+	v.pending = nil
+	return n, err
+}
+
+// Release returns the Vector and all of its pooled chunks to the pool. The
+// Vector and any slices obtained from Append must not be used afterwards.
+func (v *Vector) Release() {
+	for _, c := range v.chunks {
+		*c = (*c)[:0]
+		v.pool.chunks.Put(c)
+	}
+	clear(v.chunks)
+	v.chunks = v.chunks[:0]
+	clear(v.bufs)
+	v.bufs = v.bufs[:0]
+	v.pool.vectors.Put(v)
+}
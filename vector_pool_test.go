@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"testing"
+)
+
+func fill(b []byte, s string) { copy(b, s) }
+
+func TestVectorWriteToPipe(t *testing.T) {
+	vp := NewVectorPool(8)
+	v := vp.Get()
+	defer v.Release()
+
+	fill(v.Append(5), "hello")
+	fill(v.Append(1), " ")
+	fill(v.Append(12), "oversize-msg")
+	fill(v.Append(2), "!!")
+
+	if got := len(v.chunks); got != 2 {
+		t.Fatalf("pooled chunks = %d, want 2", got)
+	}
+	if got := len(v.bufs); got != 3 {
+		t.Fatalf("buffers = %d, want 3 (packed, oversize, packed)", got)
+	}
+
+	client, server := net.Pipe()
+	received := make(chan []byte)
+	go func() {
+		b, _ := io.ReadAll(server)
+		received <- b
+	}()
+
+	n, err := v.WriteTo(client)
+	client.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "hello oversize-msg!!"
+	if n != int64(len(want)) || v.Len() != len(want) {
+		t.Fatalf("wrote %d bytes, Len() = %d, want %d", n, v.Len(), len(want))
+	}
+	if got := string(<-received); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestVectorWriteToIsRepeatable(t *testing.T) {
+	vp := NewVectorPool(4)
+	v := vp.Get()
+	fill(v.Append(4), "abcd")
+	fill(v.Append(3), "efg")
+
+	var a, b bytes.Buffer
+	v.WriteTo(&a)
+	v.WriteTo(&b)
+	if a.String() != "abcdefg" || b.String() != "abcdefg" {
+		t.Fatalf("got %q and %q, want abcdefg twice", a.String(), b.String())
+	}
+
+	v.Release()
+	v = vp.Get()
+	if v.Len() != 0 {
+		t.Fatalf("released vector not empty: Len() = %d", v.Len())
+	}
+}
+
+var message = []string{"HEADER:", "some-key", "=", "some-value", "\r\n"}
+
+func BenchmarkVectorPool(b *testing.B) {
+	vp := NewVectorPool(4096)
+	b.ReportAllocs()
+	for b.Loop() {
+		v := vp.Get()
+		for _, part := range message {
+			fill(v.Append(len(part)), part)
+		}
+		v.WriteTo(io.Discard)
+		v.Release()
+	}
+}
+
+func BenchmarkVectorMake(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		bufs := make(net.Buffers, 0, len(message))
+		for _, part := range message {
+			chunk := make([]byte, len(part))
+			fill(chunk, part)
+			bufs = append(bufs, chunk)
+		}
+		bufs.WriteTo(io.Discard)
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// WithObjectPoolVersion stamps every item the pool constructs with version
+// v, for rolling deploys where items built by old code must not outlive it.
+// BumpVersion moves the pool on: items stamped with an older version are
+// discarded, through the OnDiscard hook, when they are Put or found idle by
+// Get, so they are replaced gradually by the constructor. Stamps live in a
+// side table keyed by address, like WithOnReuse counts, so only pointer-like
+// item types are versioned.
+func WithObjectPoolVersion[T any](v int) PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.version = &v
+	}
+}
+
+// versionEntry is one item's version stamp.
+type versionEntry struct {
+	idleGen
+	v int64
+}
+
+// itemVersions is the WithObjectPoolVersion side table.
+type itemVersions struct {
+	current atomic.Int64
+	m       sync.Map // uintptr -> *versionEntry
+}
+
+// Version returns the pool's WithObjectPoolVersion version, or 0 without
+// one.
+func (tp *TypedPool[T]) Version() int {
+	if tp.versions == nil {
+		return 0
+	}
+	return int(tp.versions.current.Load())
+}
+
+// BumpVersion increments the pool's version and returns the new one. Items
+// stamped before it are retired as they come back. It does nothing without
+// WithObjectPoolVersion.
+func (tp *TypedPool[T]) BumpVersion() int {
+	if tp.versions == nil {
+		return 0
+	}
+	return int(tp.versions.current.Add(1))
+}
+
+// stamp records that v was built at the current version.
+func (tp *TypedPool[T]) stamp(v T) {
+	if tp.versions == nil {
+		return
+	}
+	if id := uintptr(itemIdentity(v)); id != 0 {
+		tp.versions.m.Store(id, &versionEntry{v: tp.versions.current.Load()})
+	}
+}
+
+// stale reports whether v was built before the current version. Items the
+// pool did not build, or of types it cannot track, are never stale.
+func (tp *TypedPool[T]) stale(v T) bool {
+	if tp.versions == nil {
+		return false
+	}
+	e, ok := tp.versions.m.Load(uintptr(itemIdentity(v)))
+	return ok && e.(*versionEntry).v < tp.versions.current.Load()
+}
+
+// checkVersion is checkReuse for versions: it discards a stale idle item
+// and reports whether Get may return it.
+func (tp *TypedPool[T]) checkVersion(v T) bool {
+	if tp.versions == nil {
+		return true
+	}
+	id := uintptr(itemIdentity(v))
+	e, ok := tp.versions.m.Load(id)
+	if !ok {
+		return true
+	}
+	if e.(*versionEntry).v < tp.versions.current.Load() {
+		tp.discard(v)
+		return false
+	}
+	e.(*versionEntry).setIdle(false)
+	return true
+}
+
+// markVersioned notes whether v went into the store, so its stamp can be
+// dropped once the GC may have cleared it.
+func (tp *TypedPool[T]) markVersioned(v T, pooled bool) {
+	if tp.versions == nil {
+		return
+	}
+	id := uintptr(itemIdentity(v))
+	if !pooled {
+		tp.versions.m.Delete(id)
+		return
+	}
+	if e, ok := tp.versions.m.Load(id); ok {
+		e.(*versionEntry).setIdle(true)
+	}
+}
+
+// pruneVersions drops the stamps of items idle since collection cutoff, for
+// pruneGone: they are gone, and the GC may hand their addresses to new
+// items.
+func (tp *TypedPool[T]) pruneVersions(cutoff int64) {
+	if tp.versions == nil {
+		return
+	}
+	tp.versions.m.Range(func(key, value any) bool {
+		if value.(*versionEntry).gone(cutoff) {
+			tp.versions.m.Delete(key)
+		}
+		return true
+	})
+}
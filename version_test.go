@@ -0,0 +1,49 @@
+package main
+
+import "testing"
+
+func TestObjectPoolVersionRetiresOldItems(t *testing.T) {
+	type conn struct{ built int }
+	var discarded []*conn
+	pool := NewTypedPool(func() *conn { return new(conn) },
+		WithObjectPoolVersion[*conn](1),
+		WithOnDiscard(func(c *conn) { discarded = append(discarded, c) }),
+		WithFIFO[*conn](),
+		WithStats[*conn](),
+	)
+
+	idle, out := pool.Get(), pool.Get()
+	pool.Put(idle)
+	if v := pool.BumpVersion(); v != 2 || pool.Version() != 2 {
+		t.Fatalf("BumpVersion = %d, Version = %d; want 2, 2", v, pool.Version())
+	}
+
+	fresh := pool.Get()
+	if fresh == idle || len(discarded) != 1 || discarded[0] != idle {
+		t.Fatalf("Get reused the stale idle item (discarded %v)", discarded)
+	}
+	pool.Put(out)
+	if len(discarded) != 2 || discarded[1] != out {
+		t.Fatal("Put of a checked-out stale item did not discard it")
+	}
+
+	pool.Put(fresh)
+	if got := pool.Get(); got != fresh {
+		t.Fatal("an item of the current version was not reused")
+	}
+	if s := pool.Stats(); s.Misses != 3 || s.Hits != 1 {
+		t.Fatalf("hits, misses = %d, %d; want 1, 3", s.Hits, s.Misses)
+	}
+}
+
+func TestObjectPoolVersionUnset(t *testing.T) {
+	pool := NewTypedPool(func() *int { return new(int) }, WithFIFO[*int]())
+	v := pool.Get()
+	pool.Put(v)
+	if got := pool.BumpVersion(); got != 0 {
+		t.Fatalf("BumpVersion without a version = %d, want 0", got)
+	}
+	if pool.Get() != v {
+		t.Fatal("BumpVersion without a version retired an item")
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+// Warmup constructs n objects and puts them in the pool, so the first n Gets
+// are served without calling the constructor. The GC may still clear them.
+func (tp *TypedPool[T]) Warmup(n int) {
+	for i := range n {
+		tp.cfg.staggerBefore(i)
+		tp.putBack(tp.newFn())
+	}
+}
+
+// WarmupAsync runs Warmup on the pool's Scheduler, after any WithJitter delay,
+// and returns a channel that is closed once it finishes.
+func (tp *TypedPool[T]) WarmupAsync(n int) <-chan struct{} {
+	done := make(chan struct{})
+	tp.cfg.schedule(func() {
+		defer close(done)
+		tp.cfg.sleepJitter()
+		tp.Warmup(n)
+	})
+	return done
+}
+
+// Warmup constructs up to n objects and puts them in the pool, stopping early
+// once the pool is full.
+func (bp *BoundedPool[T]) Warmup(n int) {
+	for i := range n {
+		bp.cfg.staggerBefore(i)
+		bp.mu.Lock()
+		full := bp.idle.full()
+		bp.mu.Unlock()
+		if full {
+			return
+		}
+		bp.Put(bp.newFn())
+	}
+}
+
+// WarmupAsync runs Warmup on the pool's Scheduler, after any WithJitter delay,
+// and returns a channel that is closed once it finishes.
+func (bp *BoundedPool[T]) WarmupAsync(n int) <-chan struct{} {
+	done := make(chan struct{})
+	bp.cfg.schedule(func() {
+		defer close(done)
+		bp.cfg.sleepJitter()
+		bp.Warmup(n)
+	})
+	return done
+}
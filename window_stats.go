@@ -0,0 +1,115 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// The WithWindowStats ring: one-second buckets, two minutes of them.
+const (
+	windowBucket  = time.Second
+	windowBuckets = 120
+)
+
+// WithWindowStats keeps the last two minutes of the pool's counters in
+// one-second buckets, so WindowStats can report, say, the hit rate over the
+// last minute rather than since the pool was created. Buckets are rotated
+// by Get and Put as they read the pool's Clock, without a goroutine. It
+// implies WithStats.
+func WithWindowStats[T any]() PoolOption[T] {
+	return func(cfg *poolConfig[T]) {
+		cfg.stats = true
+		cfg.windowStats = true
+	}
+}
+
+// statsWindow records the cumulative counters at the start of each of the
+// last windowBuckets buckets.
+type statsWindow struct {
+	head atomic.Int64 // the current bucket, counted from the Unix epoch
+
+	mu    sync.Mutex
+	marks [windowBuckets + 1]Stats // marks[k%len] holds the counters as bucket k began
+}
+
+func newStatsWindow(now time.Time) *statsWindow {
+	w := new(statsWindow)
+	w.head.Store(now.UnixNano() / int64(windowBucket))
+	return w
+}
+
+// tick rotates the ring up to now, marking every bucket begun since the last
+// rotation with the counters read by current.
+func (w *statsWindow) tick(now time.Time, current func() Stats) {
+	if w == nil {
+		return
+	}
+	bucket := now.UnixNano() / int64(windowBucket)
+	if bucket <= w.head.Load() {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	head := w.head.Load()
+	if bucket <= head {
+		return
+	}
+	s := current()
+	for k := max(head+1, bucket-windowBuckets); k <= bucket; k++ {
+		w.marks[k%int64(len(w.marks))] = s
+	}
+	w.head.Store(bucket)
+}
+
+// since returns the counters as the bucket n-1 before the current one began.
+func (w *statsWindow) since(n int64) Stats {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	k := w.head.Load() - n + 1
+	return w.marks[k%int64(len(w.marks))]
+}
+
+// reset clears the marks along with a ResetStats.
+func (w *statsWindow) reset() {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.marks = [len(w.marks)]Stats{}
+}
+
+// WindowStats returns the counters accumulated over the last d, rounded up
+// to whole seconds and capped at two minutes, the current second included.
+// RetainedBytes is the current gauge. It returns the zero Stats unless the
+// pool was created WithWindowStats.
+func (tp *TypedPool[T]) WindowStats(d time.Duration) Stats {
+	w := tp.window
+	if w == nil {
+		return Stats{}
+	}
+	w.tick(tp.cfg.now(), tp.stats.snapshot)
+
+	n := int64((d + windowBucket - 1) / windowBucket)
+	n = min(max(n, 1), windowBuckets)
+	now, base := tp.stats.snapshot(), w.since(n)
+	return Stats{
+		Gets:     now.Gets - base.Gets,
+		Hits:     now.Hits - base.Hits,
+		Misses:   now.Misses - base.Misses,
+		Puts:     now.Puts - base.Puts,
+		Discards: now.Discards - base.Discards,
+		Prefills: now.Prefills - base.Prefills,
+
+		RetainedBytes: now.RetainedBytes,
+	}
+}
+
+// tickWindow rotates the WithWindowStats ring, if any, before a Get or Put.
+func (tp *TypedPool[T]) tickWindow() {
+	if tp.window != nil {
+		tp.window.tick(tp.cfg.now(), tp.stats.snapshot)
+	}
+}
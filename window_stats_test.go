@@ -0,0 +1,68 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ArditZubaku/go-sync-pool/pooltest"
+)
+
+func TestWindowStatsReflectsRecentPhase(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Unix(1000, 0))
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithWindowStats[*int](), WithPoolClock[*int](clock), WithFIFO[*int]())
+
+	// A cold phase: 30 seconds of misses.
+	for range 30 {
+		pool.Get()
+		clock.Advance(time.Second)
+	}
+	// A warm phase: 90 seconds of hits, two a second.
+	v := pool.Get()
+	for range 90 {
+		clock.Advance(time.Second)
+		for range 2 {
+			pool.Put(v)
+			v = pool.Get()
+		}
+	}
+
+	if s := pool.WindowStats(time.Minute); s.Hits != 120 || s.Misses != 0 || s.Puts != 120 {
+		t.Fatalf("last minute = %+v, want 120 hits and puts, no misses", s)
+	}
+	// Two minutes back from second 120 starts at second 1, so the first
+	// miss has aged out.
+	if s := pool.WindowStats(2 * time.Minute); s.Misses != 30 || s.Hits != 180 {
+		t.Fatalf("last two minutes = %+v, want 30 misses and 180 hits", s)
+	}
+	if s := pool.Stats(); s.Misses != 31 || s.Hits != 180 {
+		t.Fatalf("cumulative = %+v", s)
+	}
+
+	clock.Advance(10 * time.Minute)
+	if s := pool.WindowStats(time.Minute); s.Gets != 0 || s.Puts != 0 {
+		t.Fatalf("window after an idle stretch = %+v, want it empty", s)
+	}
+}
+
+func TestWindowStatsCurrentSecond(t *testing.T) {
+	clock := pooltest.NewFakeClock(time.Unix(1000, 0))
+	pool := NewTypedPool(func() *int { return new(int) },
+		WithWindowStats[*int](), WithPoolClock[*int](clock))
+
+	pool.Get()
+	clock.Advance(time.Second)
+	pool.Get()
+	pool.Get()
+	if s := pool.WindowStats(0); s.Gets != 2 {
+		t.Fatalf("current second Gets = %d, want 2", s.Gets)
+	}
+
+	pool.ResetStats()
+	if s := pool.WindowStats(time.Minute); s.Gets != 0 {
+		t.Fatalf("Gets after ResetStats = %d, want 0", s.Gets)
+	}
+	if s := NewTypedPool(func() *int { return new(int) }).WindowStats(time.Minute); s != (Stats{}) {
+		t.Fatalf("WindowStats without WithWindowStats = %+v", s)
+	}
+}
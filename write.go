@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"io"
+)
+
+// WithErrorHandler registers fn to be called with every error the Logger
+// gets from its writer, for call sites that ignore the returned error. In
+// async mode it is the only way to see write errors, and fn runs on the
+// flusher goroutine.
+func WithErrorHandler(fn func(error)) LoggerOption {
+	return func(l *Logger) {
+		l.onError = fn
+	}
+}
+
+// writeFull writes all of p, retrying short writes. A writer that makes no
+// progress without reporting an error yields io.ErrShortWrite.
+func writeFull(w io.Writer, p []byte) error {
+	for len(p) > 0 {
+		n, err := w.Write(p)
+		if err != nil {
+			return err
+		}
+		if n <= 0 {
+			return io.ErrShortWrite
+		}
+		p = p[n:]
+	}
+	return nil
+}
+
+// releaseWritten returns a buffer whose bytes were passed to a writer. As
+// io.Writer requires, the writer must be done with them once Write returns;
+// builds with the debug tag overwrite them with poisonByte first, so a
+// writer that keeps the slice reads poison instead of whatever line reuses
+// the buffer next.
+func releaseWritten(b *bytes.Buffer) {
+	if debugBuild {
+		poisonWritten(b.Bytes())
+	}
+	buffPool.Put(b)
+}
+
+// poisonByte marks written bytes released in debug builds.
+const poisonByte = 0xDB
+
+func poisonWritten(p []byte) {
+	for i := range p {
+		p[i] = poisonByte
+	}
+}
+
+// lineBuffer returns the buffer to render a line into for emit: the
+// Logger's output itself if it is a *bytes.Buffer, which takes the line as
+// cheaply as a pooled buffer and cannot fail part-way, and a pooled buffer
+// otherwise.
+func (l *Logger) lineBuffer() *bytes.Buffer {
+	if l.direct != nil {
+		return l.direct
+	}
+	b := buffPool.Get()
+	b.Reset()
+	return b
+}
+
+// emit hands the pooled line b to every writer taking lines at level, or
+// queues it in async mode, and gives up ownership of b. It returns the first
+// write error. A line lineBuffer rendered into the output is already
+// written.
+func (l *Logger) emit(level Level, b *bytes.Buffer) error {
+	if b == l.direct {
+		return nil
+	}
+	if l.recent != nil {
+		l.recent.record(b.Bytes())
+		if !l.Enabled(level) {
+			buffPool.Put(b)
+			return nil
+		}
+	}
+	if len(l.sinks) == 1 {
+		return l.sinks[0].write(b)
+	}
+
+	// The main writer takes every level, so some writer always owns b.
+	last := 0
+	for i, s := range l.sinks {
+		if level >= s.min {
+			last = i
+		}
+	}
+
+	var first error
+	for i, s := range l.sinks[:last+1] {
+		if level < s.min {
+			continue
+		}
+		line := b
+		if i < last {
+			line = buffPool.Get()
+			line.Reset()
+			line.Write(b.Bytes())
+		}
+		if err := s.write(line); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// handleError passes a non-nil err to the WithErrorHandler hook and returns
+// it.
+func (l *Logger) handleError(err error) error {
+	if err != nil && l.onError != nil {
+		l.onError(err)
+	}
+	return err
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"syscall"
+	"testing"
+)
+
+// shortWriter accepts at most max bytes per Write.
+type shortWriter struct {
+	bytes.Buffer
+	max   int
+	calls int
+}
+
+func (w *shortWriter) Write(p []byte) (int, error) {
+	w.calls++
+	return w.Buffer.Write(p[:min(len(p), w.max)])
+}
+
+// failingWriter accepts left bytes in total, then fails with err.
+type failingWriter struct {
+	bytes.Buffer
+	left int
+	err  error
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.left {
+		n, _ := w.Buffer.Write(p[:w.left])
+		w.left = 0
+		return n, w.err
+	}
+	w.left -= len(p)
+	return w.Buffer.Write(p)
+}
+
+// buffersInFlight reports how many buffPool buffers are checked out.
+func buffersInFlight() int64 {
+	s := buffPool.Stats()
+	return s.Gets - s.Puts
+}
+
+func TestLoggerRetriesShortWrites(t *testing.T) {
+	w := &shortWriter{max: 3}
+	logger := NewLogger(w, WithTimeLayout(""))
+	before := buffersInFlight()
+
+	if err := logger.Info("a complete line", "k", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := log(w, "legacy"); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "INFO : a complete line k=1\nTIME : legacy"; stripTime(w.String()) != want {
+		t.Fatalf("got %q, want %q", w.String(), want)
+	}
+	if w.calls < 2 {
+		t.Fatalf("got %d Write calls, want retries", w.calls)
+	}
+	if n := buffersInFlight() - before; n != 0 {
+		t.Fatalf("%d buffers leaked", n)
+	}
+}
+
+func TestLoggerSurfacesWriteErrors(t *testing.T) {
+	w := &failingWriter{left: 5, err: syscall.EPIPE}
+	var handled []error
+	logger := NewLogger(w, WithTimeLayout(""), WithErrorHandler(func(err error) {
+		handled = append(handled, err)
+	}))
+	before := buffersInFlight()
+
+	if err := logger.Info("too long"); !errors.Is(err, syscall.EPIPE) {
+		t.Fatalf("Info() = %v, want EPIPE", err)
+	}
+	if err := logger.Errorf("n=%d", 1); !errors.Is(err, syscall.EPIPE) {
+		t.Fatalf("Errorf() = %v, want EPIPE", err)
+	}
+	if err := logger.Debug("suppressed"); err != nil {
+		t.Fatalf("Debug() = %v, want nil", err)
+	}
+
+	if w.String() != "INFO " {
+		t.Fatalf("wrote %q, want the first 5 bytes", w.String())
+	}
+	if len(handled) != 2 {
+		t.Fatalf("handler saw %v, want two errors", handled)
+	}
+	if n := buffersInFlight() - before; n != 0 {
+		t.Fatalf("%d buffers leaked", n)
+	}
+}
+
+func TestWriteFullNoProgress(t *testing.T) {
+	if err := writeFull(&shortWriter{max: 0}, []byte("x")); err == nil {
+		t.Fatal("writeFull() = nil for a writer that never progresses")
+	}
+}